@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/hex"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/automaxprocs/maxprocs"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -55,7 +57,6 @@ import (
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/p2p"
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
-	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
 
@@ -66,11 +67,21 @@ type Config struct {
 	Log                     log.Config
 	Feature                 featureset.Config
 	LockFile                string
+	LockPasswordFile        string
 	NoVerify                bool
 	PrivKeyFile             string
+	PrivKeyPasswordFile     string
 	MonitoringAddr          string
+	MonitoringNamespace     string
+	ConsensusBuckets        []float64
+	ConsensusRoundTimeout   consensus.RoundTimeout
+	ConsensusBroadcastLimit float64
+	ConsensusBroadcastBurst int
+	ConsensusRecvBuffer     int
+	ConsensusSilentPeers    int
 	ValidatorAPIAddr        string
 	BeaconNodeAddrs         []string
+	BeaconNodeAPIAllowlist  []string
 	JaegerAddr              string
 	JaegerService           string
 	SimnetBMock             bool
@@ -79,6 +90,10 @@ type Config struct {
 	SimnetSlotDuration      time.Duration
 	SyntheticBlockProposals bool
 	BuilderAPI              bool
+	Observer                bool
+	MinFreeDiskMB           uint64
+	DisabledValidators      []string
+	AdminAPI                bool
 
 	TestConfig TestConfig
 }
@@ -137,6 +152,8 @@ func Run(ctx context.Context, conf Config) (err error) {
 	if !featureset.Enabled(featureset.HerumiBLS) {
 		log.Info(ctx, "Enabling Kryptology BLS signature backend")
 		tblsv2.SetImplementation(tblsv2.Kryptology{})
+	} else if !tblsv2.HerumiAvailable {
+		log.Warn(ctx, "Herumi BLS signature backend not available without cgo, falling back to Kryptology", nil)
 	}
 
 	// Wire processes and their dependencies
@@ -161,7 +178,12 @@ func Run(ctx context.Context, conf Config) (err error) {
 	p2pKey := conf.TestConfig.P2PKey
 	if p2pKey == nil {
 		var err error
-		p2pKey, err = k1util.Load(conf.PrivKeyFile)
+		password, err := p2p.LoadPrivKeyPassword(conf.PrivKeyPasswordFile)
+		if err != nil {
+			return err
+		}
+
+		p2pKey, err = k1util.LoadWithPassword(conf.PrivKeyFile, password)
 		if err != nil {
 			return errors.Wrap(err, "load priv key")
 		}
@@ -186,6 +208,10 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return errors.Wrap(err, "private key not matching lock file")
 	}
 
+	if err := verifyObserver(conf.Observer, lock, nodeIdx.PeerIdx); err != nil {
+		return err
+	}
+
 	log.Info(ctx, "Lock file loaded",
 		z.Str("peer_name", p2p.PeerName(tcpNode.ID())),
 		z.Int("peer_index", nodeIdx.PeerIdx),
@@ -193,15 +219,16 @@ func Run(ctx context.Context, conf Config) (err error) {
 		z.Str("cluster_name", lock.Name),
 		z.Int("peers", len(lock.Operators)))
 
+	logPeerNames(ctx, peers)
+
 	// Metric and logging labels.
-	labels := map[string]string{
-		"cluster_hash":    lockHashHex,
-		"cluster_name":    lock.Name,
-		"cluster_peer":    p2p.PeerName(tcpNode.ID()),
-		"cluster_network": network,
-	}
+	labels := clusterLabels(lockHashHex, lock.Name, p2p.PeerName(tcpNode.ID()), nodeIdx.PeerIdx, network)
 	log.SetLokiLabels(labels)
-	promRegistry, err := promauto.NewRegistry(labels)
+	if err := consensus.InitDurationMetric(conf.ConsensusBuckets); err != nil {
+		return err
+	}
+
+	promRegistry, err := promauto.NewRegistry(labels, promauto.WithNamespace(conf.MonitoringNamespace))
 	if err != nil {
 		return err
 	}
@@ -222,7 +249,8 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	wirePeerInfo(life, tcpNode, peerIDs, lock.LockHash, sender)
 
-	qbftDebug := newQBFTDebugger()
+	qbftDebug := newQBFTDebugger(qbftDebugMaxAge, qbftDebugMaxInstances)
+	inconsistencyDebug := new(inconsistencyDebugger)
 
 	// seenPubkeys channel to send seen public keys from validatorapi to monitoringapi.
 	seenPubkeys := make(chan core.PubKey)
@@ -246,11 +274,21 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
-	wireMonitoringAPI(ctx, life, conf.MonitoringAddr, tcpNode, eth2Cl, peerIDs,
-		promRegistry, qbftDebug, pubkeys, seenPubkeys, vapiCalls)
+	mutableConf, err := newMutableConfig(ctx, conf, pubkeys)
+	if err != nil {
+		return err
+	}
+
+	err = wireMonitoringAPI(ctx, life, conf.MonitoringAddr, tcpNode, eth2Cl, peerIDs, peerWeights(lock, peerIDs),
+		promRegistry, qbftDebug, inconsistencyDebug, pubkeys, seenPubkeys, vapiCalls, lock.ForkVersion, lock.Name,
+		newDebugConfig(conf, lock, network), filepath.Dir(conf.LockFile), conf.MinFreeDiskMB*(1<<20), mutableConf, conf.AdminAPI, conf.Observer)
+	if err != nil {
+		return err
+	}
 
 	err = wireCoreWorkflow(ctx, life, conf, lock, nodeIdx, tcpNode, p2pKey, eth2Cl,
-		peerIDs, sender, qbftDebug.AddInstance, seenPubkeysFunc, vapiCallsFunc)
+		peerIDs, sender, qbftDebug.AddInstance, inconsistencyDebug.Add, seenPubkeysFunc, vapiCallsFunc, mutableConf,
+		newLockReloader(conf, lock))
 	if err != nil {
 		return err
 	}
@@ -259,6 +297,18 @@ func Run(ctx context.Context, conf Config) (err error) {
 	return life.Run(ctx)
 }
 
+// logPeerNames logs the mapping of cluster peer IDs to their friendly names once and
+// enables enriching logged peer ID fields with the names.
+func logPeerNames(ctx context.Context, peers []p2p.Peer) {
+	names := make(map[string]string)
+	for _, p := range peers {
+		names[p.ID.String()] = p.Name
+		log.Info(ctx, "Cluster peer", z.Int("peer_index", p.Index), z.Str("peer_name", p.Name), z.Str("peer_id", p.ID.String()))
+	}
+
+	log.SetPeerNames(names)
+}
+
 // wirePeerInfo wires the peerinfo protocol.
 func wirePeerInfo(life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, lockHash []byte, sender *p2p.Sender) {
 	gitHash, _ := version.GitCommit()
@@ -311,6 +361,7 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PPing, p2p.NewPingService(tcpNode, peerIDs, conf.TestConfig.TestPingConfig))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PEventCollector, p2p.NewEventCollector(tcpNode))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PRouters, p2p.NewRelayRouter(tcpNode, peers, relays))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PDial, p2p.NewPeerDialer(tcpNode, peers))
 
 	return tcpNode, nil
 }
@@ -319,53 +370,21 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	lock cluster.Lock, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey *k1.PrivateKey,
 	eth2Cl eth2wrap.Client, peerIDs []peer.ID, sender *p2p.Sender,
-	qbftSniffer func(*pbv1.SniffedConsensusInstance), seenPubkeys func(core.PubKey),
-	vapiCalls func(),
+	qbftSniffer func(*pbv1.SniffedConsensusInstance), inconsistencyFunc func(tracker.Inconsistency),
+	seenPubkeys func(core.PubKey), vapiCalls func(), mutableConf *mutableConfig, reloader *lockReloader,
 ) error {
 	// Convert and prep public keys and public shares
-	var (
-		corePubkeys                  []core.PubKey
-		eth2Pubkeys                  []eth2p0.BLSPubKey
-		pubshares                    []eth2p0.BLSPubKey
-		allPubSharesByKey            = make(map[core.PubKey]map[int]tblsv2.PublicKey) // map[pubkey]map[shareIdx]pubshare
-		feeRecipientAddrByCorePubkey = make(map[core.PubKey]string)
-	)
-	for i, dv := range lock.Validators {
-		pubkey, err := dv.PublicKey()
-		if err != nil {
-			return err
-		}
-
-		corePubkey, err := core.PubKeyFromBytes(pubkey[:])
-		if err != nil {
-			return err
-		}
-
-		allPubShares := make(map[int]tblsv2.PublicKey)
-		for i, b := range dv.PubShares {
-			pubshare, err := tblsconv2.PubkeyFromBytes(b)
-			if err != nil {
-				return err
-			}
-
-			// share index is 1-indexed
-			allPubShares[i+1] = pubshare
-		}
-
-		pubShare, err := dv.PublicShare(nodeIdx.PeerIdx)
-		if err != nil {
-			return err
-		}
-
-		eth2Share := eth2p0.BLSPubKey(pubShare)
+	vals := newClusterValidators()
+	corePubkeys, err := vals.Update(lock)
+	if err != nil {
+		return err
+	}
 
-		eth2Pubkey := eth2p0.BLSPubKey(pubkey)
+	allPubSharesByKey := vals.PubSharesByKey(corePubkeys) // map[pubkey]map[shareIdx]pubshare
 
-		eth2Pubkeys = append(eth2Pubkeys, eth2Pubkey)
-		corePubkeys = append(corePubkeys, corePubkey)
-		pubshares = append(pubshares, eth2Share)
-		allPubSharesByKey[corePubkey] = allPubShares
-		feeRecipientAddrByCorePubkey[corePubkey] = lock.FeeRecipientAddresses()[i]
+	var pubshares []eth2p0.BLSPubKey
+	for _, pubkey := range corePubkeys {
+		pubshares = append(pubshares, eth2p0.BLSPubKey(allPubSharesByKey[pubkey][nodeIdx.ShareIdx]))
 	}
 
 	peers, err := lock.Peers()
@@ -382,28 +401,22 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return core.NewDeadliner(ctx, label, deadlineFunc)
 	}
 
-	mutableConf := newMutableConfig(ctx, conf)
-
-	sched, err := scheduler.New(corePubkeys, eth2Cl, mutableConf.BuilderAPI)
+	sched, err := scheduler.New(corePubkeys, eth2Cl, mutableConf.BuilderAPI, mutableConf.ValidatorEnabled)
 	if err != nil {
 		return err
 	}
 
-	feeRecipientFunc := func(pubkey core.PubKey) string {
-		return feeRecipientAddrByCorePubkey[pubkey]
-	}
-
-	sched.SubscribeSlots(setFeeRecipient(eth2Cl, eth2Pubkeys, feeRecipientFunc))
+	sched.SubscribeSlots(setFeeRecipient(eth2Cl, vals.Eth2Pubkeys, vals.FeeRecipient))
 	sched.SubscribeSlots(tracker.NewInclDelayFunc(eth2Cl, sched.GetDutyDefinition))
 
-	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc)
+	fetch, err := fetcher.New(eth2Cl, vals.FeeRecipient, vals.Graffiti)
 	if err != nil {
 		return err
 	}
 
 	dutyDB := dutydb.NewMemDB(deadlinerFunc("dutydb"))
 
-	vapi, err := validatorapi.NewComponent(eth2Cl, allPubSharesByKey, nodeIdx.ShareIdx, feeRecipientFunc,
+	vapi, err := validatorapi.NewComponent(eth2Cl, allPubSharesByKey, nodeIdx.ShareIdx, vals.FeeRecipient,
 		mutableConf.BuilderAPI, seenPubkeys)
 	if err != nil {
 		return err
@@ -419,7 +432,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	if conf.TestConfig.ParSigExFunc != nil {
 		parSigEx = conf.TestConfig.ParSigExFunc()
 	} else {
-		verifyFunc, err := parsigex.NewEth2Verifier(eth2Cl, allPubSharesByKey)
+		verifyFunc, err := parsigex.NewEth2VerifierFunc(eth2Cl, vals.PubShares)
 		if err != nil {
 			return err
 		}
@@ -431,15 +444,20 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 
 	aggSigDB := aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
 
-	broadcaster, err := bcast.New(ctx, eth2Cl)
+	broadcaster, err := bcast.New(ctx, eth2Cl, vals.BuilderRelays)
 	if err != nil {
 		return err
 	}
 
 	retryer := retry.New[core.Duty](deadlineFunc)
 
+	slotDuration, err := eth2Cl.SlotDuration(ctx)
+	if err != nil {
+		return err
+	}
+
 	cons, startCons, err := newConsensus(conf, lock, tcpNode, p2pKey, sender,
-		nodeIdx, deadlinerFunc("consensus"), qbftSniffer)
+		nodeIdx, deadlinerFunc("consensus"), qbftSniffer, slotDuration)
 	if err != nil {
 		return err
 	}
@@ -452,7 +470,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 
 	wireRecaster(sched, sigAgg, broadcaster)
 
-	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl)
+	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl, inconsistencyFunc)
 	if err != nil {
 		return err
 	}
@@ -461,6 +479,10 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		core.WithTracking(track),
 		core.WithAsyncRetry(retryer),
 	}
+	if conf.Observer {
+		log.Info(ctx, "Running in observer mode, partial signatures will not be produced")
+		opts = append(opts, core.WithObserver()) // Last, so dropped partial signatures are not tracked.
+	}
 	core.Wire(sched, fetch, cons, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, broadcaster, opts...)
 
 	err = wireValidatorMock(conf, pubshares, sched)
@@ -472,7 +494,34 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		sigAgg.Subscribe(conf.TestConfig.BroadcastCallback)
 	}
 
+	// Apply validators added to the cluster lock on reload.
+	reloader.Subscribe(func(ctx context.Context, prev, next cluster.Lock) (func(), error) {
+		update, err := vals.Prepare(prev, next)
+		if err != nil {
+			return nil, err
+		}
+
+		addToVAPI, err := vapi.PrepareValidators(update.PubSharesByKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() {
+			update.Commit()
+			if len(update.Added) == 0 {
+				return
+			}
+
+			addToVAPI()
+			mutableConf.AddValidators(update.Added)
+			sched.AddPubkeys(update.Added)
+
+			log.Info(ctx, "Added validators from reloaded cluster lock", z.Int("added", len(update.Added)))
+		}, nil
+	})
+
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartScheduler, lifecycle.HookFuncErr(sched.Run))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartLockReloader, lifecycle.HookFuncCtx(reloader.Run))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PConsensus, startCons)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartAggSigDB, lifecycle.HookFuncCtx(aggSigDB.Run))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartParSigDB, lifecycle.HookFuncCtx(parSigDB.Trim))
@@ -546,7 +595,7 @@ func wireRecaster(sched core.Scheduler, sigAgg core.SigAgg, broadcaster core.Bro
 
 // newTracker creates and starts a new tracker instance.
 func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	peers []p2p.Peer, eth2Cl eth2wrap.Client,
+	peers []p2p.Peer, eth2Cl eth2wrap.Client, inconsistencyFunc func(tracker.Inconsistency),
 ) (core.Tracker, error) {
 	slotDuration, err := eth2Cl.SlotDuration(ctx)
 	if err != nil {
@@ -567,7 +616,16 @@ func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(
 		return nil, err
 	}
 
+	genesisTime, err := eth2Cl.GenesisTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	track := tracker.New(analyser, deleter, peers, trackFrom)
+	track.SetDutyStartFunc(func(duty core.Duty) (time.Time, bool) {
+		return genesisTime.Add(slotDuration * time.Duration(duty.Slot)), true
+	})
+	track.SetInconsistencyFunc(inconsistencyFunc)
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartTracker, lifecycle.HookFunc(track.Run))
 
 	return track, nil
@@ -668,7 +726,7 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager,
 		return nil, errors.New("beacon node endpoints empty")
 	}
 
-	eth2Cl, err := eth2wrap.NewMultiHTTP(ctx, eth2ClientTimeout, conf.BeaconNodeAddrs...)
+	eth2Cl, err := eth2wrap.NewMultiHTTPWithAllowlist(ctx, eth2ClientTimeout, conf.BeaconNodeAPIAllowlist, conf.BeaconNodeAddrs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "new eth2 http client")
 	}
@@ -700,7 +758,7 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager,
 // newConsensus returns a new consensus component and its start lifecycle hook.
 func newConsensus(conf Config, lock cluster.Lock, tcpNode host.Host, p2pKey *k1.PrivateKey,
 	sender *p2p.Sender, nodeIdx cluster.NodeIdx, deadliner core.Deadliner,
-	qbftSniffer func(*pbv1.SniffedConsensusInstance),
+	qbftSniffer func(*pbv1.SniffedConsensusInstance), slotDuration time.Duration,
 ) (core.Consensus, lifecycle.IHookFunc, error) {
 	peers, err := lock.Peers()
 	if err != nil {
@@ -717,6 +775,40 @@ func newConsensus(conf Config, lock cluster.Lock, tcpNode host.Host, p2pKey *k1.
 			return nil, nil, err
 		}
 
+		if conf.ConsensusRoundTimeout != (consensus.RoundTimeout{}) {
+			if err := conf.ConsensusRoundTimeout.Validate(); err != nil {
+				return nil, nil, err
+			}
+			comp.SetRoundTimeout(conf.ConsensusRoundTimeout)
+		}
+
+		if conf.ConsensusBroadcastLimit != 0 || conf.ConsensusBroadcastBurst != 0 {
+			if conf.ConsensusBroadcastLimit <= 0 || conf.ConsensusBroadcastBurst <= 0 {
+				return nil, nil, errors.New("consensus broadcast limit and burst must be positive",
+					z.F64("limit", conf.ConsensusBroadcastLimit), z.Int("burst", conf.ConsensusBroadcastBurst))
+			}
+			comp.SetBroadcastLimit(conf.ConsensusBroadcastLimit, conf.ConsensusBroadcastBurst)
+		}
+
+		if conf.ConsensusRecvBuffer < 0 {
+			return nil, nil, errors.New("consensus receive buffer size must be positive", z.Int("size", conf.ConsensusRecvBuffer))
+		} else if conf.ConsensusRecvBuffer > 0 {
+			comp.SetRecvBufferSize(conf.ConsensusRecvBuffer)
+		}
+
+		if conf.ConsensusSilentPeers < 0 {
+			return nil, nil, errors.New("consensus silent peer instances must be positive", z.Int("instances", conf.ConsensusSilentPeers))
+		} else if conf.ConsensusSilentPeers > 0 {
+			comp.SetSilentPeerInstances(conf.ConsensusSilentPeers)
+		}
+
+		if err := comp.SetPeerWeights(operatorWeights(lock), lock.Threshold); err != nil {
+			return nil, nil, err
+		}
+		comp.SetMaxActiveInstances(maxActiveConsensusInstances(len(lock.Validators), slotDuration))
+		comp.SetValueValidator(validateConsensusValue)
+		comp.SetDecisionSLA(slotDuration) // Duties should be decided within a slot.
+
 		return comp, lifecycle.HookFuncCtx(comp.Start), nil
 	}
 
@@ -733,6 +825,109 @@ func newConsensus(conf Config, lock cluster.Lock, tcpNode host.Host, p2pKey *k1.
 	return lcast, lifecycle.HookFuncCtx(lcast.Run), nil
 }
 
+// consensusDutyTypes are the duty types decided by consensus, with one instance per duty.
+var consensusDutyTypes = []core.DutyType{
+	core.DutyProposer,
+	core.DutyBuilderProposer,
+	core.DutyAttester,
+	core.DutyAggregator,
+	core.DutySyncContribution,
+}
+
+// maxActiveConsensusInstances returns the expected bound of concurrently active consensus instances.
+// Instances are per duty and active until the duty deadline, so each slot has at most one instance
+// per consensus duty type, limited by the duties of the validators, for all slots until the deadline.
+func maxActiveConsensusInstances(numValidators int, slotDuration time.Duration) int {
+	// A single validator may have all consensus duties of a slot, except both proposer types.
+	perSlot := numValidators * (len(consensusDutyTypes) - 1)
+	if perSlot > len(consensusDutyTypes) {
+		perSlot = len(consensusDutyTypes)
+	}
+
+	activeSlots := int(core.LateDelta(slotDuration)/slotDuration) + 1
+
+	return perSlot * activeSlots
+}
+
+// verifyObserver returns an error if observer mode doesn't match the operator's observer flag in the lock,
+// since all nodes must agree on the non-voting observers to calculate the same quorums.
+func verifyObserver(observer bool, lock cluster.Lock, peerIdx int) error {
+	if lock.Operators[peerIdx].Observer == observer {
+		return nil
+	} else if observer {
+		return errors.New("observer mode requires the operator to be marked as observer in the cluster lock, see --num-observers")
+	}
+
+	return errors.New("operator is marked as observer in the cluster lock, enable observer mode via --observer")
+}
+
+// operatorWeights returns the explicit voting weights of the cluster operators by index.
+// Operators without an explicit weight are omitted and default to a weight of 1.
+func operatorWeights(lock cluster.Lock) map[int64]int {
+	weights := make(map[int64]int)
+	for i, op := range lock.Operators {
+		if op.VotingWeight() != 1 {
+			weights[int64(i)] = op.VotingWeight()
+		}
+	}
+
+	return weights
+}
+
+// peerWeights returns the voting weights of the cluster peers by peer ID.
+func peerWeights(lock cluster.Lock, peerIDs []peer.ID) map[peer.ID]int {
+	weights := make(map[peer.ID]int)
+	for i, pID := range peerIDs {
+		weights[pID] = lock.Operators[i].VotingWeight()
+	}
+
+	return weights
+}
+
+// validateConsensusValue is a sanity check of proposed consensus values rejecting proposals
+// with invalid duty types, malformed unsigned data or unsigned data of a different slot than the duty.
+func validateConsensusValue(_ context.Context, duty core.Duty, value proto.Message) error {
+	if !duty.Type.Valid() {
+		return errors.New("invalid duty type", z.Any("duty", duty))
+	}
+
+	setPB, ok := value.(*pbv1.UnsignedDataSet)
+	if !ok {
+		return nil // Only unsigned data sets are validated.
+	}
+
+	set, err := core.UnsignedDataSetFromProto(duty.Type, setPB)
+	if err != nil {
+		return errors.Wrap(err, "malformed unsigned data set")
+	}
+
+	for pubkey, data := range set {
+		var slot eth2p0.Slot
+		switch d := data.(type) {
+		case core.AttestationData:
+			slot = d.Data.Slot
+		case core.AggregatedAttestation:
+			slot = d.Data.Slot
+		case core.SyncContribution:
+			slot = d.Slot
+		case core.VersionedBeaconBlock:
+			slot, err = d.Slot()
+		case core.VersionedBlindedBeaconBlock:
+			slot, err = d.Slot()
+		default:
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "unsigned data slot", z.Any("pubkey", pubkey))
+		} else if int64(slot) != duty.Slot {
+			return errors.New("unsigned data slot mismatch", z.Any("duty", duty),
+				z.Any("pubkey", pubkey), z.U64("slot", uint64(slot)))
+		}
+	}
+
+	return nil
+}
+
 // createMockValidators creates mock validators identified by their public shares.
 func createMockValidators(pubkeys []eth2p0.BLSPubKey) beaconmock.ValidatorSet {
 	resp := make(beaconmock.ValidatorSet)
@@ -797,7 +992,7 @@ func wireTracing(life *lifecycle.Manager, conf Config) error {
 
 // setFeeRecipient returns a slot subscriber for scheduler which calls prepare_beacon_proposer endpoint at start of each epoch.
 // TODO(dhruv): move this somewhere else once more use-cases like this becomes clear.
-func setFeeRecipient(eth2Cl eth2wrap.Client, pubkeys []eth2p0.BLSPubKey, feeRecipientFunc func(core.PubKey) string) func(ctx context.Context, slot core.Slot) error {
+func setFeeRecipient(eth2Cl eth2wrap.Client, pubkeysFunc func() []eth2p0.BLSPubKey, feeRecipientFunc func(core.PubKey) string) func(ctx context.Context, slot core.Slot) error {
 	onStartup := true
 
 	return func(ctx context.Context, slot core.Slot) error {
@@ -808,7 +1003,7 @@ func setFeeRecipient(eth2Cl eth2wrap.Client, pubkeys []eth2p0.BLSPubKey, feeReci
 		onStartup = false
 
 		// TODO(corver): Use cache instead of using head to try to mitigate this expensive call.
-		vals, err := eth2Cl.ValidatorsByPubKey(ctx, "head", pubkeys)
+		vals, err := eth2Cl.ValidatorsByPubKey(ctx, "head", pubkeysFunc())
 		if err != nil {
 			return err
 		}
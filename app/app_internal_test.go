@@ -0,0 +1,122 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestValidateConsensusValue(t *testing.T) {
+	ctx := context.Background()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	newSet := func(slot eth2p0.Slot) *pbv1.UnsignedDataSet {
+		t.Helper()
+
+		attData := testutil.RandomCoreAttestationData(t)
+		attData.Data.Slot = slot
+
+		setPB, err := core.UnsignedDataSetToProto(core.UnsignedDataSet{pubkey: attData})
+		require.NoError(t, err)
+
+		return setPB
+	}
+
+	duty := core.NewAttesterDuty(99)
+
+	require.NoError(t, validateConsensusValue(ctx, duty, newSet(99)))
+	require.NoError(t, validateConsensusValue(ctx, duty, &pbv1.PriorityResult{}))
+
+	err := validateConsensusValue(ctx, duty, newSet(100))
+	require.ErrorContains(t, err, "unsigned data slot mismatch")
+
+	err = validateConsensusValue(ctx, core.Duty{Slot: 99}, newSet(99))
+	require.ErrorContains(t, err, "invalid duty type")
+
+	err = validateConsensusValue(ctx, duty, &pbv1.UnsignedDataSet{Set: map[string][]byte{string(pubkey): []byte("{")}})
+	require.ErrorContains(t, err, "malformed unsigned data set")
+}
+
+func TestOperatorWeights(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 1, 5, 7, 0, cluster.WithVersion("v1.6.0"), func(def *cluster.Definition) {
+		def.Operators[0].Weight = 2
+	})
+
+	// Weights are part of the hashed cluster lock.
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	var loaded cluster.Lock
+	require.NoError(t, json.Unmarshal(b, &loaded))
+	require.NoError(t, loaded.VerifyHashes())
+	require.Equal(t, 2, loaded.Operators[0].Weight)
+
+	tampered := loaded
+	tampered.Operators = append([]cluster.Operator(nil), loaded.Operators...)
+	tampered.Operators[1].Weight = 2
+	require.Error(t, tampered.VerifyHashes())
+
+	// Consensus quorum is weighted.
+	weights := operatorWeights(loaded)
+	require.Equal(t, map[int64]int{0: 2}, weights)
+	def := qbft.Definition[core.Duty, [32]byte]{Nodes: 7, Weights: weights}
+	require.Equal(t, 6, def.Quorum())
+
+	// Readiness quorum is weighted.
+	peerIDs, err := loaded.PeerIDs()
+	require.NoError(t, err)
+	require.Equal(t, 2, peerWeights(loaded, peerIDs)[peerIDs[0]])
+	require.Equal(t, 1, peerWeights(loaded, peerIDs)[peerIDs[1]])
+}
+
+func TestObserverOperator(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 1, 3, 5, 0, cluster.WithVersion("v1.6.0"), func(def *cluster.Definition) {
+		def.Operators[4].Observer = true
+	})
+	require.NoError(t, lock.VerifyHashes())
+	require.NoError(t, cluster.VerifyVoting(lock.Operators, lock.Threshold))
+
+	// Observers don't vote.
+	weights := operatorWeights(lock)
+	require.Equal(t, map[int64]int{4: 0}, weights)
+	def := qbft.Definition[core.Duty, [32]byte]{Nodes: 5, Weights: weights}
+	require.Equal(t, 3, def.Quorum())
+
+	peerIDs, err := lock.PeerIDs()
+	require.NoError(t, err)
+	require.Equal(t, 0, peerWeights(lock, peerIDs)[peerIDs[4]])
+
+	// Observer mode must match the lock.
+	require.NoError(t, verifyObserver(true, lock, 4))
+	require.NoError(t, verifyObserver(false, lock, 0))
+	require.ErrorContains(t, verifyObserver(false, lock, 4), "operator is marked as observer")
+	require.ErrorContains(t, verifyObserver(true, lock, 0), "observer mode requires the operator to be marked as observer")
+
+	// Observers can't vote with weights nor be required for the signing threshold.
+	lock.Operators[4].Weight = 2
+	require.ErrorContains(t, cluster.VerifyVoting(lock.Operators, lock.Threshold), "observer operator with voting weight")
+	lock.Operators[4].Weight = 0
+	require.ErrorContains(t, cluster.VerifyVoting(lock.Operators, 5), "insufficient non-observer operators")
+}
+
+func TestMaxActiveConsensusInstances(t *testing.T) {
+	// Mainnet deadlines are 5 slots late, so 6 slots of instances are active.
+	require.Equal(t, 4*6, maxActiveConsensusInstances(1, 12*time.Second))
+	require.Equal(t, 5*6, maxActiveConsensusInstances(2, 12*time.Second))
+	require.Equal(t, 5*6, maxActiveConsensusInstances(1000, 12*time.Second))
+
+	// Simnet deadlines are at least 30s late.
+	require.Equal(t, 5*31, maxActiveConsensusInstances(10, time.Second))
+}
@@ -4,7 +4,6 @@ package app
 
 import (
 	"context"
-	"encoding/json"
 	"os"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -12,7 +11,7 @@ import (
 	"github.com/obolnetwork/charon/cluster"
 )
 
-// loadLock reads the cluster lock from the given file path.
+// loadLock reads the cluster lock from the given file path, decrypting it if encrypted.
 func loadLock(ctx context.Context, conf Config) (cluster.Lock, error) {
 	if conf.TestConfig.Lock != nil {
 		return *conf.TestConfig.Lock, nil
@@ -23,10 +22,14 @@ func loadLock(ctx context.Context, conf Config) (cluster.Lock, error) {
 		return cluster.Lock{}, errors.Wrap(err, "read lock")
 	}
 
-	var lock cluster.Lock
-	err = json.Unmarshal(buf, &lock)
+	password, err := cluster.LoadLockPassword(conf.LockPasswordFile)
 	if err != nil {
-		return cluster.Lock{}, errors.Wrap(err, "unmarshal lock")
+		return cluster.Lock{}, err
+	}
+
+	lock, err := cluster.DecryptLock(buf, password)
+	if err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "load lock")
 	}
 
 	if err := lock.VerifyHashes(); err != nil && !conf.NoVerify {
@@ -41,5 +44,9 @@ func loadLock(ctx context.Context, conf Config) (cluster.Lock, error) {
 		log.Warn(ctx, "Ignoring failed cluster lock signature verification due to --no-verify flag", err)
 	}
 
+	if err := cluster.VerifyVoting(lock.Operators, lock.Threshold); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "invalid cluster lock voting configuration")
+	}
+
 	return lock, nil
 }
@@ -5,6 +5,7 @@ package eth2wrap
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/url"
 	"sync"
@@ -58,6 +59,36 @@ func Instrument(clients ...Client) (Client, error) {
 	return newMulti(clients), nil
 }
 
+// InstrumentWithAllowlist returns a new multi instrumented client like Instrument, but only
+// allowing calls to the beacon API methods in the allowlist (e.g. "attestation_data").
+// Calls to other methods return an error without reaching the beacon nodes.
+// An empty allowlist allows all methods, unknown methods in the allowlist return an error.
+func InstrumentWithAllowlist(allowlist []string, clients ...Client) (Client, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("clients empty")
+	}
+
+	m := newMulti(clients)
+	if len(allowlist) > 0 {
+		known := make(map[string]bool)
+		for _, labels := range [][]string{genMethodLabels, customMethodLabels} {
+			for _, label := range labels {
+				known[label] = true
+			}
+		}
+
+		m.allowed = make(map[string]bool)
+		for _, label := range allowlist {
+			if !known[label] {
+				return nil, errors.New("unknown beacon api method in allowlist", z.Str("method", label))
+			}
+			m.allowed[label] = true
+		}
+	}
+
+	return m, nil
+}
+
 // WithSyntheticDuties wraps the provided client adding synthetic duties.
 func WithSyntheticDuties(cl Client, pubkeys []eth2p0.BLSPubKey) Client {
 	return &synthWrapper{
@@ -69,6 +100,12 @@ func WithSyntheticDuties(cl Client, pubkeys []eth2p0.BLSPubKey) Client {
 
 // NewMultiHTTP returns a new instrumented multi eth2 http client.
 func NewMultiHTTP(ctx context.Context, timeout time.Duration, addresses ...string) (Client, error) {
+	return NewMultiHTTPWithAllowlist(ctx, timeout, nil, addresses...)
+}
+
+// NewMultiHTTPWithAllowlist returns a new instrumented multi eth2 http client
+// only allowing calls to the beacon API methods in the allowlist, see InstrumentWithAllowlist.
+func NewMultiHTTPWithAllowlist(ctx context.Context, timeout time.Duration, allowlist []string, addresses ...string) (Client, error) {
 	var clients []Client
 	for _, address := range addresses {
 		address := address // Capture range variable.
@@ -93,16 +130,24 @@ func NewMultiHTTP(ctx context.Context, timeout time.Duration, addresses ...strin
 		clients = append(clients, cl)
 	}
 
-	return Instrument(clients...)
+	return InstrumentWithAllowlist(allowlist, clients...)
 }
 
-func newMulti(clients []Client) Client {
+func newMulti(clients []Client) multi {
 	return multi{
 		clients:  clients,
 		selector: newBestSelector(len(clients), bestPeriod),
 	}
 }
 
+// customMethodLabels are the labels of the multi client methods not generated by genwrap.
+var customMethodLabels = []string{
+	"aggregate_beacon_committee_selections",
+	"aggregate_sync_committee_selections",
+	"block_attestations",
+	"node_peer_count",
+}
+
 // multi implements Client by wrapping multiple clients, calling them in parallel
 // and returning the first successful response.
 // It also adds prometheus metrics and error wrapping.
@@ -110,6 +155,16 @@ func newMulti(clients []Client) Client {
 type multi struct {
 	clients  []Client
 	selector *bestSelector
+	allowed  map[string]bool // Allowed method labels, nil allows all.
+}
+
+// allow returns an error if the method label isn't allowed.
+func (m multi) allow(label string) error {
+	if m.allowed == nil || m.allowed[label] {
+		return nil
+	}
+
+	return errors.New("beacon api method not allowed", z.Str("method", label))
 }
 
 // bestIdx increments the selector with the best client index.
@@ -129,6 +184,10 @@ func (m multi) AggregateBeaconCommitteeSelections(ctx context.Context, selection
 	const label = "aggregate_beacon_committee_selections"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return nil, err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*eth2exp.BeaconCommitteeSelection, error) {
 			return cl.AggregateBeaconCommitteeSelections(ctx, selections)
@@ -147,6 +206,10 @@ func (m multi) AggregateSyncCommitteeSelections(ctx context.Context, selections
 	const label = "aggregate_sync_committee_selections"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return nil, err
+	}
+
 	res, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*eth2exp.SyncCommitteeSelection, error) {
 			return cl.AggregateSyncCommitteeSelections(ctx, selections)
@@ -165,6 +228,10 @@ func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	const label = "block_attestations"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return nil, err
+	}
+
 	res, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*eth2p0.Attestation, error) {
 			return cl.BlockAttestations(ctx, stateID)
@@ -183,6 +250,10 @@ func (m multi) NodePeerCount(ctx context.Context) (int, error) {
 	const label = "node_peer_count"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return 0, err
+	}
+
 	res, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (int, error) {
 			return cl.NodePeerCount(ctx)
@@ -284,7 +355,7 @@ func incError(endpoint string) {
 func wrapError(ctx context.Context, err error, label string) error {
 	// Decompose go-eth2-client http errors
 	if e2err := new(eth2http.Error); errors.As(err, e2err) {
-		err = errors.New("nok http response",
+		err = errors.Wrap(httpStatusError(e2err.StatusCode), "nok http response",
 			z.Int("status_code", e2err.StatusCode),
 			z.Str("endpoint", e2err.Endpoint),
 			z.Str("method", e2err.Method),
@@ -320,6 +391,27 @@ func wrapError(ctx context.Context, err error, label string) error {
 	return errors.Wrap(err, "beacon api "+label, z.Str("label", label))
 }
 
+// httpStatusError is the status code of a nok beacon node http response.
+type httpStatusError int
+
+func (e httpStatusError) Error() string {
+	return fmt.Sprintf("status %d", int(e))
+}
+
+// StatusCode returns the http status code and true if the error resulted from a nok beacon node http response.
+func StatusCode(err error) (int, bool) {
+	if e2err := new(eth2http.Error); errors.As(err, e2err) {
+		return e2err.StatusCode, true
+	}
+
+	var statusErr httpStatusError
+	if errors.As(err, &statusErr) {
+		return int(statusErr), true
+	}
+
+	return 0, false
+}
+
 // newBestSelector returns a new bestSelector.
 func newBestSelector(n int, period time.Duration) *bestSelector {
 	return &bestSelector{
@@ -61,11 +61,55 @@ type Client interface {
 	eth2client.VoluntaryExitSubmitter
 }
 
+// genMethodLabels are the labels of the generated multi client methods.
+var genMethodLabels = []string{
+	"node_version",
+	"slot_duration",
+	"slots_per_epoch",
+	"deposit_contract",
+	"signed_beacon_block",
+	"aggregate_attestation",
+	"submit_aggregate_attestations",
+	"attestation_data",
+	"submit_attestations",
+	"attester_duties",
+	"sync_committee_duties",
+	"submit_sync_committee_messages",
+	"submit_sync_committee_subscriptions",
+	"sync_committee_contribution",
+	"submit_sync_committee_contributions",
+	"beacon_block_proposal",
+	"beacon_block_root",
+	"submit_beacon_block",
+	"submit_beacon_committee_subscriptions",
+	"blinded_beacon_block_proposal",
+	"submit_blinded_beacon_block",
+	"submit_validator_registrations",
+	"events",
+	"fork",
+	"fork_schedule",
+	"genesis",
+	"node_syncing",
+	"submit_proposal_preparations",
+	"proposer_duties",
+	"spec",
+	"validators",
+	"validators_by_pub_key",
+	"submit_voluntary_exit",
+	"domain",
+	"genesis_domain",
+	"genesis_time",
+}
+
 // NodeVersion returns a free-text string with the node version.
 // Note this endpoint is cached in go-eth2-client.
 func (m multi) NodeVersion(ctx context.Context) (string, error) {
 	const label = "node_version"
 
+	if err := m.allow(label); err != nil {
+		return *new(string), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (string, error) {
 			return cl.NodeVersion(ctx)
@@ -86,6 +130,10 @@ func (m multi) NodeVersion(ctx context.Context) (string, error) {
 func (m multi) SlotDuration(ctx context.Context) (time.Duration, error) {
 	const label = "slot_duration"
 
+	if err := m.allow(label); err != nil {
+		return *new(time.Duration), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (time.Duration, error) {
 			return cl.SlotDuration(ctx)
@@ -106,6 +154,10 @@ func (m multi) SlotDuration(ctx context.Context) (time.Duration, error) {
 func (m multi) SlotsPerEpoch(ctx context.Context) (uint64, error) {
 	const label = "slots_per_epoch"
 
+	if err := m.allow(label); err != nil {
+		return *new(uint64), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (uint64, error) {
 			return cl.SlotsPerEpoch(ctx)
@@ -126,6 +178,10 @@ func (m multi) SlotsPerEpoch(ctx context.Context) (uint64, error) {
 func (m multi) DepositContract(ctx context.Context) (*apiv1.DepositContract, error) {
 	const label = "deposit_contract"
 
+	if err := m.allow(label); err != nil {
+		return *new(*apiv1.DepositContract), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*apiv1.DepositContract, error) {
 			return cl.DepositContract(ctx)
@@ -146,6 +202,10 @@ func (m multi) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.Ver
 	const label = "signed_beacon_block"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*spec.VersionedSignedBeaconBlock), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*spec.VersionedSignedBeaconBlock, error) {
 			return cl.SignedBeaconBlock(ctx, blockID)
@@ -166,6 +226,10 @@ func (m multi) AggregateAttestation(ctx context.Context, slot phase0.Slot, attes
 	const label = "aggregate_attestation"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*phase0.Attestation), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*phase0.Attestation, error) {
 			return cl.AggregateAttestation(ctx, slot, attestationDataRoot)
@@ -186,6 +250,10 @@ func (m multi) SubmitAggregateAttestations(ctx context.Context, aggregateAndProo
 	const label = "submit_aggregate_attestations"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitAggregateAttestations(ctx, aggregateAndProofs)
@@ -206,6 +274,10 @@ func (m multi) AttestationData(ctx context.Context, slot phase0.Slot, committeeI
 	const label = "attestation_data"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*phase0.AttestationData), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*phase0.AttestationData, error) {
 			return cl.AttestationData(ctx, slot, committeeIndex)
@@ -226,6 +298,10 @@ func (m multi) SubmitAttestations(ctx context.Context, attestations []*phase0.At
 	const label = "submit_attestations"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitAttestations(ctx, attestations)
@@ -247,6 +323,10 @@ func (m multi) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validator
 	const label = "attester_duties"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new([]*apiv1.AttesterDuty), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*apiv1.AttesterDuty, error) {
 			return cl.AttesterDuties(ctx, epoch, validatorIndices)
@@ -268,6 +348,10 @@ func (m multi) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, vali
 	const label = "sync_committee_duties"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new([]*apiv1.SyncCommitteeDuty), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*apiv1.SyncCommitteeDuty, error) {
 			return cl.SyncCommitteeDuties(ctx, epoch, validatorIndices)
@@ -288,6 +372,10 @@ func (m multi) SubmitSyncCommitteeMessages(ctx context.Context, messages []*alta
 	const label = "submit_sync_committee_messages"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitSyncCommitteeMessages(ctx, messages)
@@ -308,6 +396,10 @@ func (m multi) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptio
 	const label = "submit_sync_committee_subscriptions"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
@@ -328,6 +420,10 @@ func (m multi) SyncCommitteeContribution(ctx context.Context, slot phase0.Slot,
 	const label = "sync_committee_contribution"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*altair.SyncCommitteeContribution), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*altair.SyncCommitteeContribution, error) {
 			return cl.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
@@ -348,6 +444,10 @@ func (m multi) SubmitSyncCommitteeContributions(ctx context.Context, contributio
 	const label = "submit_sync_committee_contributions"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
@@ -368,6 +468,10 @@ func (m multi) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randao
 	const label = "beacon_block_proposal"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*spec.VersionedBeaconBlock), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*spec.VersionedBeaconBlock, error) {
 			return cl.BeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
@@ -388,6 +492,10 @@ func (m multi) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randao
 func (m multi) BeaconBlockRoot(ctx context.Context, blockID string) (*phase0.Root, error) {
 	const label = "beacon_block_root"
 
+	if err := m.allow(label); err != nil {
+		return *new(*phase0.Root), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*phase0.Root, error) {
 			return cl.BeaconBlockRoot(ctx, blockID)
@@ -408,6 +516,10 @@ func (m multi) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSigne
 	const label = "submit_beacon_block"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitBeaconBlock(ctx, block)
@@ -428,6 +540,10 @@ func (m multi) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscript
 	const label = "submit_beacon_committee_subscriptions"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
@@ -448,6 +564,10 @@ func (m multi) BlindedBeaconBlockProposal(ctx context.Context, slot phase0.Slot,
 	const label = "blinded_beacon_block_proposal"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*api.VersionedBlindedBeaconBlock), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*api.VersionedBlindedBeaconBlock, error) {
 			return cl.BlindedBeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
@@ -468,6 +588,10 @@ func (m multi) SubmitBlindedBeaconBlock(ctx context.Context, block *api.Versione
 	const label = "submit_blinded_beacon_block"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitBlindedBeaconBlock(ctx, block)
@@ -488,6 +612,10 @@ func (m multi) SubmitValidatorRegistrations(ctx context.Context, registrations [
 	const label = "submit_validator_registrations"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitValidatorRegistrations(ctx, registrations)
@@ -508,6 +636,10 @@ func (m multi) Events(ctx context.Context, topics []string, handler eth2client.E
 	const label = "events"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.Events(ctx, topics, handler)
@@ -528,6 +660,10 @@ func (m multi) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
 	const label = "fork"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*phase0.Fork), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*phase0.Fork, error) {
 			return cl.Fork(ctx, stateID)
@@ -548,6 +684,10 @@ func (m multi) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
 	const label = "fork_schedule"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new([]*phase0.Fork), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*phase0.Fork, error) {
 			return cl.ForkSchedule(ctx)
@@ -568,6 +708,10 @@ func (m multi) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
 func (m multi) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
 	const label = "genesis"
 
+	if err := m.allow(label); err != nil {
+		return *new(*apiv1.Genesis), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*apiv1.Genesis, error) {
 			return cl.Genesis(ctx)
@@ -588,6 +732,10 @@ func (m multi) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
 	const label = "node_syncing"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(*apiv1.SyncState), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (*apiv1.SyncState, error) {
 			return cl.NodeSyncing(ctx)
@@ -609,6 +757,10 @@ func (m multi) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
 func (m multi) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
 	const label = "submit_proposal_preparations"
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitProposalPreparations(ctx, preparations)
@@ -630,6 +782,10 @@ func (m multi) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validator
 	const label = "proposer_duties"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new([]*apiv1.ProposerDuty), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) ([]*apiv1.ProposerDuty, error) {
 			return cl.ProposerDuties(ctx, epoch, validatorIndices)
@@ -650,6 +806,10 @@ func (m multi) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validator
 func (m multi) Spec(ctx context.Context) (map[string]interface{}, error) {
 	const label = "spec"
 
+	if err := m.allow(label); err != nil {
+		return *new(map[string]interface{}), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (map[string]interface{}, error) {
 			return cl.Spec(ctx)
@@ -673,6 +833,10 @@ func (m multi) Validators(ctx context.Context, stateID string, validatorIndices
 	const label = "validators"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(map[phase0.ValidatorIndex]*apiv1.Validator), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
 			return cl.Validators(ctx, stateID, validatorIndices)
@@ -696,6 +860,10 @@ func (m multi) ValidatorsByPubKey(ctx context.Context, stateID string, validator
 	const label = "validators_by_pub_key"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return *new(map[phase0.ValidatorIndex]*apiv1.Validator), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
 			return cl.ValidatorsByPubKey(ctx, stateID, validatorPubKeys)
@@ -716,6 +884,10 @@ func (m multi) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.Si
 	const label = "submit_voluntary_exit"
 	defer latency(label)()
 
+	if err := m.allow(label); err != nil {
+		return err
+	}
+
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitVoluntaryExit(ctx, voluntaryExit)
@@ -736,6 +908,10 @@ func (m multi) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.Si
 func (m multi) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
 	const label = "domain"
 
+	if err := m.allow(label); err != nil {
+		return *new(phase0.Domain), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (phase0.Domain, error) {
 			return cl.Domain(ctx, domainType, epoch)
@@ -759,6 +935,10 @@ func (m multi) Domain(ctx context.Context, domainType phase0.DomainType, epoch p
 func (m multi) GenesisDomain(ctx context.Context, domainType phase0.DomainType) (phase0.Domain, error) {
 	const label = "genesis_domain"
 
+	if err := m.allow(label); err != nil {
+		return *new(phase0.Domain), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (phase0.Domain, error) {
 			return cl.GenesisDomain(ctx, domainType)
@@ -779,6 +959,10 @@ func (m multi) GenesisDomain(ctx context.Context, domainType phase0.DomainType)
 func (m multi) GenesisTime(ctx context.Context) (time.Time, error) {
 	const label = "genesis_time"
 
+	if err := m.allow(label); err != nil {
+		return *new(time.Time), err
+	}
+
 	res0, err := provide(ctx, m.clients,
 		func(ctx context.Context, cl Client) (time.Time, error) {
 			return cl.GenesisTime(ctx)
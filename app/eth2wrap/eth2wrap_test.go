@@ -160,6 +160,48 @@ func TestSyncState(t *testing.T) {
 	require.False(t, resp.IsSyncing)
 }
 
+func TestAllowlist(t *testing.T) {
+	ctx := context.Background()
+
+	var syncCalls, peerCountCalls int
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+	bmock.NodeSyncingFunc = func(ctx context.Context) (*eth2v1.SyncState, error) {
+		syncCalls++
+		return &eth2v1.SyncState{IsSyncing: true}, nil
+	}
+	bmock.NodePeerCountFunc = func(ctx context.Context) (int, error) {
+		peerCountCalls++
+		return 1, nil
+	}
+
+	eth2Cl, err := eth2wrap.InstrumentWithAllowlist([]string{"node_syncing"}, bmock)
+	require.NoError(t, err)
+
+	resp, err := eth2Cl.NodeSyncing(ctx)
+	require.NoError(t, err)
+	require.True(t, resp.IsSyncing)
+	require.Equal(t, 1, syncCalls)
+
+	_, err = eth2Cl.NodePeerCount(ctx)
+	require.ErrorContains(t, err, "beacon api method not allowed")
+	require.Zero(t, peerCountCalls)
+
+	t.Run("empty allowlist", func(t *testing.T) {
+		eth2Cl, err := eth2wrap.InstrumentWithAllowlist(nil, bmock)
+		require.NoError(t, err)
+
+		_, err = eth2Cl.NodePeerCount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, peerCountCalls)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, err := eth2wrap.InstrumentWithAllowlist([]string{"node_syncing", "node_synching"}, bmock)
+		require.ErrorContains(t, err, "unknown beacon api method in allowlist")
+	})
+}
+
 func TestErrors(t *testing.T) {
 	ctx := context.Background()
 	t.Run("network dial error", func(t *testing.T) {
@@ -210,6 +252,10 @@ func TestErrors(t *testing.T) {
 		log.Error(ctx, "See this error log for fields", err)
 		require.Error(t, err)
 		require.ErrorContains(t, err, "beacon api aggregate_attestation: nok http response")
+
+		code, ok := eth2wrap.StatusCode(err)
+		require.True(t, ok)
+		require.Equal(t, http.StatusBadRequest, code)
 	})
 
 	t.Run("zero net op error", func(t *testing.T) {
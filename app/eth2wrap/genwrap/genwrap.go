@@ -51,6 +51,13 @@ type Client interface {
     {{end -}}
 }
 
+// genMethodLabels are the labels of the generated multi client methods.
+var genMethodLabels = []string{
+	{{- range .Methods}}
+	"{{.Label}}",
+	{{- end}}
+}
+
 {{range .Methods}}
 	{{.Doc}}
     {{- if not .Latency}}// Note this endpoint is cached in go-eth2-client.
@@ -59,6 +66,9 @@ type Client interface {
 		const label = "{{.Label}}"
 		{{if .Latency}}defer latency(label)() {{end}}
 
+		if err := m.allow(label); err != nil {
+			return {{.ZeroResults}}
+		}
 
 		{{.ResultNames}} := {{.DoFunc}}(ctx, m.clients,
 			func(ctx context.Context, cl Client) ({{.ResultTypes}}){
@@ -202,6 +212,20 @@ func (m Method) ResultNames() string {
 	return strings.Join(resp, ", ")
 }
 
+// ZeroResults returns the zero values of the non-error results followed by the error.
+func (m Method) ZeroResults() string {
+	var resp []string
+	for i, result := range m.results {
+		if i == len(m.results)-1 {
+			resp = append(resp, "err")
+			continue
+		}
+		resp = append(resp, fmt.Sprintf("*new(%s)", result.Type))
+	}
+
+	return strings.Join(resp, ", ")
+}
+
 func (m Method) ResultTypes() string {
 	var resp []string
 	for _, result := range m.results {
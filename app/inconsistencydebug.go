@@ -0,0 +1,48 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/obolnetwork/charon/core/tracker"
+)
+
+// maxInconsistencies is the maximum number of recent partial signed data inconsistencies retained.
+const maxInconsistencies = 100
+
+// inconsistencyDebugger buffers the most recent partial signed data inconsistencies in a fifo buffer
+// serving them as JSON on request.
+type inconsistencyDebugger struct {
+	mu              sync.Mutex
+	inconsistencies []tracker.Inconsistency
+}
+
+// Add adds the inconsistency to the fifo buffer, removing the oldest if the max size is exceeded.
+func (d *inconsistencyDebugger) Add(inconsistency tracker.Inconsistency) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inconsistencies = append(d.inconsistencies, inconsistency)
+	if len(d.inconsistencies) > maxInconsistencies {
+		d.inconsistencies = d.inconsistencies[len(d.inconsistencies)-maxInconsistencies:]
+	}
+}
+
+// ServeHTTP serves the buffered inconsistencies as a JSON array, oldest first.
+func (d *inconsistencyDebugger) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	d.mu.Lock()
+	resp := append([]tracker.Inconsistency{}, d.inconsistencies...)
+	d.mu.Unlock()
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeResponse(w, http.StatusOK, string(b))
+}
@@ -0,0 +1,57 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestInconsistencyDebugger(t *testing.T) {
+	debug := new(inconsistencyDebugger)
+
+	get := func() []tracker.Inconsistency {
+		t.Helper()
+
+		rr := httptest.NewRecorder()
+		debug.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/inconsistencies", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var resp []tracker.Inconsistency
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+		return resp
+	}
+
+	require.Empty(t, get())
+
+	inconsistency := tracker.Inconsistency{
+		Time:   time.Now().UTC().Truncate(time.Second),
+		Duty:   core.NewAttesterDuty(1).String(),
+		Pubkey: string(testutil.RandomCorePubKey(t)),
+		Groups: []tracker.InconsistentGroup{
+			{MsgRoot: "0x01", Peers: []string{"peer-0", "peer-1", "peer-2"}},
+			{MsgRoot: "0x02", Peers: []string{"peer-3"}},
+		},
+	}
+	debug.Add(inconsistency)
+	require.Equal(t, []tracker.Inconsistency{inconsistency}, get())
+
+	// Only the most recent inconsistencies are retained.
+	for i := 0; i < maxInconsistencies; i++ {
+		debug.Add(tracker.Inconsistency{Duty: core.NewAttesterDuty(int64(i + 2)).String()})
+	}
+	resp := get()
+	require.Len(t, resp, maxInconsistencies)
+	require.Equal(t, core.NewAttesterDuty(2).String(), resp[0].Duty)
+}
@@ -4,12 +4,15 @@
 package k1util
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"os"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
@@ -118,6 +121,10 @@ func Load(file string) (*k1.PrivateKey, error) {
 		return nil, errors.Wrap(err, "read private key from disk", z.Str("file", file))
 	}
 
+	if isEncrypted(hexStr) {
+		return nil, errors.New("private key is encrypted, password required", z.Str("file", file))
+	}
+
 	b, err := hex.DecodeString(string(hexStr))
 	if err != nil {
 		return nil, errors.Wrap(err, "decode private key hex")
@@ -137,6 +144,83 @@ func Save(key *k1.PrivateKey, file string) error {
 	return nil
 }
 
+// encryptedKey is the json file representation of an encrypted private key.
+type encryptedKey struct {
+	Crypto  map[string]any `json:"crypto"`
+	Version uint           `json:"version"`
+}
+
+// IsEncrypted returns true if the private key file on disk is encrypted.
+func IsEncrypted(file string) (bool, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return false, errors.Wrap(err, "read private key from disk", z.Str("file", file))
+	}
+
+	return isEncrypted(b), nil
+}
+
+// isEncrypted returns true if the private key file contents is an encrypted json object
+// as opposed to a plaintext hex encoded key.
+func isEncrypted(b []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(b), []byte("{"))
+}
+
+// LoadWithPassword returns a private key by reading it from disk. Encrypted files are decrypted
+// using the password, while plaintext hex encoded files are loaded as is for backwards compatibility.
+func LoadWithPassword(file string, password string) (*k1.PrivateKey, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "read private key from disk", z.Str("file", file))
+	}
+
+	if !isEncrypted(b) {
+		return Load(file)
+	}
+
+	var key encryptedKey
+	if err := json.Unmarshal(b, &key); err != nil {
+		return nil, errors.Wrap(err, "unmarshal encrypted private key")
+	}
+
+	secret, err := keystorev4.New().Decrypt(key.Crypto, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt private key")
+	}
+
+	return k1.PrivKeyFromBytes(secret), nil
+}
+
+// SaveEncrypted writes the private key encrypted with the password to disk.
+// The key is written to a temporary file first which is then renamed, so an existing key is never left half-written.
+func SaveEncrypted(key *k1.PrivateKey, file string, password string) error {
+	encryptor := keystorev4.New()
+	fields, err := encryptor.Encrypt(key.Serialize(), password)
+	if err != nil {
+		return errors.Wrap(err, "encrypt private key")
+	}
+
+	b, err := json.MarshalIndent(encryptedKey{
+		Crypto:  fields,
+		Version: encryptor.Version(),
+	}, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal encrypted private key")
+	}
+
+	tmpFile := file + ".tmp"
+	if err := os.WriteFile(tmpFile, b, 0o600); err != nil {
+		return errors.Wrap(err, "write private key to disk", z.Str("file", tmpFile))
+	}
+
+	if err := os.Rename(tmpFile, file); err != nil {
+		_ = os.Remove(tmpFile)
+		return errors.Wrap(err, "rename private key file", z.Str("file", file))
+	}
+
+	return nil
+}
+
 // to32Scalar returns the 256-bit big-endian unsigned
 // integer as a scalar.
 func to32Scalar(b []byte) (*k1.ModNScalar, error) {
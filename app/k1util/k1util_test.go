@@ -5,6 +5,8 @@ package k1util_test
 import (
 	"encoding/hex"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -68,6 +70,44 @@ func TestK1Util(t *testing.T) {
 	require.True(t, key.PubKey().IsEqual(recovered))
 }
 
+func TestEncryptedKey(t *testing.T) {
+	key := k1.PrivKeyFromBytes(fromHex(t, privKey1))
+	dir := t.TempDir()
+
+	// Encrypted keys round-trip with the correct password.
+	encrypted := filepath.Join(dir, "encrypted")
+	require.NoError(t, k1util.SaveEncrypted(key, encrypted, "secret"))
+
+	_, err := os.Stat(encrypted + ".tmp")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	ok, err := k1util.IsEncrypted(encrypted)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	loaded, err := k1util.LoadWithPassword(encrypted, "secret")
+	require.NoError(t, err)
+	require.True(t, key.PubKey().IsEqual(loaded.PubKey()))
+
+	_, err = k1util.LoadWithPassword(encrypted, "wrong")
+	require.ErrorContains(t, err, "decrypt private key")
+
+	_, err = k1util.Load(encrypted)
+	require.ErrorContains(t, err, "private key is encrypted")
+
+	// Plaintext keys still load for backwards compatibility.
+	plaintext := filepath.Join(dir, "plaintext")
+	require.NoError(t, k1util.Save(key, plaintext))
+
+	ok, err = k1util.IsEncrypted(plaintext)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	loaded, err = k1util.LoadWithPassword(plaintext, "secret")
+	require.NoError(t, err)
+	require.True(t, key.PubKey().IsEqual(loaded.PubKey()))
+}
+
 func TestRandom(t *testing.T) {
 	key, err := k1.GeneratePrivateKey()
 	require.NoError(t, err)
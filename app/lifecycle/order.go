@@ -20,12 +20,14 @@ const (
 	StartValidatorAPI
 	StartP2PPing
 	StartP2PRouters
+	StartP2PDial
 	StartP2PConsensus
 	StartSimulator
 	StartScheduler
 	StartP2PEventCollector
 	StartPeerInfo
 	StartParSigDB
+	StartLockReloader
 )
 
 // Global ordering of stop hooks; follows dependency tree from root to leaves.
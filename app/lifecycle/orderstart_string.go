@@ -17,17 +17,19 @@ func _() {
 	_ = x[StartValidatorAPI-4]
 	_ = x[StartP2PPing-5]
 	_ = x[StartP2PRouters-6]
-	_ = x[StartP2PConsensus-7]
-	_ = x[StartSimulator-8]
-	_ = x[StartScheduler-9]
-	_ = x[StartP2PEventCollector-10]
-	_ = x[StartPeerInfo-11]
-	_ = x[StartParSigDB-12]
+	_ = x[StartP2PDial-7]
+	_ = x[StartP2PConsensus-8]
+	_ = x[StartSimulator-9]
+	_ = x[StartScheduler-10]
+	_ = x[StartP2PEventCollector-11]
+	_ = x[StartPeerInfo-12]
+	_ = x[StartParSigDB-13]
+	_ = x[StartLockReloader-14]
 }
 
-const _OrderStart_name = "TrackerAggSigDBRelayMonitoringAPIValidatorAPIP2PPingP2PRoutersP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDB"
+const _OrderStart_name = "TrackerAggSigDBRelayMonitoringAPIValidatorAPIP2PPingP2PRoutersP2PDialP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBLockReloader"
 
-var _OrderStart_index = [...]uint8{0, 7, 15, 20, 33, 45, 52, 62, 74, 83, 92, 109, 117, 125}
+var _OrderStart_index = [...]uint8{0, 7, 15, 20, 33, 45, 52, 62, 69, 81, 90, 99, 116, 124, 132, 144}
 
 func (i OrderStart) String() string {
 	if i < 0 || i >= OrderStart(len(_OrderStart_index)-1) {
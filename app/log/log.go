@@ -132,7 +132,8 @@ func Error(ctx context.Context, msg string, err error, fields ...z.Field) {
 	logger.Error(err.Error(), zfl...)
 }
 
-// unwrapDedup returns true and the wrapped zap fields from the slice and from the context. Duplicate fields are dropped.
+// unwrapDedup returns true and the wrapped zap fields from the slice and from the context. Duplicate fields are dropped,
+// sensitive fields are redacted and peer ID fields are enriched with peer names.
 // It returns false if the whole log should be filtered out (dropped).
 func unwrapDedup(ctx context.Context, fields ...z.Field) ([]zap.Field, bool) {
 	var (
@@ -150,7 +151,12 @@ func unwrapDedup(ctx context.Context, fields ...z.Field) ([]zap.Field, bool) {
 			return
 		}
 		dups[f.Key] = true
-		resp = append(resp, f)
+		resp = append(resp, redact(f))
+
+		if name, ok := peerNameField(f); ok && !dups[name.Key] {
+			dups[name.Key] = true
+			resp = append(resp, name)
+		}
 	}
 
 	for _, field := range fields {
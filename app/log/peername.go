@@ -0,0 +1,53 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	peerNamesMu sync.RWMutex
+	peerNames   map[string]string
+)
+
+// SetPeerNames sets the friendly names of peers by peer ID.
+// Logged fields with a known peer ID value are enriched with an additional "<key>_name" field.
+func SetPeerNames(names map[string]string) {
+	peerNamesMu.Lock()
+	defer peerNamesMu.Unlock()
+
+	peerNames = names
+}
+
+// peerNameField returns a "<key>_name" field containing the friendly name of the peer
+// if the field value is a known peer ID.
+func peerNameField(f zap.Field) (zap.Field, bool) {
+	var val string
+	switch f.Type {
+	case zapcore.StringType:
+		val = f.String
+	case zapcore.StringerType:
+		s, ok := f.Interface.(fmt.Stringer)
+		if !ok {
+			return zap.Field{}, false
+		}
+		val = s.String()
+	default:
+		return zap.Field{}, false
+	}
+
+	peerNamesMu.RLock()
+	defer peerNamesMu.RUnlock()
+
+	name, ok := peerNames[val]
+	if !ok {
+		return zap.Field{}, false
+	}
+
+	return zap.String(f.Key+"_name", name), true
+}
@@ -3,6 +3,8 @@
 package app
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/obolnetwork/charon/app/promauto"
@@ -24,6 +26,15 @@ const (
 	// readyVCMissingValidators indicates that readyz is returning 500s since VC is not configured correctly
 	// and missing some/all validators.
 	readyzVCMissingValidators = 6
+	// readyzBeaconNodeRateLimited indicates that readyz is returning 500s since the Beacon Node API
+	// has been rate limiting requests (429s) for longer than the grace period.
+	readyzBeaconNodeRateLimited = 7
+	// readyzBeaconNodeUnavailable indicates that readyz is returning 500s since the Beacon Node API
+	// has been temporarily unavailable (503s) for longer than the grace period.
+	readyzBeaconNodeUnavailable = 8
+	// readyzLowDisk indicates that readyz is returning 500s since the free disk space of the data directory
+	// is below the configured minimum.
+	readyzLowDisk = 9
 )
 
 var (
@@ -59,7 +70,10 @@ var (
 			"Else `/readyz` is returning 500s and this metric is either set to " +
 			"2 if the beacon node is down, or" +
 			"3 if the beacon node is syncing, or" +
-			"4 if quorum peers are not connected.",
+			"4 if quorum peers are not connected, or" +
+			"7 if the beacon node is rate limiting requests, or" +
+			"8 if the beacon node is temporarily unavailable, or" +
+			"9 if the free disk space is low.",
 	})
 
 	beaconNodePeerCountGauge = promauto.NewGauge(prometheus.GaugeOpts{
@@ -94,6 +108,13 @@ var (
 		Help:      "Number of validators in the cluster lock",
 	})
 
+	qbftDebugInstancesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "qbft_debug",
+		Name:      "instances",
+		Help:      "Number of sniffed consensus instances retained by the /debug/qbft endpoint",
+	})
+
 	networkGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "cluster",
 		Name:      "network",
@@ -101,6 +122,17 @@ var (
 	}, []string{"network"})
 )
 
+// clusterLabels returns the labels identifying the cluster and this node attached to all metrics and logs.
+func clusterLabels(lockHashHex, clusterName, peerName string, peerIdx int, network string) map[string]string {
+	return map[string]string{
+		"cluster_hash":       lockHashHex,
+		"cluster_name":       clusterName,
+		"cluster_peer":       peerName,
+		"cluster_peer_index": strconv.Itoa(peerIdx),
+		"cluster_network":    network,
+	}
+}
+
 func initStartupMetrics(peerName string, threshold, numOperators, numValidators int, network string) {
 	startGauge.SetToCurrentTime()
 	networkGauge.WithLabelValues(network).Set(1)
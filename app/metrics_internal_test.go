@@ -0,0 +1,47 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+func TestClusterLabels(t *testing.T) {
+	labels := clusterLabels("0xabcd", "test cluster", "peer-name", 2, "goerli")
+	require.Equal(t, map[string]string{
+		"cluster_hash":       "0xabcd",
+		"cluster_name":       "test cluster",
+		"cluster_peer":       "peer-name",
+		"cluster_peer_index": "2",
+		"cluster_network":    "goerli",
+	}, labels)
+
+	initStartupMetrics("peer-name", 3, 4, 5, "goerli")
+
+	registry, err := promauto.NewRegistry(labels)
+	require.NoError(t, err)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var found bool
+	for _, fam := range families {
+		if fam.GetName() != "cluster_validators" {
+			continue
+		}
+		found = true
+
+		for _, metric := range fam.GetMetric() {
+			actual := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				actual[label.GetName()] = label.GetValue()
+			}
+			require.Equal(t, labels, actual)
+		}
+	}
+	require.True(t, found)
+}
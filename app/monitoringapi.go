@@ -4,9 +4,15 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/http/pprof"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -20,26 +26,39 @@ import (
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
 )
 
+// tickerJitter is the maximum relative deviation of monitoring ticker intervals, so that nodes
+// sharing a beacon node don't query it in lockstep.
+const tickerJitter = 0.1
+
 var (
 	errReadyUninitialised     = errors.New("ready check uninitialised")
 	errReadyInsufficientPeers = errors.New("quorum peers not connected")
 	errReadyBeaconNodeSyncing = errors.New("beacon node not synced")
 	errReadyBeaconNodeDown    = errors.New("beacon node down")
+	errReadyBeaconNodeLimited = errors.New("beacon node rate limited")
+	errReadyBeaconNodeUnavail = errors.New("beacon node temporarily unavailable")
 	errReadyVCNotConnected    = errors.New("vc not connected")
 	errReadyVCMissingVals     = errors.New("vc missing validators")
+	errReadyLowDisk           = errors.New("low free disk space")
 )
 
 // wireMonitoringAPI constructs the monitoring API and registers it with the life cycle manager.
 // It serves prometheus metrics, pprof profiling and the runtime enr.
+// It returns an error if the monitoring address cannot be bound.
 func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, addr string,
 	tcpNode host.Host, eth2Cl eth2wrap.Client,
-	peerIDs []peer.ID, registry *prometheus.Registry, qbftDebug http.Handler,
+	peerIDs []peer.ID, peerWeights map[peer.ID]int, registry *prometheus.Registry, qbftDebug http.Handler, inconsistencyDebug http.Handler,
 	pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
-) {
+	forkVersion []byte, clusterName string, debugConf debugConfig, dataDir string, minFreeDisk uint64,
+	mutableConf *mutableConfig, adminAPI bool, observer bool,
+) error {
 	beaconNodeMetrics(ctx, eth2Cl, clockwork.NewRealClock())
 
 	mux := http.NewServeMux()
@@ -50,12 +69,11 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, addr string
 	))
 
 	// Serve monitoring endpoints
-	mux.Handle("/livez", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		writeResponse(w, http.StatusOK, "ok")
-	}))
+	mux.Handle("/livez", newLivezHandler(version.Version, forkVersionNetwork(forkVersion), clusterName, time.Now()))
 
-	readyErrFunc := startReadyChecker(ctx, tcpNode, eth2Cl, peerIDs, clockwork.NewRealClock(),
-		pubkeys, seenPubkeys, vapiCalls)
+	epochDuration := readyEpochDuration(ctx, eth2Cl, forkVersion)
+	readyErrFunc := startReadyChecker(ctx, tcpNode, eth2Cl, peerIDs, peerWeights, clockwork.NewRealClock(),
+		pubkeys, seenPubkeys, vapiCalls, epochDuration, func() (uint64, error) { return diskFree(dataDir) }, minFreeDisk, !observer)
 
 	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
 		readyErr := readyErrFunc()
@@ -70,6 +88,15 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, addr string
 	// Serve sniffed qbft instances messages in gzipped protobuf format.
 	mux.Handle("/debug/qbft", qbftDebug)
 
+	// Serve recent partial signed data inconsistencies as JSON.
+	mux.Handle("/debug/inconsistencies", inconsistencyDebug)
+
+	// Serve the effective secret-redacted runtime configuration.
+	mux.Handle("/debug/config", newDebugConfigHandler(debugConf))
+
+	// Serve the validators disabled at runtime, toggling them requires the admin api to be enabled.
+	mux.Handle("/admin/validators", newValidatorsAdminHandler(ctx, mutableConf, adminAPI))
+
 	// Copied from net/http/pprof/pprof.go
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
@@ -77,29 +104,223 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, addr string
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 
+	return serveMonitoringAPI(life, addr, mux)
+}
+
+// livezResponse is the optional JSON response of the /livez endpoint.
+type livezResponse struct {
+	Version       string  `json:"version"`
+	Network       string  `json:"network"`
+	ClusterName   string  `json:"cluster_name"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+}
+
+// newLivezHandler returns the /livez handler. It responds with "ok" by default, or with
+// the node identification as JSON if requested via the Accept header.
+func newLivezHandler(version, network, clusterName string, startTime time.Time) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/json") {
+			writeResponse(w, http.StatusOK, "ok")
+			return
+		}
+
+		b, err := json.Marshal(livezResponse{
+			Version:       version,
+			Network:       network,
+			ClusterName:   clusterName,
+			UptimeSeconds: time.Since(startTime).Seconds(),
+		})
+		if err != nil {
+			writeResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeResponse(w, http.StatusOK, string(b))
+	})
+}
+
+// debugConfig is the effective runtime configuration served by the /debug/config endpoint.
+// Sensitive values must be redacted, see newDebugConfig.
+type debugConfig struct {
+	Network                 string   `json:"network"`
+	ClusterName             string   `json:"cluster_name"`
+	Nodes                   int      `json:"nodes"`
+	Threshold               int      `json:"threshold"`
+	Validators              int      `json:"validators"`
+	LockFile                string   `json:"lock_file"`
+	PrivKeyFile             string   `json:"private_key_file"`
+	PrivKeyPasswordFile     string   `json:"private_key_password_file"`
+	BeaconNodeEndpoints     []string `json:"beacon_node_endpoints"`
+	ValidatorAPIAddr        string   `json:"validator_api_address"`
+	MonitoringAddr          string   `json:"monitoring_address"`
+	JaegerAddr              string   `json:"jaeger_address"`
+	P2PRelays               []string `json:"p2p_relays"`
+	P2PTCPAddrs             []string `json:"p2p_tcp_address"`
+	FeatureSetMinStatus     string   `json:"feature_set"`
+	FeatureSetEnable        []string `json:"feature_set_enable"`
+	FeatureSetDisable       []string `json:"feature_set_disable"`
+	LegacyQBFTProbability   float64  `json:"legacy_qbft_probability"`
+	NoVerify                bool     `json:"no_verify"`
+	BuilderAPI              bool     `json:"builder_api"`
+	SyntheticBlockProposals bool     `json:"synthetic_block_proposals"`
+	Observer                bool     `json:"observer"`
+}
+
+// newDebugConfig returns the effective runtime configuration with sensitive values redacted by the log redaction layer.
+func newDebugConfig(conf Config, lock cluster.Lock, network string) debugConfig {
+	redactAll := func(key string, vals []string) []string {
+		resp := make([]string, 0, len(vals))
+		for _, val := range vals {
+			resp = append(resp, log.Redact(key, val))
+		}
+
+		return resp
+	}
+
+	return debugConfig{
+		Network:                 network,
+		ClusterName:             lock.Name,
+		Nodes:                   len(lock.Operators),
+		Threshold:               lock.Threshold,
+		Validators:              len(lock.Validators),
+		LockFile:                log.Redact("lock_file", conf.LockFile),
+		PrivKeyFile:             log.Redact("private_key_file", conf.PrivKeyFile),
+		PrivKeyPasswordFile:     log.Redact("private_key_password_file", conf.PrivKeyPasswordFile),
+		BeaconNodeEndpoints:     redactAll("beacon_node_endpoints", conf.BeaconNodeAddrs),
+		ValidatorAPIAddr:        log.Redact("validator_api_address", conf.ValidatorAPIAddr),
+		MonitoringAddr:          log.Redact("monitoring_address", conf.MonitoringAddr),
+		JaegerAddr:              log.Redact("jaeger_address", conf.JaegerAddr),
+		P2PRelays:               redactAll("p2p_relays", conf.P2P.Relays),
+		P2PTCPAddrs:             redactAll("p2p_tcp_address", conf.P2P.TCPAddrs),
+		FeatureSetMinStatus:     conf.Feature.MinStatus,
+		FeatureSetEnable:        conf.Feature.Enabled,
+		FeatureSetDisable:       conf.Feature.Disabled,
+		LegacyQBFTProbability:   conf.TestConfig.LegacyQBFTProbability,
+		NoVerify:                conf.NoVerify,
+		BuilderAPI:              conf.BuilderAPI,
+		SyntheticBlockProposals: conf.SyntheticBlockProposals,
+		Observer:                conf.Observer,
+	}
+}
+
+// newDebugConfigHandler returns the /debug/config handler responding with the configuration as JSON.
+func newDebugConfigHandler(conf debugConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := json.Marshal(conf)
+		if err != nil {
+			writeResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeResponse(w, http.StatusOK, string(b))
+	})
+}
+
+// validatorToggle is the /admin/validators request enabling or disabling a validator.
+type validatorToggle struct {
+	PubKey  string `json:"pubkey"`
+	Enabled bool   `json:"enabled"`
+}
+
+// newValidatorsAdminHandler returns the /admin/validators handler responding with the disabled validators as JSON
+// on GET and enabling or disabling a validator on POST of a validatorToggle if the admin api is enabled.
+func newValidatorsAdminHandler(ctx context.Context, conf *mutableConfig, adminAPI bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			if !adminAPI {
+				writeResponse(w, http.StatusForbidden, "admin api disabled, enable via --admin-api")
+				return
+			}
+
+			var toggle validatorToggle
+			if err := json.NewDecoder(r.Body).Decode(&toggle); err != nil {
+				writeResponse(w, http.StatusBadRequest, "invalid request body")
+				return
+			}
+
+			if err := conf.SetValidatorEnabled(toggle.PubKey, toggle.Enabled); err != nil {
+				writeResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			log.Info(ctx, "Validator toggled via admin api", z.Str("pubkey", toggle.PubKey), z.Bool("enabled", toggle.Enabled))
+		default:
+			writeResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		b, err := json.Marshal(struct {
+			DisabledValidators []core.PubKey `json:"disabled_validators"`
+		}{
+			DisabledValidators: conf.DisabledValidators(),
+		})
+		if err != nil {
+			writeResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeResponse(w, http.StatusOK, string(b))
+	})
+}
+
+// forkVersionNetwork returns the network name of the fork version or its hex representation if unknown.
+func forkVersionNetwork(forkVersion []byte) string {
+	network, err := eth2util.ForkVersionToNetwork(forkVersion)
+	if err != nil {
+		return fmt.Sprintf("%#x", forkVersion)
+	}
+
+	return network
+}
+
+// serveMonitoringAPI binds the monitoring API address synchronously, so bind failures abort startup immediately,
+// and registers the server with the life cycle manager.
+func serveMonitoringAPI(life *lifecycle.Manager, addr string, handler http.Handler) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrap(err, "bind monitoring address", z.Str("address", addr))
+	}
+
 	server := &http.Server{
 		Addr:              addr,
-		Handler:           mux,
+		Handler:           handler,
 		ReadHeaderTimeout: time.Second,
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, httpServeHook(func() error {
+		return server.Serve(ln)
+	}))
 	life.RegisterStop(lifecycle.StopMonitoringAPI, lifecycle.HookFunc(server.Shutdown))
+
+	return nil
 }
 
 // startReadyChecker returns function which returns an error resulting from ready checks periodically.
+// The node is not ready if the free disk space returned by diskFreeFunc is below minFreeDisk bytes, zero disables this check.
+// The validator client checks are skipped if requireVC is false, since observers don't have validator clients.
 func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2client.NodeSyncingProvider, peerIDs []peer.ID,
-	clock clockwork.Clock, pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
+	peerWeights map[peer.ID]int, clock clockwork.Clock, pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
+	epochDuration time.Duration, diskFreeFunc func() (uint64, error), minFreeDisk uint64, requireVC bool,
 ) func() error {
-	const minNotConnected = 6 // Require 6 rounds (1min) of too few connected
+	const (
+		minNotConnected = 6 // Require 6 rounds (1min) of too few connected
+		minTransient    = 3 // Require 3 rounds (30s) of transient beacon node errors
+	)
 	var (
 		mu                 sync.Mutex
 		readyErr           = errReadyUninitialised
 		notConnectedRounds = minNotConnected // Start as not connected.
+		transientRounds    int
+		prevSyncing        bool
 	)
 	go func() {
-		ticker := clock.NewTicker(10 * time.Second)
-		epochTicker := clock.NewTicker(32 * 12 * time.Second) // 32 slots * 12 second slot time
+		ticker := clock.NewTimer(jitter(10 * time.Second))
+		epochTicker := clock.NewTicker(epochDuration)
 		currVAPICount := 0
 		prevVAPICount := 1 // Assume connected.
 		currPKs := make(map[core.PubKey]bool)
@@ -117,27 +338,49 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2client
 				prevPKs, currPKs = currPKs, make(map[core.PubKey]bool)
 				prevVAPICount, currVAPICount = currVAPICount, 0
 			case <-ticker.Chan():
-				if quorumPeersConnected(peerIDs, tcpNode) {
+				ticker.Reset(jitter(10 * time.Second))
+
+				if quorumPeersConnected(peerIDs, peerWeights, tcpNode) {
 					notConnectedRounds = 0
 				} else {
 					notConnectedRounds++
 				}
 
 				syncing, err := beaconNodeSyncing(ctx, eth2Cl)
+				if isTransientBeaconNodeErr(err) {
+					transientRounds++
+				} else {
+					transientRounds = 0
+				}
+				if transientRounds > 0 && transientRounds < minTransient {
+					// Ignore transient errors during the grace period, using the previous syncing state.
+					err = nil
+					syncing = prevSyncing
+				}
+				if err == nil {
+					prevSyncing = syncing
+				}
+
+				lowDisk := isLowDisk(ctx, diskFreeFunc, minFreeDisk)
+
 				//nolint:nestif
 				if err != nil {
-					err = errReadyBeaconNodeDown
-					readyzGauge.Set(readyzBeaconNodeDown)
+					var gauge float64
+					gauge, err = beaconNodeDownReason(err)
+					readyzGauge.Set(gauge)
 				} else if syncing {
 					err = errReadyBeaconNodeSyncing
 					readyzGauge.Set(readyzBeaconNodeSyncing)
 				} else if notConnectedRounds >= minNotConnected {
 					err = errReadyInsufficientPeers
 					readyzGauge.Set(readyzInsufficientPeers)
-				} else if prevVAPICount == 0 {
+				} else if lowDisk {
+					err = errReadyLowDisk
+					readyzGauge.Set(readyzLowDisk)
+				} else if requireVC && prevVAPICount == 0 {
 					err = errReadyVCNotConnected
 					readyzGauge.Set(readyzVCNotConnected)
-				} else if len(prevPKs) < len(pubkeys) {
+				} else if requireVC && len(prevPKs) < len(pubkeys) {
 					err = errReadyVCMissingVals
 					readyzGauge.Set(readyzVCMissingValidators)
 				} else {
@@ -163,6 +406,72 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2client
 	}
 }
 
+// isLowDisk returns true if the free disk space is below minFreeDisk bytes. It returns false if the check
+// is disabled or the free disk space cannot be determined.
+func isLowDisk(ctx context.Context, diskFreeFunc func() (uint64, error), minFreeDisk uint64) bool {
+	if minFreeDisk == 0 {
+		return false
+	}
+
+	free, err := diskFreeFunc()
+	if err != nil {
+		log.Warn(ctx, "Failed to determine free disk space", err)
+		return false
+	}
+
+	return free < minFreeDisk
+}
+
+// diskFree returns the free disk space in bytes available to unprivileged users of the file system containing dir.
+func diskFree(dir string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, errors.Wrap(err, "statfs", z.Str("dir", dir))
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:unconvert // Field types are platform specific.
+}
+
+// epochSpecProvider is the subset of the eth2 client providing the slot and epoch spec.
+type epochSpecProvider interface {
+	eth2client.SlotDurationProvider
+	eth2client.SlotsPerEpochProvider
+}
+
+// readyEpochDuration returns the epoch duration used by the ready checker. It is fetched from the beacon node spec,
+// falling back to the known slot parameters of the cluster network if the beacon node is unavailable at startup.
+func readyEpochDuration(ctx context.Context, eth2Cl epochSpecProvider, forkVersion []byte) time.Duration {
+	const defaultEpochDuration = 32 * 12 * time.Second // 32 slots * 12 second slot time
+
+	ctx, cancel := context.WithTimeout(ctx, eth2ClientTimeout)
+	defer cancel()
+
+	slotDuration, err := eth2Cl.SlotDuration(ctx)
+	if err == nil {
+		var slotsPerEpoch uint64
+		slotsPerEpoch, err = eth2Cl.SlotsPerEpoch(ctx)
+		if err == nil {
+			return slotDuration * time.Duration(slotsPerEpoch)
+		}
+	}
+
+	network, netErr := eth2util.ForkVersionToNetwork(forkVersion)
+	if netErr != nil {
+		log.Warn(ctx, "Beacon node spec unavailable and unknown network, using default epoch duration", err)
+		return defaultEpochDuration
+	}
+
+	slotDuration, slotsPerEpoch, netErr := eth2util.NetworkToSlotParams(network)
+	if netErr != nil {
+		log.Warn(ctx, "Beacon node spec unavailable and unknown network, using default epoch duration", err)
+		return defaultEpochDuration
+	}
+
+	log.Warn(ctx, "Beacon node spec unavailable, using known network epoch duration", err, z.Str("network", network))
+
+	return slotDuration * time.Duration(slotsPerEpoch)
+}
+
 // beaconNodeSyncing returns true if the beacon node is still syncing.
 func beaconNodeSyncing(ctx context.Context, eth2Cl eth2client.NodeSyncingProvider) (bool, error) {
 	state, err := eth2Cl.NodeSyncing(ctx)
@@ -173,9 +482,30 @@ func beaconNodeSyncing(ctx context.Context, eth2Cl eth2client.NodeSyncingProvide
 	return state.IsSyncing, nil
 }
 
+// isTransientBeaconNodeErr returns true if the error is a rate limited (429) or
+// temporarily unavailable (503) beacon node response.
+func isTransientBeaconNodeErr(err error) bool {
+	code, ok := eth2wrap.StatusCode(err)
+
+	return ok && (code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable)
+}
+
+// beaconNodeDownReason returns the readyz gauge value and ready error of the beacon node error.
+func beaconNodeDownReason(err error) (float64, error) {
+	code, _ := eth2wrap.StatusCode(err)
+	switch code {
+	case http.StatusTooManyRequests:
+		return readyzBeaconNodeRateLimited, errReadyBeaconNodeLimited
+	case http.StatusServiceUnavailable:
+		return readyzBeaconNodeUnavailable, errReadyBeaconNodeUnavail
+	default:
+		return readyzBeaconNodeDown, errReadyBeaconNodeDown
+	}
+}
+
 // beaconNodeMetrics sets beacon node metrics like the peer count and node version.
 func beaconNodeMetrics(ctx context.Context, eth2Cl eth2wrap.Client, clock clockwork.Clock) {
-	peerCountTicker := clock.NewTicker(1 * time.Minute)
+	peerCountTicker := clock.NewTimer(jitter(1 * time.Minute))
 	setPeerCount := func() {
 		peerCount, err := eth2Cl.NodePeerCount(ctx)
 		if err != nil {
@@ -185,7 +515,7 @@ func beaconNodeMetrics(ctx context.Context, eth2Cl eth2wrap.Client, clock clockw
 		beaconNodePeerCountGauge.Set(float64(peerCount))
 	}
 
-	nodeVersionTicker := clock.NewTicker(10 * time.Minute)
+	nodeVersionTicker := clock.NewTimer(jitter(10 * time.Minute))
 	var prevNodeVersion string
 	setNodeVersion := func() {
 		version, err := eth2Cl.NodeVersion(ctx)
@@ -214,8 +544,10 @@ func beaconNodeMetrics(ctx context.Context, eth2Cl eth2wrap.Client, clock clockw
 				setPeerCount()
 				setNodeVersion()
 			case <-peerCountTicker.Chan():
+				peerCountTicker.Reset(jitter(1 * time.Minute))
 				setPeerCount()
 			case <-nodeVersionTicker.Chan():
+				nodeVersionTicker.Reset(jitter(10 * time.Minute))
 				setNodeVersion()
 			case <-ctx.Done():
 				return
@@ -224,21 +556,40 @@ func beaconNodeMetrics(ctx context.Context, eth2Cl eth2wrap.Client, clock clockw
 	}()
 }
 
+// jitter returns the interval randomly adjusted by up to tickerJitter in either direction,
+// keeping the mean interval unchanged.
+func jitter(interval time.Duration) time.Duration {
+	//nolint:gosec // Jitter doesn't require a secure random source.
+	return time.Duration(float64(interval) * (1 + tickerJitter*(rand.Float64()*2-1)))
+}
+
 // quorumPeersConnected returns true if quorum peers are currently connected.
-func quorumPeersConnected(peerIDs []peer.ID, tcpNode host.Host) bool {
-	var count int
+// Peers are weighted by the optional weights (defaulting to 1), so quorum is reached when
+// the sum of the weights of self and connected peers reaches the threshold of the total weight.
+func quorumPeersConnected(peerIDs []peer.ID, weights map[peer.ID]int, tcpNode host.Host) bool {
+	weight := func(pID peer.ID) int {
+		if w, ok := weights[pID]; ok {
+			return w
+		}
+
+		return 1
+	}
+
+	var total, connected int
 	for _, pID := range peerIDs {
+		total += weight(pID)
+
 		if tcpNode.ID() == pID {
-			continue // Don't check self
+			connected += weight(pID) // Self is always connected.
+			continue
 		}
 
 		if len(tcpNode.Network().ConnsToPeer(pID)) > 0 {
-			count++
+			connected += weight(pID)
 		}
 	}
 
-	// Excluding self when comparing with threshold, since we need to connect to threshold - 1 no. of peers.
-	return count >= cluster.Threshold(len(peerIDs))-1
+	return connected >= cluster.Threshold(total)
 }
 
 func writeResponse(w http.ResponseWriter, status int, msg string) {
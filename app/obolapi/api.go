@@ -5,6 +5,8 @@ package obolapi
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -55,6 +57,49 @@ func (c Client) PublishLock(ctx context.Context, lock cluster.Lock) error {
 	return nil
 }
 
+// LockHash is the minimal metadata published to obol-api instead of the full lock.
+// It registers the existence of a cluster without disclosing validator public shares or deposit data.
+type LockHash struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	NumValidators  int    `json:"num_validators"`
+	NumOperators   int    `json:"num_operators"`
+	Threshold      int    `json:"threshold"`
+	DefinitionHash string `json:"definition_hash"`
+	LockHash       string `json:"lock_hash"`
+}
+
+// PublishLockHash posts only the lock hash, definition hash and minimal metadata of the lock to obol-api.
+func (c Client) PublishLockHash(ctx context.Context, lock cluster.Lock) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	addr, err := url.JoinPath(c.baseURL, "lock", "hash")
+	if err != nil {
+		return errors.Wrap(err, "invalid address")
+	}
+
+	url, err := url.Parse(addr)
+	if err != nil {
+		return errors.Wrap(err, "invalid endpoint")
+	}
+
+	b, err := json.Marshal(LockHash{
+		Name:           lock.Name,
+		Version:        lock.Version,
+		NumValidators:  lock.NumValidators,
+		NumOperators:   len(lock.Operators),
+		Threshold:      lock.Threshold,
+		DefinitionHash: fmt.Sprintf("%#x", lock.DefinitionHash),
+		LockHash:       fmt.Sprintf("%#x", lock.LockHash),
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal lock hash")
+	}
+
+	return httpPost(ctx, url, b)
+}
+
 func httpPost(ctx context.Context, url *url.URL, b []byte) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(b))
 	if err != nil {
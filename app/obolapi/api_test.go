@@ -5,6 +5,7 @@ package obolapi_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -49,3 +50,32 @@ func TestLockPublish(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestLockHashPublish(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 3, 3, 4, 0)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, r.URL.Path, "/lock/hash")
+
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		defer r.Body.Close()
+
+		require.NotContains(t, string(data), "distributed_validators")
+		require.NotContains(t, string(data), "public_shares")
+		require.NotContains(t, string(data), "deposit_data")
+
+		var req obolapi.LockHash
+		require.NoError(t, json.Unmarshal(data, &req))
+		require.Equal(t, fmt.Sprintf("%#x", lock.LockHash), req.LockHash)
+		require.Equal(t, fmt.Sprintf("%#x", lock.DefinitionHash), req.DefinitionHash)
+		require.Equal(t, lock.NumValidators, req.NumValidators)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cl := obolapi.New(srv.URL)
+	err := cl.PublishLockHash(context.Background(), lock)
+	require.NoError(t, err)
+}
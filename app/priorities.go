@@ -4,26 +4,49 @@ package app
 
 import (
 	"context"
+	"encoding/hex"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/infosync"
 )
 
-// newMutableConfig returns a new mutable config.
-func newMutableConfig(ctx context.Context, conf Config) *mutableConfig {
-	return &mutableConfig{
-		ctx:  ctx,
-		conf: conf,
+// newMutableConfig returns a new mutable config of the cluster validators with the configured validators disabled.
+func newMutableConfig(ctx context.Context, conf Config, pubkeys []core.PubKey) (*mutableConfig, error) {
+	validators := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		validators[pubkey] = true
 	}
+
+	c := &mutableConfig{
+		ctx:        ctx,
+		conf:       conf,
+		validators: validators,
+		disabled:   make(map[core.PubKey]bool),
+	}
+
+	for _, pubkey := range conf.DisabledValidators {
+		if err := c.SetValidatorEnabled(pubkey, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
-// mutableConfig defines mutable cluster wide config.
+// mutableConfig defines mutable runtime config, both cluster wide and local to this node.
 type mutableConfig struct {
 	ctx  context.Context
 	conf Config
 
-	mu       sync.Mutex
-	infosync *infosync.Component
+	mu         sync.Mutex
+	infosync   *infosync.Component
+	validators map[core.PubKey]bool
+	disabled   map[core.PubKey]bool
 }
 
 func (c *mutableConfig) SetInfoSync(infosync *infosync.Component) {
@@ -45,3 +68,72 @@ func (c *mutableConfig) BuilderAPI(_ int64) bool {
 	// TODO(corver): Dynamic BuilderAPI config disabled since VCs do not support it.
 	return c.conf.BuilderAPI
 }
+
+// ValidatorEnabled returns true if the duties of the validator are scheduled.
+func (c *mutableConfig) ValidatorEnabled(pubkey core.PubKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return !c.disabled[pubkey]
+}
+
+// SetValidatorEnabled enables or disables scheduling the duties of the validator with the hex public key.
+// Note disabling a validator doesn't remove its keys.
+func (c *mutableConfig) SetValidatorEnabled(pubkeyHex string, enabled bool) error {
+	pubkey, err := parsePubKey(pubkeyHex)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.validators[pubkey] {
+		return errors.New("unknown validator public key", z.Str("pubkey", pubkeyHex))
+	}
+
+	if enabled {
+		delete(c.disabled, pubkey)
+	} else {
+		c.disabled[pubkey] = true
+	}
+
+	return nil
+}
+
+// AddValidators adds the validators to the cluster validators, e.g. when reloading the cluster lock.
+// Added validators are enabled.
+func (c *mutableConfig) AddValidators(pubkeys []core.PubKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, pubkey := range pubkeys {
+		c.validators[pubkey] = true
+	}
+}
+
+// DisabledValidators returns the sorted public keys of the disabled validators.
+func (c *mutableConfig) DisabledValidators() []core.PubKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := make([]core.PubKey, 0, len(c.disabled))
+	for pubkey := range c.disabled {
+		resp = append(resp, pubkey)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i] < resp[j]
+	})
+
+	return resp
+}
+
+// parsePubKey returns the core public key of the hex public key with optional 0x prefix.
+func parsePubKey(pubkeyHex string) (core.PubKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(pubkeyHex), "0x"))
+	if err != nil {
+		return "", errors.Wrap(err, "decode validator public key hex", z.Str("pubkey", pubkeyHex))
+	}
+
+	return core.PubKeyFromBytes(b)
+}
@@ -19,12 +19,37 @@ var (
 	metrics []prometheus.Collector
 )
 
+// RegistryOption configures optional registry settings.
+type RegistryOption func(*registryOpts)
+
+// registryOpts contains the optional registry settings.
+type registryOpts struct {
+	namespace string
+}
+
+// WithNamespace returns an option that prefixes all metric names with the namespace,
+// e.g. "core_consensus_decided_rounds" becomes "namespace_core_consensus_decided_rounds".
+// An empty namespace retains the default metric names.
+func WithNamespace(namespace string) RegistryOption {
+	return func(opts *registryOpts) {
+		opts.namespace = namespace
+	}
+}
+
 // NewRegistry returns a new registry containing all promauto created metrics and
 // built-in Go process metrics wrapping everything with the provided labels.
-func NewRegistry(labels prometheus.Labels) (*prometheus.Registry, error) {
+func NewRegistry(labels prometheus.Labels, opts ...RegistryOption) (*prometheus.Registry, error) {
+	var o registryOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	registry := prometheus.NewRegistry()
 
 	registerer := prometheus.WrapRegistererWith(labels, registry)
+	if o.namespace != "" {
+		registerer = prometheus.WrapRegistererWithPrefix(o.namespace+"_", registerer)
+	}
 
 	err := registerer.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	if err != nil {
@@ -3,6 +3,7 @@
 package promauto_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -56,3 +57,22 @@ func TestWrapRegisterer(t *testing.T) {
 
 	require.True(t, foundTest)
 }
+
+func TestNamespace(t *testing.T) {
+	testGauge.WithLabelValues("0").Set(1)
+
+	registry, err := promauto.NewRegistry(nil, promauto.WithNamespace("charon"))
+	require.NoError(t, err)
+	metrics, err := registry.Gather()
+	require.NoError(t, err)
+
+	var foundTest bool
+	for _, metricFam := range metrics {
+		require.True(t, strings.HasPrefix(*metricFam.Name, "charon_"), *metricFam.Name)
+		if *metricFam.Name == "charon_test" {
+			foundTest = true
+		}
+	}
+
+	require.True(t, foundTest)
+}
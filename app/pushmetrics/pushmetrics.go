@@ -0,0 +1,99 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package pushmetrics pushes metrics of short-lived charon commands (e.g. create cluster or dkg)
+// to a Prometheus Pushgateway, since these never expose a scrapeable metrics endpoint.
+package pushmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+)
+
+const (
+	job = "charon"
+	// pushTimeout bounds a push independently of the parent context.
+	pushTimeout = 10 * time.Second
+)
+
+// Operation contains the results of a short-lived charon operation.
+type Operation struct {
+	// Name of the operation, e.g. create_cluster.
+	Name string
+	// Duration of the operation.
+	Duration time.Duration
+	// NumValidators created by the operation.
+	NumValidators int
+	// Phases contains optional durations of the operation phases by name.
+	Phases map[string]time.Duration
+	// Err is the error returned by the operation, nil if successful.
+	Err error
+}
+
+// Push pushes the operation metrics to the Pushgateway at the provided address.
+// It uses a fresh context with a short timeout since it is typically deferred until after
+// the operation's context has been cancelled, e.g. on failure or interrupt.
+func Push(ctx context.Context, addr string, op Operation) error {
+	ctx, cancel := context.WithTimeout(log.CopyFields(context.Background(), ctx), pushTimeout)
+	defer cancel()
+
+	result := "success"
+	if op.Err != nil {
+		result = "failure"
+	}
+
+	duration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "charon",
+		Subsystem: "operation",
+		Name:      "duration_seconds",
+		Help:      "Duration of the operation in seconds",
+	})
+	duration.Set(op.Duration.Seconds())
+
+	validators := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "charon",
+		Subsystem: "operation",
+		Name:      "validators",
+		Help:      "Number of validators created by the operation",
+	})
+	validators.Set(float64(op.NumValidators))
+
+	success := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "charon",
+		Subsystem: "operation",
+		Name:      "success",
+		Help:      "Set to 1 if the operation succeeded, else 0",
+	})
+	if op.Err == nil {
+		success.Set(1)
+	}
+
+	phases := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "charon",
+		Subsystem: "operation",
+		Name:      "phase_duration_seconds",
+		Help:      "Duration of the operation phases in seconds by phase",
+	}, []string{"phase"})
+	for phase, d := range op.Phases {
+		phases.WithLabelValues(phase).Set(d.Seconds())
+	}
+
+	err := push.New(addr, job).
+		Grouping("operation", op.Name).
+		Grouping("result", result).
+		Collector(duration).
+		Collector(validators).
+		Collector(success).
+		Collector(phases).
+		PushContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "push metrics")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package pushmetrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/pushmetrics"
+)
+
+func TestPush(t *testing.T) {
+	var (
+		path string
+		body []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		path = r.URL.Path
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// Push with a cancelled context, as when deferred after the operation failed or was interrupted.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := pushmetrics.Push(ctx, srv.URL, pushmetrics.Operation{
+		Name:          "create_cluster",
+		Duration:      time.Second,
+		NumValidators: 2,
+		Phases:        map[string]time.Duration{"tss_split": time.Millisecond},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "/metrics/job/charon/operation/create_cluster/result/success", path)
+	for _, name := range []string{
+		"charon_operation_duration_seconds",
+		"charon_operation_validators",
+		"charon_operation_success",
+		"charon_operation_phase_duration_seconds",
+		"tss_split",
+	} {
+		require.Contains(t, string(body), name)
+	}
+}
@@ -5,8 +5,10 @@ package app
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -16,25 +18,63 @@ import (
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 )
 
-const maxQBFTDebugger = 50 * (1 << 20) // 50 MB.
+const (
+	maxQBFTDebugger = 50 * (1 << 20) // 50 MB.
 
-// newQBFTDebugger returns a new qbftDebugger.
-func newQBFTDebugger() *qbftDebugger {
+	// qbftDebugTimeout is the maximum duration of serving a qbft debug dump.
+	qbftDebugTimeout = 10 * time.Second
+
+	// qbftDebugMaxAge is the default maximum age of buffered qbft debug instances.
+	qbftDebugMaxAge = time.Hour
+
+	// qbftDebugMaxInstances is the default maximum number of buffered qbft debug instances.
+	qbftDebugMaxInstances = 10000
+
+	// qbftDebugTruncatedHeader is the response header set when a partial qbft debug dump is served.
+	qbftDebugTruncatedHeader = "Charon-Truncated"
+)
+
+// newQBFTDebugger returns a new qbftDebugger retaining instances for up to maxAge and up to maxInstances.
+// Zero values disable the respective retention limit.
+func newQBFTDebugger(maxAge time.Duration, maxInstances int) *qbftDebugger {
 	gitHash, _ := version.GitCommit()
 
 	return &qbftDebugger{
-		gitHash: gitHash,
+		gitHash:      gitHash,
+		maxAge:       maxAge,
+		maxInstances: maxInstances,
+		nowFunc:      time.Now,
 	}
 }
 
-// qbftDebugger buffers up to 2MB worth of sniffed qbft messages in a fifo buffer serving them as a gzipped
-// *pbv1.SniffedConsensusSets protobuf on request.
+// sniffedInstance is a sniffed qbft instance buffered by the qbftDebugger.
+type sniffedInstance struct {
+	Instance *pbv1.SniffedConsensusInstance
+	Size     int
+	Added    time.Time
+}
+
+// qbftDebugger buffers up to 50MB worth of sniffed qbft instances in a fifo buffer serving them as a gzipped
+// *pbv1.SniffedConsensusSets protobuf on request. Instances older than maxAge or exceeding maxInstances are
+// evicted, oldest first.
 type qbftDebugger struct {
-	gitHash string
+	gitHash      string
+	maxAge       time.Duration
+	maxInstances int
+	nowFunc      func() time.Time
 
 	mu        sync.Mutex
 	totalSize int
-	sets      []*pbv1.SniffedConsensusInstance
+	sets      []sniffedInstance
+}
+
+// now returns the current time, defaulting to time.Now.
+func (d *qbftDebugger) now() time.Time {
+	if d.nowFunc == nil {
+		return time.Now()
+	}
+
+	return d.nowFunc()
 }
 
 // AddInstance adds the instance to the fifo buffer, removing older messages if the max size is exceeded.
@@ -42,68 +82,117 @@ func (d *qbftDebugger) AddInstance(instance *pbv1.SniffedConsensusInstance) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	// getSize returns the size of the proto or false.
-	getSize := func(instance *pbv1.SniffedConsensusInstance) (int, bool) {
-		b, err := proto.Marshal(instance)
-		return len(b), err == nil
+	b, err := proto.Marshal(instance)
+	if err != nil {
+		return // Just drop this if we cannot calculate the size
 	}
 
-	size, ok := getSize(instance)
-	if !ok {
-		return // Just drop this if we cannot calculate the size
+	d.totalSize += len(b)
+	d.sets = append(d.sets, sniffedInstance{
+		Instance: instance,
+		Size:     len(b),
+		Added:    d.now(),
+	})
+
+	d.prune()
+}
+
+// prune evicts the oldest instances exceeding the max size, max instances or max age.
+// It must be called with the mutex held.
+func (d *qbftDebugger) prune() {
+	isExpired := func(set sniffedInstance) bool {
+		return d.maxAge > 0 && d.now().Sub(set.Added) > d.maxAge
 	}
 
-	d.totalSize += size
-	d.sets = append(d.sets, instance)
+	for len(d.sets) > 0 {
+		if d.totalSize <= maxQBFTDebugger &&
+			(d.maxInstances <= 0 || len(d.sets) <= d.maxInstances) &&
+			!isExpired(d.sets[0]) {
+			break
+		}
 
-	for d.totalSize > maxQBFTDebugger {
-		dropped, _ := getSize(d.sets[0]) // Ignoring ok is ok here since we got the size when we added it.
-		d.totalSize -= dropped
+		d.totalSize -= d.sets[0].Size
 		d.sets = d.sets[1:]
 	}
+
+	qbftDebugInstancesGauge.Set(float64(len(d.sets)))
 }
 
 // ServeHTTP serves sniffed qbft messages in a fifo buffer as a gzipped
-// *pbv1.SniffedConsensusSets protobuf.
+// *pbv1.SniffedConsensusSets protobuf. A partial dump is served if the request
+// times out, indicated by the truncated response header.
 func (d *qbftDebugger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	b, err := d.getZippedProto()
+	ctx, cancel := context.WithTimeout(r.Context(), qbftDebugTimeout)
+	defer cancel()
+
+	b, truncated, err := d.getZippedProto(ctx)
 	if err != nil {
-		log.Warn(r.Context(), "Error serving qbft debug", err)
+		log.Warn(ctx, "Error serving qbft debug", err)
 		http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
 
 		return
 	}
 
+	if truncated {
+		log.Warn(ctx, "Serving truncated qbft debug dump", ctx.Err())
+		w.Header().Set(qbftDebugTruncatedHeader, "true")
+	}
+
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", `attachment; filename="qbft_messages.pb.gz"`)
 	_, _ = w.Write(b)
 }
 
 // getZippedProto returns a gzipped serialised *pbv1.SniffedConsensusSets protobuf of the fifo buffer.
-func (d *qbftDebugger) getZippedProto() ([]byte, error) {
+// It stops adding instances when the context is closed, returning true if the result was truncated.
+func (d *qbftDebugger) getZippedProto(ctx context.Context) ([]byte, bool, error) {
 	d.mu.Lock()
-	b, err := proto.Marshal(&pbv1.SniffedConsensusInstances{
-		Instances: d.sets,
-		GitHash:   d.gitHash,
-	})
+	d.prune()
+	sets := append([]sniffedInstance(nil), d.sets...)
 	d.mu.Unlock()
-	if err != nil {
-		return nil, errors.Wrap(err, "marshal proto")
-	}
 
 	var buf bytes.Buffer
 	zw, err := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
 	if err != nil {
-		return nil, errors.Wrap(err, "new gzip writer")
+		return nil, false, errors.Wrap(err, "new gzip writer")
+	}
+
+	// writeProto appends the serialised proto to the zipped output. Note that concatenated
+	// serialised protos are merged when unmarshalled, so repeated fields are appended.
+	writeProto := func(msg *pbv1.SniffedConsensusInstances) error {
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			return errors.Wrap(err, "marshal proto")
+		}
+
+		if _, err := zw.Write(b); err != nil {
+			return errors.Wrap(err, "zip proto")
+		}
+
+		return nil
+	}
+
+	if err := writeProto(&pbv1.SniffedConsensusInstances{GitHash: d.gitHash}); err != nil {
+		return nil, false, err
 	}
 
-	if _, err := zw.Write(b); err != nil {
-		return nil, errors.Wrap(err, "zip proto")
+	var truncated bool
+	for _, set := range sets {
+		if ctx.Err() != nil {
+			truncated = true
+			break
+		}
+
+		if err := writeProto(&pbv1.SniffedConsensusInstances{
+			Instances: []*pbv1.SniffedConsensusInstance{set.Instance},
+		}); err != nil {
+			return nil, false, err
+		}
 	}
 
 	if err := zw.Close(); err != nil {
-		return nil, errors.Wrap(err, "close gzip writer")
+		return nil, false, errors.Wrap(err, "close gzip writer")
 	}
 
-	return buf.Bytes(), nil
+	return buf.Bytes(), truncated, nil
 }
@@ -4,12 +4,15 @@ package app
 
 import (
 	"compress/gzip"
+	"context"
 	"io"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -58,6 +61,84 @@ func TestQBFTDebugger(t *testing.T) {
 	require.True(t, proto.Equal(&pbv1.SniffedConsensusInstances{Instances: instances}, resp))
 }
 
+func TestQBFTDebuggerTimeout(t *testing.T) {
+	debug := &qbftDebugger{gitHash: "abcdef"}
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		debug.AddInstance(&pbv1.SniffedConsensusInstance{
+			Msgs: []*pbv1.SniffedConsensusMsg{{
+				Timestamp: timestamppb.Now(),
+				Msg:       &pbv1.ConsensusMsg{Msg: randomQBFTMessage()},
+			}},
+		})
+	}
+
+	// Request with a deadline that is already exceeded.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/qbft", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	debug.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "true", rec.Header().Get(qbftDebugTruncatedHeader))
+
+	r, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	resp := new(pbv1.SniffedConsensusInstances)
+	require.NoError(t, proto.Unmarshal(b, resp))
+	require.Equal(t, "abcdef", resp.GitHash)
+	require.Less(t, len(resp.Instances), n)
+}
+
+func TestQBFTDebuggerRetention(t *testing.T) {
+	const (
+		maxAge       = time.Minute
+		maxInstances = 5
+	)
+
+	now := time.Unix(1700000000, 0)
+	debug := newQBFTDebugger(maxAge, maxInstances)
+	debug.nowFunc = func() time.Time { return now }
+
+	// retained returns the peer indexes of the retained instances.
+	retained := func() []int64 {
+		_, _, err := debug.getZippedProto(context.Background()) // Prunes expired instances.
+		require.NoError(t, err)
+
+		var resp []int64
+		for _, set := range debug.sets {
+			resp = append(resp, set.Instance.PeerIdx)
+		}
+		require.EqualValues(t, len(resp), promtestutil.ToFloat64(qbftDebugInstancesGauge))
+
+		return resp
+	}
+
+	// Add an instance every 10s over a simulated 100s window.
+	for i := int64(0); i < 10; i++ {
+		debug.AddInstance(&pbv1.SniffedConsensusInstance{PeerIdx: i, StartedAt: timestamppb.New(now)})
+		now = now.Add(10 * time.Second)
+	}
+
+	// The oldest instances exceeding max instances are evicted.
+	require.Equal(t, []int64{5, 6, 7, 8, 9}, retained())
+
+	// Instances older than max age are evicted, oldest first.
+	now = now.Add(35 * time.Second)
+	require.Equal(t, []int64{8, 9}, retained())
+
+	now = now.Add(maxAge)
+	require.Empty(t, retained())
+}
+
 func randomQBFTMessage() *pbv1.QBFTMsg {
 	return &pbv1.QBFTMsg{
 		Type:          rand.Int63(),
@@ -0,0 +1,325 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/core"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+)
+
+// newLockReloader returns a new lock reloader of the loaded cluster lock.
+func newLockReloader(conf Config, lock cluster.Lock) *lockReloader {
+	return &lockReloader{
+		conf: conf,
+		lock: lock,
+	}
+}
+
+// lockReloader reloads the cluster lock from disk on SIGHUP. Additive changes are applied
+// by the subscribers, while unsafe changes are rejected and logged.
+type lockReloader struct {
+	conf Config
+
+	mu   sync.Mutex
+	lock cluster.Lock
+	subs []reloadPrepareFunc
+}
+
+// reloadPrepareFunc prepares applying the changes from the previous to the next cluster lock without applying
+// them. It returns a commit function applying the prepared changes, which must not fail.
+type reloadPrepareFunc func(ctx context.Context, prev, next cluster.Lock) (commit func(), err error)
+
+// Subscribe registers a subscriber preparing the changes of reloaded cluster locks.
+// This is not thread safe, it must be called before Run.
+func (r *lockReloader) Subscribe(fn reloadPrepareFunc) {
+	r.subs = append(r.subs, fn)
+}
+
+// Run reloads the cluster lock on each SIGHUP until the context is closed.
+func (r *lockReloader) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "reload")
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			log.Info(ctx, "Reloading cluster lock on SIGHUP")
+
+			if err := r.Reload(ctx); err != nil {
+				log.Error(ctx, "Rejected cluster lock reload", err)
+			}
+		}
+	}
+}
+
+// Reload loads the cluster lock from disk and applies it via the subscribers if it only contains safe changes.
+// The changes are only applied if all subscribers prepared them successfully, so a rejected reload has no effect.
+func (r *lockReloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lock, err := loadLock(ctx, r.conf)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyLockReload(r.lock, lock); err != nil {
+		return err
+	}
+
+	var commits []func()
+	for _, sub := range r.subs {
+		commit, err := sub(ctx, r.lock, lock)
+		if err != nil {
+			return err
+		}
+		commits = append(commits, commit)
+	}
+
+	for _, commit := range commits {
+		commit()
+	}
+
+	log.Info(ctx, "Cluster lock reloaded",
+		z.Int("validators", len(lock.Validators)),
+		z.Int("added", len(lock.Validators)-len(r.lock.Validators)))
+
+	r.lock = lock
+
+	return nil
+}
+
+// verifyLockReload returns an error if the reloaded cluster lock contains changes that cannot
+// be applied to a running node. Only adding validators and changing validator addresses,
+// graffiti and builder relays are supported.
+func verifyLockReload(prev, next cluster.Lock) error {
+	if len(prev.Operators) != len(next.Operators) {
+		return errors.New("cluster operators added or removed",
+			z.Int("prev", len(prev.Operators)), z.Int("next", len(next.Operators)))
+	}
+
+	for i, op := range prev.Operators {
+		if op.ENR != next.Operators[i].ENR {
+			return errors.New("cluster operator changed", z.Int("operator", i))
+		} else if op.Weight != next.Operators[i].Weight {
+			return errors.New("cluster operator weight changed", z.Int("operator", i))
+		}
+	}
+
+	if prev.Threshold != next.Threshold {
+		return errors.New("cluster threshold changed",
+			z.Int("prev", prev.Threshold), z.Int("next", next.Threshold))
+	}
+
+	if !bytes.Equal(prev.ForkVersion, next.ForkVersion) {
+		return errors.New("cluster fork version changed")
+	}
+
+	if len(next.Validators) < len(prev.Validators) {
+		return errors.New("cluster validators removed",
+			z.Int("prev", len(prev.Validators)), z.Int("next", len(next.Validators)))
+	}
+
+	for i, val := range prev.Validators {
+		if !bytes.Equal(val.PubKey, next.Validators[i].PubKey) ||
+			len(val.PubShares) != len(next.Validators[i].PubShares) {
+			return errors.New("cluster validator changed", z.Int("validator", i))
+		}
+
+		for j, pubshare := range val.PubShares {
+			if !bytes.Equal(pubshare, next.Validators[i].PubShares[j]) {
+				return errors.New("cluster validator public share changed", z.Int("validator", i))
+			}
+		}
+	}
+
+	if len(next.FeeRecipientAddresses()) < len(next.Validators) {
+		return errors.New("missing validator fee recipient addresses")
+	}
+
+	return nil
+}
+
+// newClusterValidators returns an empty cluster validators registry.
+func newClusterValidators() *clusterValidators {
+	return &clusterValidators{
+		pubSharesByKey: make(map[core.PubKey]map[int]tblsv2.PublicKey),
+		feeRecipients:  make(map[core.PubKey]string),
+		graffiti:       make(map[core.PubKey]string),
+		builderRelays:  make(map[core.PubKey][]string),
+	}
+}
+
+// clusterValidators is a thread safe registry of the cluster validators and their config
+// that supports adding validators at runtime.
+type clusterValidators struct {
+	mu             sync.RWMutex
+	eth2Pubkeys    []eth2p0.BLSPubKey
+	pubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey // map[pubkey]map[shareIdx]pubshare
+	feeRecipients  map[core.PubKey]string
+	graffiti       map[core.PubKey]string
+	builderRelays  map[core.PubKey][]string
+}
+
+// Update adds the validators of the cluster lock to the empty registry and returns their public keys in lock order.
+func (v *clusterValidators) Update(lock cluster.Lock) ([]core.PubKey, error) {
+	update, err := v.Prepare(cluster.Lock{}, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	update.Commit()
+
+	return update.Added, nil
+}
+
+// validatorsUpdate is a prepared update of the cluster validators registry.
+type validatorsUpdate struct {
+	// Added are the public keys of the added validators in lock order.
+	Added []core.PubKey
+	// PubSharesByKey are the public shares of the added validators by public key.
+	PubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey
+	// Commit applies the update to the registry.
+	Commit func()
+}
+
+// Prepare returns the update of the registry from the previous to the next cluster lock, adding the
+// validators of the next lock not in the previous lock and updating the config of all validators.
+// The next lock must extend the validators of the previous lock, see verifyLockReload.
+func (v *clusterValidators) Prepare(prev, next cluster.Lock) (validatorsUpdate, error) {
+	feeRecipients := next.FeeRecipientAddresses()
+
+	var (
+		pubkeys        []core.PubKey
+		eth2Pubkeys    []eth2p0.BLSPubKey
+		pubSharesByKey = make(map[core.PubKey]map[int]tblsv2.PublicKey)
+	)
+	for i, dv := range next.Validators {
+		pubkey, err := dv.PublicKey()
+		if err != nil {
+			return validatorsUpdate{}, err
+		}
+
+		corePubkey, err := core.PubKeyFromBytes(pubkey[:])
+		if err != nil {
+			return validatorsUpdate{}, err
+		}
+
+		pubkeys = append(pubkeys, corePubkey)
+
+		if i < len(prev.Validators) {
+			continue // Existing validators only update their config.
+		}
+
+		allPubShares := make(map[int]tblsv2.PublicKey)
+		for i, b := range dv.PubShares {
+			pubshare, err := tblsconv2.PubkeyFromBytes(b)
+			if err != nil {
+				return validatorsUpdate{}, err
+			}
+
+			// share index is 1-indexed
+			allPubShares[i+1] = pubshare
+		}
+
+		pubSharesByKey[corePubkey] = allPubShares
+		eth2Pubkeys = append(eth2Pubkeys, eth2p0.BLSPubKey(pubkey))
+	}
+
+	commit := func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+
+		for i, pubkey := range pubkeys {
+			v.feeRecipients[pubkey] = feeRecipients[i]
+			v.graffiti[pubkey] = next.Validators[i].Graffiti
+			v.builderRelays[pubkey] = next.Validators[i].BuilderRelays
+		}
+
+		for pubkey, pubshares := range pubSharesByKey {
+			v.pubSharesByKey[pubkey] = pubshares
+		}
+		v.eth2Pubkeys = append(v.eth2Pubkeys, eth2Pubkeys...)
+	}
+
+	var added []core.PubKey
+	if len(pubkeys) > len(prev.Validators) {
+		added = pubkeys[len(prev.Validators):]
+	}
+
+	return validatorsUpdate{Added: added, PubSharesByKey: pubSharesByKey, Commit: commit}, nil
+}
+
+// PubShares returns all public shares of the validator by share index.
+func (v *clusterValidators) PubShares(pubkey core.PubKey) (map[int]tblsv2.PublicKey, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	pubshares, ok := v.pubSharesByKey[pubkey]
+
+	return pubshares, ok
+}
+
+// PubSharesByKey returns all public shares of the provided validators by public key.
+func (v *clusterValidators) PubSharesByKey(pubkeys []core.PubKey) map[core.PubKey]map[int]tblsv2.PublicKey {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	resp := make(map[core.PubKey]map[int]tblsv2.PublicKey)
+	for _, pubkey := range pubkeys {
+		resp[pubkey] = v.pubSharesByKey[pubkey]
+	}
+
+	return resp
+}
+
+// Eth2Pubkeys returns the public keys of all validators.
+func (v *clusterValidators) Eth2Pubkeys() []eth2p0.BLSPubKey {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return append([]eth2p0.BLSPubKey(nil), v.eth2Pubkeys...)
+}
+
+// FeeRecipient returns the fee recipient address of the validator.
+func (v *clusterValidators) FeeRecipient(pubkey core.PubKey) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return v.feeRecipients[pubkey]
+}
+
+// Graffiti returns the graffiti of the validator.
+func (v *clusterValidators) Graffiti(pubkey core.PubKey) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return v.graffiti[pubkey]
+}
+
+// BuilderRelays returns the builder relays preferred by the validator.
+func (v *clusterValidators) BuilderRelays(pubkey core.PubKey) []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return v.builderRelays[pubkey]
+}
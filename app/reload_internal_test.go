@@ -0,0 +1,204 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestLockReloader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	full, _, _ := cluster.NewForT(t, 3, 3, 4, 1)
+
+	// withValidators returns the full lock with only the first n validators.
+	withValidators := func(n int) cluster.Lock {
+		lock := full
+		lock.Validators = full.Validators[:n]
+		lock.ValidatorAddresses = full.ValidatorAddresses[:n]
+		lock.NumValidators = n
+
+		return lock
+	}
+
+	// Start with the first validator only.
+	prev := withValidators(1)
+	lock := withValidators(2)
+
+	lockFile := filepath.Join(t.TempDir(), "cluster-lock.json")
+	writeTestLock(t, lockFile, prev)
+
+	conf := Config{LockFile: lockFile, NoVerify: true}
+	loaded, err := loadLock(ctx, conf)
+	require.NoError(t, err)
+
+	vals := newClusterValidators()
+	pubkeys, err := vals.Update(loaded)
+	require.NoError(t, err)
+	require.Len(t, pubkeys, 1)
+
+	mutableConf, err := newMutableConfig(ctx, conf, pubkeys)
+	require.NoError(t, err)
+
+	var (
+		fail    atomic.Bool
+		commits atomic.Int64
+	)
+	reloader := newLockReloader(conf, loaded)
+	reloader.Subscribe(func(ctx context.Context, prev, next cluster.Lock) (func(), error) {
+		update, err := vals.Prepare(prev, next)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() {
+			update.Commit()
+			mutableConf.AddValidators(update.Added)
+			commits.Add(1)
+		}, nil
+	})
+	reloader.Subscribe(func(context.Context, cluster.Lock, cluster.Lock) (func(), error) {
+		if fail.Load() {
+			return nil, errors.New("prepare failed")
+		}
+
+		return func() {}, nil
+	})
+
+	// Prevent the default SIGHUP handler from terminating the test before the reloader is listening.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	go reloader.Run(ctx)
+
+	// Add the second validator.
+	writeTestLock(t, lockFile, lock)
+
+	pk, err := lock.Validators[1].PublicKey()
+	require.NoError(t, err)
+	added, err := core.PubKeyFromBytes(pk[:])
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+		_, ok := vals.PubShares(added)
+
+		return ok
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, mutableConf.SetValidatorEnabled(string(added), true))
+	require.True(t, mutableConf.ValidatorEnabled(added))
+	require.Equal(t, lock.FeeRecipientAddresses()[1], vals.FeeRecipient(added))
+	require.Len(t, vals.Eth2Pubkeys(), 2)
+
+	t.Run("failed prepare", func(t *testing.T) {
+		writeTestLock(t, lockFile, full)
+		before := commits.Load()
+
+		// Nothing is applied if any subscriber fails to prepare.
+		fail.Store(true)
+		err := reloader.Reload(ctx)
+		require.ErrorContains(t, err, "prepare failed")
+		require.Equal(t, before, commits.Load())
+		require.Len(t, vals.Eth2Pubkeys(), 2)
+
+		// The retry only adds the validators missing from the previously applied lock.
+		fail.Store(false)
+		require.NoError(t, reloader.Reload(ctx))
+		require.Equal(t, before+1, commits.Load())
+		require.Len(t, vals.Eth2Pubkeys(), 3)
+
+		pk, err := full.Validators[2].PublicKey()
+		require.NoError(t, err)
+		added, err := core.PubKeyFromBytes(pk[:])
+		require.NoError(t, err)
+		_, ok := vals.PubShares(added)
+		require.True(t, ok)
+	})
+
+	t.Run("removed operator", func(t *testing.T) {
+		next := lock
+		next.Operators = lock.Operators[:3]
+		writeTestLock(t, lockFile, next)
+
+		err := reloader.Reload(ctx)
+		require.ErrorContains(t, err, "cluster operators added or removed")
+	})
+
+	t.Run("removed validator", func(t *testing.T) {
+		writeTestLock(t, lockFile, prev)
+
+		err := reloader.Reload(ctx)
+		require.ErrorContains(t, err, "cluster validators removed")
+	})
+
+	t.Run("changed threshold", func(t *testing.T) {
+		next := lock
+		next.Threshold = 2
+		writeTestLock(t, lockFile, next)
+
+		err := reloader.Reload(ctx)
+		require.ErrorContains(t, err, "cluster threshold changed")
+	})
+}
+
+func writeTestLock(t *testing.T, filename string, lock cluster.Lock) {
+	t.Helper()
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filename, b, 0o644))
+}
+
+func TestClusterValidatorsBuilderRelays(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0, cluster.WithVersion("v1.6.0"))
+
+	relays := []string{"https://relay0.example.com", "https://relay1.example.com"}
+	lock.Validators[0].BuilderRelays = relays
+
+	lock, err := lock.SetLockHash()
+	require.NoError(t, err)
+
+	// Builder relays round-trip through the lock.
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	var loaded cluster.Lock
+	require.NoError(t, json.Unmarshal(b, &loaded))
+	require.NoError(t, loaded.VerifyHashes())
+	require.Equal(t, relays, loaded.Validators[0].BuilderRelays)
+
+	vals := newClusterValidators()
+	pubkeys, err := vals.Update(loaded)
+	require.NoError(t, err)
+	require.Equal(t, relays, vals.BuilderRelays(pubkeys[0]))
+	require.Empty(t, vals.BuilderRelays(pubkeys[1]))
+
+	// Builder relays of existing validators are updated on reload.
+	next := loaded
+	next.Validators = append([]cluster.DistValidator(nil), loaded.Validators...)
+	next.Validators[1].BuilderRelays = relays[:1]
+
+	update, err := vals.Prepare(loaded, next)
+	require.NoError(t, err)
+	require.Empty(t, update.Added)
+	update.Commit()
+	require.Equal(t, relays[:1], vals.BuilderRelays(pubkeys[1]))
+}
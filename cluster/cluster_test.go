@@ -15,6 +15,7 @@ import (
 
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/eth2util"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 	"github.com/obolnetwork/charon/testutil"
 )
 
@@ -59,6 +60,37 @@ func TestEncode(t *testing.T) {
 				withdrawalAddrs = append(withdrawalAddrs, testutil.RandomETHAddress())
 			}
 
+			creator := cluster.Creator{
+				Address:         testutil.RandomETHAddress(),
+				ConfigSignature: testutil.RandomSecp256k1Signature(),
+			}
+
+			operators := []cluster.Operator{
+				{
+					Address:         testutil.RandomETHAddress(),
+					ENR:             fmt.Sprintf("enr://%x", testutil.RandomBytes32()),
+					ConfigSignature: testutil.RandomSecp256k1Signature(),
+					ENRSignature:    testutil.RandomSecp256k1Signature(),
+				},
+				{
+					Address:         testutil.RandomETHAddress(),
+					ENR:             fmt.Sprintf("enr://%x", testutil.RandomBytes32()),
+					ConfigSignature: testutil.RandomSecp256k1Signature(),
+					ENRSignature:    testutil.RandomSecp256k1Signature(),
+				},
+			}
+
+			// Definition versions prior to v1.6.0 don't support operator weights, observers and tss schemes.
+			if cluster.SupportOperatorWeights(version) {
+				operators[0].Weight = 2
+			}
+			if cluster.SupportObservers(version) {
+				operators[1].Observer = true
+			}
+			if cluster.SupportTSSScheme(version) {
+				opts = append(opts, cluster.WithTSSScheme(tblsv2.DefaultTSSScheme))
+			}
+
 			definition, err := cluster.NewDefinition(
 				"test definition",
 				numVals,
@@ -66,24 +98,8 @@ func TestEncode(t *testing.T) {
 				feeRecipientAddrs,
 				withdrawalAddrs,
 				eth2util.Sepolia.ForkVersionHex,
-				cluster.Creator{
-					Address:         testutil.RandomETHAddress(),
-					ConfigSignature: testutil.RandomSecp256k1Signature(),
-				},
-				[]cluster.Operator{
-					{
-						Address:         testutil.RandomETHAddress(),
-						ENR:             fmt.Sprintf("enr://%x", testutil.RandomBytes32()),
-						ConfigSignature: testutil.RandomSecp256k1Signature(),
-						ENRSignature:    testutil.RandomSecp256k1Signature(),
-					},
-					{
-						Address:         testutil.RandomETHAddress(),
-						ENR:             fmt.Sprintf("enr://%x", testutil.RandomBytes32()),
-						ConfigSignature: testutil.RandomSecp256k1Signature(),
-						ENRSignature:    testutil.RandomSecp256k1Signature(),
-					},
-				},
+				creator,
+				operators,
 				rand.New(rand.NewSource(0)),
 				opts...,
 			)
@@ -135,6 +151,11 @@ func TestEncode(t *testing.T) {
 							testutil.RandomBytes48(),
 						},
 						DepositData: cluster.RandomDepositData(),
+						Graffiti:    "graffiti 0",
+						BuilderRelays: []string{
+							"https://relay0.example.com",
+							"https://relay1.example.com",
+						},
 					}, {
 						PubKey: testutil.RandomBytes48(),
 						PubShares: [][]byte{
@@ -142,14 +163,17 @@ func TestEncode(t *testing.T) {
 							testutil.RandomBytes48(),
 						},
 						DepositData: cluster.RandomDepositData(),
+						Graffiti:    "graffiti 1",
 					},
 				},
 			}
 
-			// Lock version prior to v1.6.0 don't support DepositData.
+			// Lock version prior to v1.6.0 don't support DepositData, Graffiti and BuilderRelays.
 			if isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5) {
 				for i := range lock.Validators {
 					lock.Validators[i].DepositData = cluster.DepositData{}
+					lock.Validators[i].Graffiti = ""
+					lock.Validators[i].BuilderRelays = nil
 				}
 			}
 
@@ -215,6 +239,8 @@ func TestExamples(t *testing.T) {
 			b, err := os.ReadFile(file)
 			require.NoError(t, err)
 
+			require.NoError(t, cluster.VerifyDefinitionJSON(b))
+
 			var def cluster.Definition
 			err = json.Unmarshal(b, &def)
 			require.NoError(t, err)
@@ -224,6 +250,55 @@ func TestExamples(t *testing.T) {
 	}
 }
 
+func TestVerifyDefinitionJSON(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0)
+
+	b, err := json.Marshal(lock.Definition)
+	require.NoError(t, err)
+	require.NoError(t, cluster.VerifyDefinitionJSON(b))
+
+	modify := func(t *testing.T, version string, fn func(map[string]any)) []byte {
+		t.Helper()
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(b, &fields))
+		fields["version"] = version
+		fn(fields)
+
+		resp, err := json.Marshal(fields)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		data := modify(t, "v1.6.0", func(fields map[string]any) {
+			fields["num_validator"] = 1
+		})
+		err := cluster.VerifyDefinitionJSON(data)
+		require.ErrorContains(t, err, "invalid definition field")
+		require.ErrorContains(t, err, `unknown field "num_validator"`)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		data := modify(t, "v1.6.0", func(fields map[string]any) {
+			delete(fields, "threshold")
+		})
+		err := cluster.VerifyDefinitionJSON(data)
+		require.ErrorContains(t, err, "missing required definition field")
+	})
+
+	t.Run("released versions", func(t *testing.T) {
+		for _, version := range []string{"v1.4.0", "v1.5.0"} {
+			data := modify(t, version, func(fields map[string]any) {
+				fields["unknown"] = true
+				delete(fields, "threshold")
+			})
+			require.NoError(t, cluster.VerifyDefinitionJSON(data))
+		}
+	})
+}
+
 func TestDefinitionPeers(t *testing.T) {
 	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 5)
 	peers, err := lock.Peers()
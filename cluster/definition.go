@@ -36,6 +36,13 @@ func WithVersion(version string) func(*Definition) {
 	}
 }
 
+// WithTSSScheme returns an option to set a non-default threshold secret sharing scheme in a new definition.
+func WithTSSScheme(scheme string) func(*Definition) {
+	return func(d *Definition) {
+		d.TSSScheme = scheme
+	}
+}
+
 // WithLegacyVAddrs returns an option to set single feeRecipient address and withdrawal address to validator addresses.
 func WithLegacyVAddrs(feeRecipientAddress, withdrawalAddress string) func(*Definition) {
 	return func(d *Definition) {
@@ -138,6 +145,10 @@ type Definition struct {
 	// ValidatorAddresses define addresses of each validator.
 	ValidatorAddresses []ValidatorAddresses `json:"validators" ssz:"CompositeList[65536]" config_hash:"10" definition_hash:"10"`
 
+	// TSSScheme is the name of the threshold secret sharing scheme used to split the validator keys during the DKG,
+	// see tblsv2.GetTSSScheme. It is only supported from v1.6, empty means the default scheme. Max 32 chars.
+	TSSScheme string `json:"tss_scheme,omitempty" ssz:"ByteList[32]" config_hash:"11" definition_hash:"12"`
+
 	// ConfigHash uniquely identifies a cluster definition excluding operator ENRs and signatures.
 	ConfigHash []byte `json:"config_hash,0xhex" ssz:"Bytes32" config_hash:"-" definition_hash:"11"`
 
@@ -415,6 +426,54 @@ func (d *Definition) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// strictDefinitionFields defines the required fields of definition versions that are decoded strictly.
+// Only the draft version is strict, definitions of released versions may contain unknown fields
+// and lack fields for backwards compatibility.
+var strictDefinitionFields = map[string][]string{
+	v1_6: requiredDefinitionFieldsV1x6,
+}
+
+// requiredDefinitionFieldsV1x6 are the required top-level fields of v1.6 definitions.
+var requiredDefinitionFieldsV1x6 = []string{
+	"creator", "operators", "uuid", "version", "num_validators", "threshold",
+	"validators", "dkg_algorithm", "fork_version", "config_hash", "definition_hash",
+}
+
+// VerifyDefinitionJSON returns an error if the definition JSON contains unknown fields or lacks required fields.
+// Only the draft definition version is verified, released versions are always accepted.
+func VerifyDefinitionJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return errors.Wrap(err, "unmarshal definition")
+	}
+
+	var version string
+	if err := json.Unmarshal(fields["version"], &version); err != nil {
+		return errors.Wrap(err, "unmarshal version")
+	}
+
+	required, ok := strictDefinitionFields[version]
+	if !ok {
+		return nil
+	}
+
+	for _, field := range required {
+		if _, ok := fields[field]; !ok {
+			return errors.New("missing required definition field", z.Str("field", field), z.Str("version", version))
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var defJSON definitionJSONv1x5
+	if err := dec.Decode(&defJSON); err != nil {
+		return errors.Wrap(err, "invalid definition field", z.Str("version", version))
+	}
+
+	return nil
+}
+
 // VerifyHashes returns an error if hashes populated from json object doesn't matches actual hashes.
 func (d Definition) VerifyHashes() error {
 	configHash, err := hashDefinition(d, true)
@@ -545,6 +604,7 @@ func marshalDefinitionV1x5(def Definition) ([]byte, error) {
 			Address:         def.Creator.Address,
 			ConfigSignature: def.Creator.ConfigSignature,
 		},
+		TSSScheme: def.TSSScheme,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal definition")
@@ -678,6 +738,7 @@ func unmarshalDefinitionV1x5(data []byte) (def Definition, err error) {
 			Address:         defJSON.Creator.Address,
 			ConfigSignature: defJSON.Creator.ConfigSignature,
 		},
+		TSSScheme: defJSON.TSSScheme,
 	}, nil
 }
 
@@ -764,6 +825,7 @@ type definitionJSONv1x5 struct {
 	ForkVersion        ethHex                    `json:"fork_version"`
 	ConfigHash         ethHex                    `json:"config_hash"`
 	DefinitionHash     ethHex                    `json:"definition_hash"`
+	TSSScheme          string                    `json:"tss_scheme,omitempty"`
 }
 
 // Creator identifies the creator of a cluster definition.
@@ -18,6 +18,13 @@ type DistValidator struct {
 
 	// DepositData is the validator deposit data.
 	DepositData DepositData `json:"deposit_data,omitempty" ssz:"Composite" lock_hash:"2"`
+
+	// Graffiti is the optional custom graffiti (max 32 bytes) included in blocks proposed by the validator.
+	Graffiti string `json:"graffiti,omitempty" ssz:"Bytes32" lock_hash:"3"`
+
+	// BuilderRelays are the optional builder relay URLs preferred by the validator for builder API block proposals.
+	// Validator builder registrations are also submitted to these relays by the broadcaster.
+	BuilderRelays []string `json:"builder_relays,omitempty" ssz:"CompositeList[16],ByteList[256]" lock_hash:"4"`
 }
 
 // PublicKey returns the validator BLS group public key.
@@ -51,9 +58,11 @@ type distValidatorJSONv1x2to5 struct {
 
 // distValidatorJSONv1x6 is the json formatter of DistValidator for versions v1.6.0 or later.
 type distValidatorJSONv1x6 struct {
-	PubKey      ethHex          `json:"distributed_public_key"`
-	PubShares   []ethHex        `json:"public_shares,omitempty"`
-	DepositData depositDataJSON `json:"deposit_data,omitempty"`
+	PubKey        ethHex          `json:"distributed_public_key"`
+	PubShares     []ethHex        `json:"public_shares,omitempty"`
+	DepositData   depositDataJSON `json:"deposit_data,omitempty"`
+	Graffiti      string          `json:"graffiti,omitempty"`
+	BuilderRelays []string        `json:"builder_relays,omitempty"`
 }
 
 func distValidatorsFromV1x1(distValidators []distValidatorJSONv1x1) []DistValidator {
@@ -121,9 +130,11 @@ func distValidatorsFromV1x6orLater(distValidators []distValidatorJSONv1x6) []Dis
 			shares = append(shares, share)
 		}
 		resp = append(resp, DistValidator{
-			PubKey:      dv.PubKey,
-			PubShares:   shares,
-			DepositData: depositDataFromJSON(dv.DepositData),
+			PubKey:        dv.PubKey,
+			PubShares:     shares,
+			DepositData:   depositDataFromJSON(dv.DepositData),
+			Graffiti:      dv.Graffiti,
+			BuilderRelays: dv.BuilderRelays,
 		})
 	}
 
@@ -139,9 +150,11 @@ func distValidatorsToV1x6OrLater(distValidators []DistValidator) []distValidator
 		}
 
 		resp = append(resp, distValidatorJSONv1x6{
-			PubKey:      dv.PubKey,
-			PubShares:   shares,
-			DepositData: depositDataToJSON(dv.DepositData),
+			PubKey:        dv.PubKey,
+			PubShares:     shares,
+			DepositData:   depositDataToJSON(dv.DepositData),
+			Graffiti:      dv.Graffiti,
+			BuilderRelays: dv.BuilderRelays,
 		})
 	}
 
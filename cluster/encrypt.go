@@ -0,0 +1,110 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cluster
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// encryptedLock is the json file representation of a passphrase encrypted cluster lock.
+// The encrypted secret is the plaintext indented lock json, so hashes remain verifiable after decryption.
+type encryptedLock struct {
+	Crypto  map[string]any `json:"crypto"`
+	Version uint           `json:"version"`
+}
+
+// EncryptLock returns the lock json encrypted with the password using the EIP-2335 keystore scheme.
+func EncryptLock(lock Lock, password string) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("empty cluster lock password")
+	}
+
+	b, err := json.MarshalIndent(lock, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal cluster lock")
+	}
+
+	encryptor := keystorev4.New()
+	fields, err := encryptor.Encrypt(b, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt cluster lock")
+	}
+
+	resp, err := json.MarshalIndent(encryptedLock{
+		Crypto:  fields,
+		Version: encryptor.Version(),
+	}, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal encrypted cluster lock")
+	}
+
+	return resp, nil
+}
+
+// DecryptLock returns the lock unmarshalled from the file contents, decrypting it with the password if encrypted.
+// Plaintext lock files are unmarshalled as is, the password is ignored.
+func DecryptLock(b []byte, password string) (Lock, error) {
+	if !IsEncryptedLock(b) {
+		var lock Lock
+		if err := json.Unmarshal(b, &lock); err != nil {
+			return Lock{}, errors.Wrap(err, "unmarshal cluster lock")
+		}
+
+		return lock, nil
+	}
+
+	if password == "" {
+		return Lock{}, errors.New("cluster lock is encrypted, but no password provided")
+	}
+
+	var enc encryptedLock
+	if err := json.Unmarshal(b, &enc); err != nil {
+		return Lock{}, errors.Wrap(err, "unmarshal encrypted cluster lock")
+	}
+
+	plaintext, err := keystorev4.New().Decrypt(enc.Crypto, password)
+	if err != nil {
+		return Lock{}, errors.Wrap(err, "decrypt cluster lock")
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(plaintext, &lock); err != nil {
+		return Lock{}, errors.Wrap(err, "unmarshal decrypted cluster lock")
+	}
+
+	return lock, nil
+}
+
+// IsEncryptedLock returns true if the lock file contents is a passphrase encrypted lock.
+func IsEncryptedLock(b []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return false
+	}
+
+	_, ok := fields["crypto"]
+
+	return ok
+}
+
+// LoadLockPassword returns the cluster lock password read from the file, or an empty password
+// if no file is provided, indicating an unencrypted lock.
+func LoadLockPassword(passwordFile string) (string, error) {
+	if passwordFile == "" {
+		return "", nil
+	}
+
+	b, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return "", errors.Wrap(err, "read cluster lock password file", z.Str("file", passwordFile))
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
@@ -24,6 +24,7 @@ import (
 )
 
 // FetchDefinition fetches cluster definition file from a remote URI.
+// The definition JSON is verified with VerifyDefinitionJSON.
 func FetchDefinition(ctx context.Context, url string) (Definition, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
@@ -44,6 +45,10 @@ func FetchDefinition(ctx context.Context, url string) (Definition, error) {
 		return Definition{}, errors.Wrap(err, "read response body")
 	}
 
+	if err := VerifyDefinitionJSON(buf); err != nil {
+		return Definition{}, errors.Wrap(err, "invalid definition file", z.Str("url", url))
+	}
+
 	var res Definition
 	if err := json.Unmarshal(buf, &res); err != nil {
 		return Definition{}, errors.Wrap(err, "unmarshal definition")
@@ -55,7 +60,7 @@ func FetchDefinition(ctx context.Context, url string) (Definition, error) {
 // uuid returns a random uuid.
 func uuid(random io.Reader) string {
 	b := make([]byte, 16)
-	_, _ = random.Read(b)
+	_, _ = io.ReadFull(random, b) // Device entropy sources may return short reads.
 
 	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 }
@@ -4,6 +4,7 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -83,6 +84,11 @@ func TestFetchDefinition(t *testing.T) {
 	validDef := lock.Definition
 	invalidDef := Definition{}
 
+	draft, _, _ := NewForT(t, 1, 2, 3, 0, WithVersion(v1_6))
+	unknownFieldDef, err := json.Marshal(draft.Definition)
+	require.NoError(t, err)
+	unknownFieldDef = append(unknownFieldDef[:len(unknownFieldDef)-1], []byte(`,"unknown":true}`)...)
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch strings.TrimSpace(r.URL.Path) {
 		case "/validDef":
@@ -91,6 +97,8 @@ func TestFetchDefinition(t *testing.T) {
 		case "/invalidDef":
 			b, _ := invalidDef.MarshalJSON()
 			_, _ = w.Write(b)
+		case "/unknownFieldDef":
+			_, _ = w.Write(unknownFieldDef)
 		}
 	}))
 	defer server.Close()
@@ -113,6 +121,11 @@ func TestFetchDefinition(t *testing.T) {
 			want:    invalidDef,
 			wantErr: true,
 		},
+		{
+			name:    "Fetch draft definition with unknown field",
+			url:     fmt.Sprintf("%s/%s", server.URL, "unknownFieldDef"),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
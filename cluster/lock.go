@@ -6,8 +6,12 @@ import (
 	"bytes"
 	"encoding/json"
 
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
 )
@@ -168,6 +172,57 @@ func (l Lock) VerifySignatures() error {
 	return nil
 }
 
+// VerifyDepositData returns an error if the deposit data signature of any distributed validator doesn't
+// verify against the recorded deposit message, for example due to tampered withdrawal credentials.
+// Validators without deposit data are ignored, since earlier versions didn't populate it.
+func (l Lock) VerifyDepositData() error {
+	var network string
+	for i, val := range l.Validators {
+		if len(val.DepositData.Signature) == 0 {
+			continue
+		}
+
+		if network == "" {
+			var err error
+			network, err = eth2util.ForkVersionToNetwork(l.ForkVersion)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !bytes.Equal(val.PubKey, val.DepositData.PubKey) {
+			return errors.New("deposit data pubkey mismatch", z.Int("validator", i))
+		}
+
+		pubkey, err := tblsconv2.PubkeyFromBytes(val.PubKey)
+		if err != nil {
+			return err
+		}
+
+		sig, err := tblsconv2.SignatureFromBytes(val.DepositData.Signature)
+		if err != nil {
+			return err
+		}
+
+		msg := eth2p0.DepositMessage{
+			PublicKey:             eth2p0.BLSPubKey(pubkey),
+			WithdrawalCredentials: val.DepositData.WithdrawalCredentials,
+			Amount:                eth2p0.Gwei(val.DepositData.Amount),
+		}
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+		if err != nil {
+			return err
+		}
+
+		if err := tblsv2.Verify(pubkey, sigRoot[:], sig); err != nil {
+			return errors.Wrap(err, "verify deposit data signature", z.Int("validator", i))
+		}
+	}
+
+	return nil
+}
+
 func marshalLockV1x0or1(lock Lock, lockHash [32]byte) ([]byte, error) {
 	resp, err := json.Marshal(lockJSONv1x0or1{
 		Definition:         lock.Definition,
@@ -3,11 +3,17 @@
 package cluster_test
 
 import (
+	"encoding/json"
 	"testing"
 
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	"github.com/obolnetwork/charon/testutil"
 )
 
 func TestVerifyLock(t *testing.T) {
@@ -15,3 +21,83 @@ func TestVerifyLock(t *testing.T) {
 	require.NoError(t, lock.Definition.VerifySignatures())
 	require.NoError(t, lock.VerifySignatures())
 }
+
+func TestVerifyDepositData(t *testing.T) {
+	const (
+		numVals  = 2
+		numNodes = 4
+		thresh   = 3
+	)
+
+	lock, _, dvShares := cluster.NewForT(t, numVals, thresh, numNodes, 0)
+
+	// NewForT creates mainnet locks.
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	require.NoError(t, err)
+
+	for i, shares := range dvShares {
+		shareMap := make(map[int]tblsv2.PrivateKey)
+		for j, share := range shares {
+			shareMap[j+1] = share
+		}
+
+		secret, err := tblsv2.RecoverSecret(shareMap, numNodes, thresh)
+		require.NoError(t, err)
+
+		msg, err := deposit.NewMessage(eth2p0.BLSPubKey(lock.Validators[i].PubKey), testutil.RandomETHAddress())
+		require.NoError(t, err)
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+		require.NoError(t, err)
+
+		sig, err := tblsv2.Sign(secret, sigRoot[:])
+		require.NoError(t, err)
+
+		lock.Validators[i].DepositData = cluster.DepositData{
+			PubKey:                msg.PublicKey[:],
+			WithdrawalCredentials: msg.WithdrawalCredentials,
+			Amount:                int(msg.Amount),
+			Signature:             sig[:],
+		}
+	}
+
+	require.NoError(t, lock.VerifyDepositData())
+
+	// Tamper with the withdrawal credentials after signing.
+	tampered := append([]byte(nil), lock.Validators[1].DepositData.WithdrawalCredentials...)
+	tampered[31] ^= 0xff
+	lock.Validators[1].DepositData.WithdrawalCredentials = tampered
+
+	err = lock.VerifyDepositData()
+	require.ErrorContains(t, err, "verify deposit data signature")
+}
+
+func TestEncryptLock(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+
+	b, err := cluster.EncryptLock(lock, "secret")
+	require.NoError(t, err)
+	require.True(t, cluster.IsEncryptedLock(b))
+	require.NotContains(t, string(b), "cluster_definition")
+
+	_, err = cluster.DecryptLock(b, "")
+	require.ErrorContains(t, err, "no password provided")
+
+	_, err = cluster.DecryptLock(b, "wrong")
+	require.ErrorContains(t, err, "decrypt cluster lock")
+
+	decrypted, err := cluster.DecryptLock(b, "secret")
+	require.NoError(t, err)
+	require.NoError(t, decrypted.VerifyHashes())
+	require.NoError(t, decrypted.VerifySignatures())
+	require.Equal(t, lock.LockHash, decrypted.LockHash)
+
+	// Plaintext locks are loaded as is.
+	plaintext, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.False(t, cluster.IsEncryptedLock(plaintext))
+
+	loaded, err := cluster.DecryptLock(plaintext, "ignored")
+	require.NoError(t, err)
+	require.NoError(t, loaded.VerifyHashes())
+}
@@ -4,6 +4,7 @@ package cluster
 
 import (
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
 )
 
 // Operator identifies the operator of a charon node and its ENR.
@@ -25,6 +26,45 @@ type Operator struct {
 
 	// ENRSignature is a EIP712 signature of the ENR by the Address, authorising the charon node to act on behalf of the operator in the cluster.
 	ENRSignature []byte `json:"enr_signature,0xhex" ssz:"Bytes65" config_hash:"-" definition_hash:"3"`
+
+	// Weight is the optional voting weight of the charon node in consensus quorums, zero defaults to 1.
+	// It is only supported from v1.6.
+	Weight int `json:"weight,omitempty" ssz:"uint64" config_hash:"1" definition_hash:"4"`
+
+	// Observer indicates the charon node is a non-voting observer that never signs. Its key shares are not used,
+	// so it doesn't count towards the consensus quorum or the signing threshold. It is only supported from v1.6.
+	Observer bool `json:"observer,omitempty" ssz:"bool" config_hash:"2" definition_hash:"5"`
+}
+
+// VotingWeight returns the voting weight of the charon node in consensus quorums, zero for observers.
+func (o Operator) VotingWeight() int {
+	if o.Observer {
+		return 0
+	} else if o.Weight == 0 {
+		return 1
+	}
+
+	return o.Weight
+}
+
+// VerifyVoting returns an error if the operators' voting configuration is invalid for the signing threshold.
+// Observers can't have weights and the non-observer operators must be able to reach the signing threshold.
+func VerifyVoting(operators []Operator, threshold int) error {
+	var signers int
+	for i, op := range operators {
+		if op.Observer && op.Weight != 0 {
+			return errors.New("observer operator with voting weight", z.Int("operator", i))
+		} else if !op.Observer {
+			signers++
+		}
+	}
+
+	if signers < threshold {
+		return errors.New("insufficient non-observer operators for signing threshold",
+			z.Int("signers", signers), z.Int("threshold", threshold))
+	}
+
+	return nil
 }
 
 // operatorJSONv1x1 is the json formatter of Operator for versions v1.0.0 and v1.1.0.
@@ -42,6 +82,8 @@ type operatorJSONv1x2orLater struct {
 	ENR             string `json:"enr"`
 	ConfigSignature ethHex `json:"config_signature"`
 	ENRSignature    ethHex `json:"enr_signature"`
+	Weight          int    `json:"weight,omitempty"`
+	Observer        bool   `json:"observer,omitempty"`
 }
 
 func operatorsFromV1x1(operators []operatorJSONv1x1) ([]Operator, error) {
@@ -85,6 +127,8 @@ func operatorsFromV1x2orLater(operators []operatorJSONv1x2orLater) []Operator {
 			ENR:             o.ENR,
 			ConfigSignature: o.ConfigSignature,
 			ENRSignature:    o.ENRSignature,
+			Weight:          o.Weight,
+			Observer:        o.Observer,
 		})
 	}
 
@@ -99,6 +143,8 @@ func operatorsToV1x2orLater(operators []Operator) []operatorJSONv1x2orLater {
 			ENR:             o.ENR,
 			ConfigSignature: o.ConfigSignature,
 			ENRSignature:    o.ENRSignature,
+			Weight:          o.Weight,
+			Observer:        o.Observer,
 		})
 	}
 
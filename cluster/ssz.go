@@ -24,6 +24,10 @@ const (
 	sszLenHash          = 32
 	sszLenWithdrawCreds = 32
 	sszLenPubKey        = 48
+	sszLenGraffiti      = 32
+	sszMaxBuilderRelays = 16
+	sszMaxBuilderRelay  = 256
+	sszMaxTSSScheme     = 32
 )
 
 // getDefinitionHashFunc returns the function to hash a definition based on the provided version.
@@ -339,6 +343,23 @@ func hashDefinitionV1x5(d Definition, hh ssz.HashWalker, configOnly bool) error
 				}
 			}
 
+			// Field (4) 'Weight' uint64, only supported from v1.6, also included in the config hash.
+			if SupportOperatorWeights(d.Version) {
+				if o.Weight < 0 {
+					return errors.New("negative operator weight", z.Int("weight", o.Weight))
+				}
+				hh.PutUint64(uint64(o.Weight))
+			} else if o.Weight != 0 {
+				return errors.New("operator weight not supported by version", z.Str("version", d.Version))
+			}
+
+			// Field (5) 'Observer' bool, only supported from v1.6, also included in the config hash.
+			if SupportObservers(d.Version) {
+				hh.PutBool(o.Observer)
+			} else if o.Observer {
+				return errors.New("observer operator not supported by version", z.Str("version", d.Version))
+			}
+
 			hh.Merkleize(operatorIdx)
 		}
 		hh.MerkleizeWithMixin(operatorsIdx, num, sszMaxOperators)
@@ -391,6 +412,15 @@ func hashDefinitionV1x5(d Definition, hh ssz.HashWalker, configOnly bool) error
 		}
 	}
 
+	// Field (11 or 12) 'TSSScheme' ByteList[32], only supported from v1.6, also included in the config hash.
+	if SupportTSSScheme(d.Version) {
+		if err := putByteList(hh, []byte(d.TSSScheme), sszMaxTSSScheme, "tss_scheme"); err != nil {
+			return err
+		}
+	} else if d.TSSScheme != "" {
+		return errors.New("tss scheme not supported by version", z.Str("version", d.Version))
+	}
+
 	hh.Merkleize(indx)
 
 	return nil
@@ -455,7 +485,7 @@ func hashLockV1x3or4(l Lock, hh ssz.HashWalker) error {
 	return nil
 }
 
-// hashLockV1x5 hashes the version v1.5 of the lock.
+// hashLockV1x5 hashes the version v1.5 or later of the lock.
 func hashLockV1x5(l Lock, hh ssz.HashWalker) error {
 	indx := hh.Index()
 
@@ -543,6 +573,34 @@ func hashValidatorV1x5(v DistValidator, hh ssz.HashWalker, version string) error
 		return err
 	}
 
+	// Field (3) 'Graffiti' Bytes32, only supported from v1.6.
+	if SupportGraffiti(version) {
+		if len(v.Graffiti) > sszLenGraffiti {
+			return errors.New("graffiti too long", z.Int("max", sszLenGraffiti), z.Int("length", len(v.Graffiti)))
+		}
+
+		var graffiti [sszLenGraffiti]byte
+		copy(graffiti[:], v.Graffiti) // Graffiti is right padded, as in beacon blocks.
+		hh.PutBytes(graffiti[:])
+	}
+
+	// Field (4) 'BuilderRelays' CompositeList[16], only supported from v1.6.
+	if SupportBuilderRelays(version) {
+		if len(v.BuilderRelays) > sszMaxBuilderRelays {
+			return errors.New("too many builder relays", z.Int("max", sszMaxBuilderRelays), z.Int("length", len(v.BuilderRelays)))
+		}
+
+		subIndx := hh.Index()
+		num := uint64(len(v.BuilderRelays))
+		for _, relay := range v.BuilderRelays {
+			// ByteList[256]
+			if err := putByteList(hh, []byte(relay), sszMaxBuilderRelay, "builder_relay"); err != nil {
+				return err
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, sszMaxBuilderRelays)
+	}
+
 	hh.Merkleize(indx)
 
 	return nil
@@ -43,6 +43,31 @@ func isV1x3(version string) bool {
 	return version == v1_3
 }
 
+// SupportedVersion returns true if the definition and lock version is supported.
+func SupportedVersion(version string) bool {
+	return supportedVersions[version]
+}
+
+// SupportGraffiti returns true if the lock version supports custom validator graffiti.
+func SupportGraffiti(version string) bool {
+	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5)
+}
+
+// SupportBuilderRelays returns true if the lock version supports validator builder relays.
+func SupportBuilderRelays(version string) bool {
+	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5)
+}
+
+// SupportTSSScheme returns true if the lock version supports recording the threshold secret sharing scheme.
+func SupportTSSScheme(version string) bool {
+	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5)
+}
+
+// SupportOperatorWeights returns true if the definition version supports operator voting weights.
+func SupportOperatorWeights(version string) bool {
+	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5)
+}
+
 // SupportedVersionsForT returns the supported definition versions for testing purposes only.
 func SupportedVersionsForT(*testing.T) []string {
 	var resp []string
@@ -52,3 +77,8 @@ func SupportedVersionsForT(*testing.T) []string {
 
 	return resp
 }
+
+// SupportObservers returns true if the definition version supports non-voting observer operators.
+func SupportObservers(version string) bool {
+	return !isAnyVersion(version, v1_0, v1_1, v1_2, v1_3, v1_4, v1_5)
+}
@@ -0,0 +1,19 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func newAddCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "add",
+		Short: "Add to an existing charon cluster",
+		Long:  "Add distributed validators to an existing locally created charon cluster.",
+	}
+
+	root.AddCommand(cmds...)
+
+	titledHelp(root)
+
+	return root
+}
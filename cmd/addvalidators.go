@@ -0,0 +1,440 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+type addValidatorsConfig struct {
+	ClusterDir          string
+	NumDVs              int
+	FeeRecipientAddrs   []string
+	WithdrawalAddrs     []string
+	WithdrawalCredTypes []string
+	Graffiti            []string
+	BuilderRelays       []string
+	LockPasswordFile    string
+	InsecureKeys        bool
+}
+
+func newAddValidatorsCmd(runFunc func(context.Context, io.Writer, addValidatorsConfig) error) *cobra.Command {
+	var conf addValidatorsConfig
+
+	cmd := &cobra.Command{
+		Use:   "validators",
+		Short: "Add distributed validators to an existing cluster",
+		Long: "Generates new distributed validator keys for an existing locally created cluster, splits them across the existing operators " +
+			"and appends them to the cluster-lock.json, deposit-data.json and validator keystores of each node. Existing validators are left untouched.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindAddValidatorsFlags(cmd.Flags(), &conf)
+	bindInsecureFlags(cmd.Flags(), &conf.InsecureKeys)
+	bindLockPasswordFlag(cmd.Flags(), &conf.LockPasswordFile)
+
+	return cmd
+}
+
+func bindAddValidatorsFlags(flags *pflag.FlagSet, config *addValidatorsConfig) {
+	flags.StringVar(&config.ClusterDir, "cluster-dir", ".charon/cluster", "The existing cluster folder containing the node directories.")
+	flags.IntVar(&config.NumDVs, "num-validators", 1, "The number of distributed validators to add to the cluster.")
+	flags.StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each new validator. Either provide a single fee recipient address or fee recipient addresses for each new validator.")
+	flags.StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each new validator. Either provide a single withdrawal address or withdrawal addresses for each new validator.")
+	flags.StringSliceVar(&config.WithdrawalCredTypes, "withdrawal-credential-types", nil, "Comma separated list of withdrawal credential types for each new validator. Options: 0x01 (execution), 0x02 (compounding). Either provide a single type or types for each new validator. Defaults to 0x01.")
+	flags.StringSliceVar(&config.Graffiti, "graffiti", nil, "Optional comma separated list of custom graffiti (max 32 bytes) included in blocks proposed by each new validator. Either provide a single graffiti or graffiti for each new validator. Requires cluster lock version v1.6.0 or later.")
+	flags.StringSliceVar(&config.BuilderRelays, "builder-relays", nil, "Optional comma separated list of builder relay URLs preferred by all new validators for builder API block proposals. Validator builder registrations are also submitted to these relays. Requires cluster lock version v1.6.0 or later.")
+}
+
+// runAddValidators appends new distributed validators to the existing cluster in the configured cluster directory.
+// All files are generated and verified before being written to temporary files that are only renamed
+// into place once all of them were written, so a failure leaves the existing cluster untouched.
+func runAddValidators(ctx context.Context, w io.Writer, conf addValidatorsConfig) error {
+	if conf.NumDVs <= 0 {
+		return errors.New("number of validators to add must be positive", z.Int("num_validators", conf.NumDVs))
+	}
+
+	lockPassword, err := cluster.LoadLockPassword(conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	lock, numNodes, err := loadClusterDirLock(conf.ClusterDir, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	if len(lock.Creator.ConfigSignature) > 0 {
+		return errors.New("cannot add validators to a cluster with a signed definition")
+	}
+	for _, op := range lock.Operators {
+		if len(op.ConfigSignature) > 0 || len(op.ENRSignature) > 0 {
+			return errors.New("cannot add validators to a cluster with a signed definition")
+		}
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		return err
+	}
+
+	if conf.InsecureKeys && isMainNetwork(network) {
+		return errors.New("insecure keys not supported on mainnet")
+	}
+
+	feeRecipientAddrs, withdrawalAddrs, err := validateAddresses(conf.NumDVs, conf.FeeRecipientAddrs, conf.WithdrawalAddrs)
+	if err != nil {
+		return err
+	}
+
+	graffiti, err := validatorGraffiti(conf.Graffiti, conf.NumDVs)
+	if err != nil {
+		return err
+	} else if len(graffiti) > 0 && !cluster.SupportGraffiti(lock.Version) {
+		return errors.New("graffiti not supported by cluster lock version", z.Str("version", lock.Version))
+	}
+
+	if err := validateBuilderRelays(conf.BuilderRelays); err != nil {
+		return err
+	} else if len(conf.BuilderRelays) > 0 && !cluster.SupportBuilderRelays(lock.Version) {
+		return errors.New("builder relays not supported by cluster lock version", z.Str("version", lock.Version))
+	}
+
+	prefixes, err := withdrawalPrefixes(conf.WithdrawalCredTypes, conf.NumDVs)
+	if err != nil {
+		return err
+	}
+
+	shareSets, err := loadShareSets(lock, conf.ClusterDir, numNodes)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := getKeys(false, "", conf.NumDVs)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := tblsv2.GetTSSScheme(lock.Definition.TSSScheme)
+	if err != nil {
+		return err
+	}
+
+	pubkeys, newShareSets, err := getTSSShares(scheme, secrets, lock.Threshold, numNodes)
+	if err != nil {
+		return err
+	}
+
+	depositDatas, err := createDepositDatas(withdrawalAddrs, prefixes, network, secrets)
+	if err != nil {
+		return err
+	}
+
+	vals, err := getValidators(pubkeys, newShareSets, depositDatas)
+	if err != nil {
+		return err
+	}
+
+	for i, g := range graffiti {
+		vals[i].Graffiti = g
+	}
+
+	for i := range vals {
+		vals[i].BuilderRelays = conf.BuilderRelays
+	}
+
+	def := lock.Definition
+	def.NumValidators += conf.NumDVs
+	def.ValidatorAddresses = append([]cluster.ValidatorAddresses(nil), def.ValidatorAddresses...)
+	for i := 0; i < conf.NumDVs; i++ {
+		def.ValidatorAddresses = append(def.ValidatorAddresses, cluster.ValidatorAddresses{
+			FeeRecipientAddress: feeRecipientAddrs[i],
+			WithdrawalAddress:   withdrawalAddrs[i],
+		})
+	}
+
+	def, err = def.SetDefinitionHashes()
+	if err != nil {
+		return err
+	}
+
+	newLock := cluster.Lock{
+		Definition: def,
+		Validators: append(append([]cluster.DistValidator(nil), lock.Validators...), vals...),
+	}
+	newLock, err = newLock.SetLockHash()
+	if err != nil {
+		return err
+	}
+
+	if err = newLock.VerifyDepositData(); err != nil {
+		return err
+	}
+
+	lockJSON, err := marshalSignedLock(newLock, append(shareSets, newShareSets...), lockPassword)
+	if err != nil {
+		return err
+	}
+
+	var depositJSONs [][]byte
+	for i := 0; i < numNodes; i++ {
+		b, err := appendDepositData(depositDatas, network, conf.ClusterDir, i)
+		if err != nil {
+			return err
+		}
+		depositJSONs = append(depositJSONs, b)
+	}
+
+	var staged stagedFiles
+	defer staged.Cleanup()
+
+	if err = stageKeys(&staged, numNodes, conf.ClusterDir, conf.InsecureKeys, newShareSets); err != nil {
+		return err
+	}
+
+	for i := 0; i < numNodes; i++ {
+		if err := staged.Write(path.Join(nodeDir(conf.ClusterDir, i), "deposit-data.json"), depositJSONs[i], 0o400); err != nil {
+			return err
+		}
+
+		if err := staged.Write(path.Join(nodeDir(conf.ClusterDir, i), "cluster-lock.json"), lockJSON, 0o400); err != nil {
+			return err
+		}
+	}
+
+	if err = staged.Commit(); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Added validators to cluster", z.Int("added", conf.NumDVs), z.Int("total", len(newLock.Validators)))
+
+	_, _ = fmt.Fprintf(w, "Added %d validators to charon cluster %s, restart all nodes to start validating.\n",
+		conf.NumDVs, conf.ClusterDir)
+
+	return nil
+}
+
+// loadClusterDirLock returns the cluster lock shared by all node directories in the cluster directory
+// and the number of nodes.
+func loadClusterDirLock(clusterDir string, passwordFile string) (cluster.Lock, int, error) {
+	lock, err := readLockFile(path.Join(nodeDir(clusterDir, 0), "cluster-lock.json"), passwordFile)
+	if err != nil {
+		return cluster.Lock{}, 0, err
+	}
+
+	if err := verifyAggSign(lock); err != nil {
+		return cluster.Lock{}, 0, err
+	}
+
+	numNodes := len(lock.Operators)
+	for i := 1; i < numNodes; i++ {
+		other, err := readLockFile(path.Join(nodeDir(clusterDir, i), "cluster-lock.json"), passwordFile)
+		if err != nil {
+			return cluster.Lock{}, 0, err
+		}
+
+		if !bytes.Equal(other.LockHash, lock.LockHash) {
+			return cluster.Lock{}, 0, errors.New("mismatching cluster lock files", z.Int("node", i))
+		}
+	}
+
+	return lock, numNodes, nil
+}
+
+// loadShareSets returns the existing private key shares of each validator of the lock, ordered by node.
+func loadShareSets(lock cluster.Lock, clusterDir string, numNodes int) ([][]tblsv2.PrivateKey, error) {
+	shareSets := make([][]tblsv2.PrivateKey, len(lock.Validators))
+	for i := 0; i < numNodes; i++ {
+		secrets, err := keystore.LoadKeys(path.Join(nodeDir(clusterDir, i), "validator_keys"))
+		if err != nil {
+			return nil, errors.Wrap(err, "load node keys", z.Int("node", i))
+		}
+
+		pubshares := make(map[tblsv2.PublicKey]tblsv2.PrivateKey)
+		for _, secret := range secrets {
+			pubshare, err := tblsv2.SecretToPublicKey(secret)
+			if err != nil {
+				return nil, err
+			}
+			pubshares[pubshare] = secret
+		}
+
+		for v, val := range lock.Validators {
+			secret, ok := pubshares[tblsv2.PublicKey(val.PubShares[i])]
+			if !ok {
+				return nil, errors.New("validator key share not found", z.Int("node", i), z.Str("pubkey", val.PublicKeyHex()))
+			}
+
+			shareSets[v] = append(shareSets[v], secret)
+		}
+	}
+
+	return shareSets, nil
+}
+
+// stagedFiles are temporary files that are renamed to their target files on commit.
+type stagedFiles struct {
+	files map[string]string // map[tmpFile]targetFile
+	dirs  []string
+}
+
+// Write writes the data to a temporary file renamed to the target file on commit.
+func (s *stagedFiles) Write(target string, data []byte, perm os.FileMode) error {
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return errors.Wrap(err, "write temporary file", z.Str("file", tmp))
+	}
+
+	s.add(tmp, target)
+
+	return nil
+}
+
+// Dir returns a new temporary directory whose files are moved to the target directory on commit.
+func (s *stagedFiles) Dir(target string) (string, error) {
+	tmp := target + ".tmp"
+	if err := os.Mkdir(tmp, 0o755); err != nil {
+		return "", errors.Wrap(err, "create temporary directory", z.Str("dir", tmp))
+	}
+
+	s.dirs = append(s.dirs, tmp)
+
+	return tmp, nil
+}
+
+func (s *stagedFiles) add(tmp, target string) {
+	if s.files == nil {
+		s.files = make(map[string]string)
+	}
+	s.files[tmp] = target
+}
+
+// Commit renames all temporary files to their target files.
+func (s *stagedFiles) Commit() error {
+	for _, dir := range s.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return errors.Wrap(err, "read temporary directory")
+		}
+
+		for _, e := range entries {
+			s.add(path.Join(dir, e.Name()), path.Join(strings.TrimSuffix(dir, ".tmp"), e.Name()))
+		}
+	}
+
+	for tmp, target := range s.files {
+		if err := os.Rename(tmp, target); err != nil {
+			return errors.Wrap(err, "rename temporary file", z.Str("file", target))
+		}
+	}
+
+	return nil
+}
+
+// Cleanup removes all remaining temporary files and directories.
+func (s *stagedFiles) Cleanup() {
+	for tmp := range s.files {
+		_ = os.Remove(tmp)
+	}
+	for _, dir := range s.dirs {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// stageKeys stages validator keyshares to be appended to the existing keystores of each node.
+func stageKeys(staged *stagedFiles, numNodes int, clusterDir string, insecureKeys bool, shareSets [][]tblsv2.PrivateKey) error {
+	for i := 0; i < numNodes; i++ {
+		var secrets []tblsv2.PrivateKey
+		for _, shares := range shareSets {
+			secrets = append(secrets, shares[i])
+		}
+
+		keysDir := path.Join(nodeDir(clusterDir, i), "validator_keys")
+
+		stageDir, err := staged.Dir(keysDir)
+		if err != nil {
+			return err
+		}
+
+		if insecureKeys {
+			if err := keystore.AppendKeysInsecure(secrets, keysDir, stageDir, keystore.ConfirmInsecureKeys); err != nil {
+				return err
+			}
+		} else {
+			if err := keystore.AppendKeys(secrets, keysDir, stageDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// appendDepositData returns the existing deposit data file of the node with the deposit datas appended,
+// retaining the existing entries and deposit contract.
+func appendDepositData(depositDatas []eth2p0.DepositData, network string, clusterDir string, node int) ([]byte, error) {
+	existing, err := os.ReadFile(path.Join(nodeDir(clusterDir, node), "deposit-data.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read deposit data")
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(existing, &entries); err != nil {
+		return nil, errors.Wrap(err, "unmarshal deposit data")
+	} else if len(entries) == 0 {
+		return nil, errors.New("empty deposit data", z.Int("node", node))
+	}
+
+	var first struct {
+		DepositContract string `json:"deposit_contract_address"`
+		ChainID         int64  `json:"chain_id"`
+	}
+	if err := json.Unmarshal(entries[0], &first); err != nil {
+		return nil, errors.Wrap(err, "unmarshal deposit data")
+	}
+
+	var opts []deposit.MarshalOption
+	if first.DepositContract != "" {
+		opts = append(opts, deposit.WithDepositContract(first.DepositContract, first.ChainID))
+	}
+
+	b, err := deposit.MarshalDepositData(depositDatas, network, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var added []json.RawMessage
+	if err := json.Unmarshal(b, &added); err != nil {
+		return nil, errors.Wrap(err, "unmarshal deposit data")
+	}
+
+	b, err = json.MarshalIndent(append(entries, added...), "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal deposit data")
+	}
+
+	return b, nil
+}
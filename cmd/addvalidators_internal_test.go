@@ -0,0 +1,154 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+func TestAddValidators(t *testing.T) {
+	clusterDir := t.TempDir()
+
+	err := runCreateCluster(context.Background(), io.Discard, clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        clusterDir,
+		NumNodes:          minNodes,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		Graffiti:          []string{"existing"}, // Graffiti requires the v1.6.0 draft lock version.
+	})
+	require.NoError(t, err)
+
+	before, err := readLockFile(path.Join(nodeDir(clusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+
+	keystoreBefore, err := os.ReadFile(path.Join(nodeDir(clusterDir, 0), "validator_keys", "keystore-insecure-0.json"))
+	require.NoError(t, err)
+
+	conf := addValidatorsConfig{
+		ClusterDir:          clusterDir,
+		NumDVs:              2,
+		WithdrawalAddrs:     []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs:   []string{defaultWithdrawalAddr},
+		WithdrawalCredTypes: []string{"0x02"},
+		Graffiti:            []string{"added"},
+		BuilderRelays:       []string{"https://relay.example.com"},
+		InsecureKeys:        true,
+	}
+	err = runAddValidators(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	for i := 0; i < minNodes; i++ {
+		dir := nodeDir(clusterDir, i)
+
+		lock, err := readLockFile(path.Join(dir, "cluster-lock.json"), "")
+		require.NoError(t, err)
+		require.Len(t, lock.Validators, 3)
+		require.Equal(t, 3, lock.NumValidators)
+		require.Equal(t, before.Validators[0], lock.Validators[0])
+		require.NotEqual(t, before.LockHash, lock.LockHash)
+		require.NoError(t, lock.VerifyHashes())
+		require.NoError(t, lock.VerifyDepositData())
+		require.NoError(t, verifyAggSign(lock))
+
+		for _, val := range lock.Validators[1:] {
+			require.Equal(t, "added", val.Graffiti)
+			require.Equal(t, conf.BuilderRelays, val.BuilderRelays)
+			require.EqualValues(t, deposit.CompoundingWithdrawalPrefix, val.DepositData.WithdrawalCredentials[0])
+		}
+
+		tmpFiles, err := filepath.Glob(path.Join(dir, "*.tmp"))
+		require.NoError(t, err)
+		require.Empty(t, tmpFiles)
+
+		secrets, err := keystore.LoadKeys(path.Join(dir, "validator_keys"))
+		require.NoError(t, err)
+		require.Len(t, secrets, 3)
+
+		b, err := os.ReadFile(path.Join(dir, "deposit-data.json"))
+		require.NoError(t, err)
+
+		var depositDatas []map[string]any
+		require.NoError(t, json.Unmarshal(b, &depositDatas))
+		require.Len(t, depositDatas, 3)
+		require.Equal(t, before.Validators[0].PublicKeyHex(), "0x"+depositDatas[0]["pubkey"].(string))
+	}
+
+	keystoreAfter, err := os.ReadFile(path.Join(nodeDir(clusterDir, 0), "validator_keys", "keystore-insecure-0.json"))
+	require.NoError(t, err)
+	require.Equal(t, keystoreBefore, keystoreAfter)
+
+	files, err := filepath.Glob(path.Join(nodeDir(clusterDir, 0), "validator_keys", "keystore-insecure-2.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	t.Run("mismatching locks", func(t *testing.T) {
+		lockPath := path.Join(nodeDir(clusterDir, 1), "cluster-lock.json")
+		require.NoError(t, os.Remove(lockPath))
+		writeLockFile(t, lockPath, before)
+
+		err := runAddValidators(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "mismatching cluster lock files")
+	})
+}
+
+func TestAddValidatorsEncryptedLock(t *testing.T) {
+	clusterDir := t.TempDir()
+
+	passwordFile := path.Join(t.TempDir(), "lock-password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret"), 0o600))
+
+	err := runCreateCluster(context.Background(), io.Discard, clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        clusterDir,
+		NumNodes:          minNodes,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		LockPasswordFile:  passwordFile,
+	})
+	require.NoError(t, err)
+
+	conf := addValidatorsConfig{
+		ClusterDir:        clusterDir,
+		NumDVs:            1,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+	}
+
+	err = runAddValidators(context.Background(), io.Discard, conf)
+	require.ErrorContains(t, err, "cluster lock is encrypted, but no password provided")
+
+	conf.LockPasswordFile = passwordFile
+	err = runAddValidators(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	for i := 0; i < minNodes; i++ {
+		b, err := os.ReadFile(path.Join(nodeDir(clusterDir, i), "cluster-lock.json"))
+		require.NoError(t, err)
+		require.True(t, cluster.IsEncryptedLock(b))
+
+		lock, err := readLockFile(path.Join(nodeDir(clusterDir, i), "cluster-lock.json"), passwordFile)
+		require.NoError(t, err)
+		require.Len(t, lock.Validators, 2)
+		require.NoError(t, lock.VerifyHashes())
+	}
+}
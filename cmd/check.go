@@ -0,0 +1,19 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func newCheckCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "check",
+		Short: "Check charon artifacts for hazards",
+		Long:  "Check charon artifacts for operational hazards before running the distributed validators.",
+	}
+
+	root.AddCommand(cmds...)
+
+	titledHelp(root)
+
+	return root
+}
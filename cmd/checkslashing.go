@@ -0,0 +1,137 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+type checkSlashingConfig struct {
+	ClusterDirs      []string
+	LockPasswordFile string
+}
+
+func newCheckSlashingCmd(runFunc func(context.Context, io.Writer, checkSlashingConfig) error) *cobra.Command {
+	var conf checkSlashingConfig
+
+	cmd := &cobra.Command{
+		Use:   "slashing-risk",
+		Short: "Check that clusters do not share validator keys",
+		Long:  "Compares the distributed validator public keys of the cluster locks in the provided cluster directories and reports any validator present in more than one cluster, since running it in multiple clusters results in double signing.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindCheckSlashingFlags(cmd, &conf)
+
+	return cmd
+}
+
+func bindCheckSlashingFlags(cmd *cobra.Command, config *checkSlashingConfig) {
+	cmd.Flags().StringSliceVar(&config.ClusterDirs, "cluster-dir", nil, "Comma separated list of cluster directories to compare. Each directory contains either a cluster-lock.json file or the node directories of a created cluster.")
+	bindLockPasswordFlag(cmd.Flags(), &config.LockPasswordFile)
+	mustMarkFlagRequired(cmd, "cluster-dir")
+}
+
+// runCheckSlashing reports validators shared by the cluster locks of the configured cluster directories.
+// It returns an error if any validator is shared.
+func runCheckSlashing(_ context.Context, w io.Writer, conf checkSlashingConfig) error {
+	if len(conf.ClusterDirs) < 2 {
+		return errors.New("at least two cluster directories required", z.Int("count", len(conf.ClusterDirs)))
+	}
+
+	clusters := make(map[string][]string) // map[cluster dir][]validator pubkeys
+	for _, dir := range conf.ClusterDirs {
+		lockFile, err := clusterLockFile(dir)
+		if err != nil {
+			return err
+		}
+
+		lock, err := readLockFile(lockFile, conf.LockPasswordFile)
+		if err != nil {
+			return errors.Wrap(err, "read cluster lock", z.Str("cluster_dir", dir))
+		}
+
+		for _, val := range lock.Validators {
+			clusters[dir] = append(clusters[dir], val.PublicKeyHex())
+		}
+	}
+
+	shared := sharedValidators(clusters)
+	if len(shared) == 0 {
+		_, _ = fmt.Fprintln(w, "No validators shared between clusters")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Found %d validators shared between clusters:\n", len(shared))
+	for _, val := range shared {
+		_, _ = fmt.Fprintf(w, "%s\n", val.PubKey)
+		for _, dir := range val.ClusterDirs {
+			_, _ = fmt.Fprintf(w, "  %s\n", dir)
+		}
+	}
+
+	return errors.New("slashing risk: validators shared between clusters", z.Int("count", len(shared)))
+}
+
+// clusterLockFile returns the path of the cluster lock file in the cluster directory.
+// It supports both a node's charon directory and the output directory of a created cluster.
+func clusterLockFile(dir string) (string, error) {
+	for _, file := range []string{
+		path.Join(dir, "cluster-lock.json"),
+		path.Join(nodeDir(dir, 0), "cluster-lock.json"),
+	} {
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
+		}
+	}
+
+	return "", errors.New("cluster lock not found", z.Str("cluster_dir", dir))
+}
+
+// sharedValidator is a validator public key present in multiple clusters.
+type sharedValidator struct {
+	PubKey      string
+	ClusterDirs []string
+}
+
+// sharedValidators returns the validator public keys present in more than one cluster, sorted by public key.
+func sharedValidators(clusters map[string][]string) []sharedValidator {
+	dirsByPubkey := make(map[string][]string)
+	for dir, pubkeys := range clusters {
+		for _, pubkey := range pubkeys {
+			if dirs := dirsByPubkey[pubkey]; len(dirs) > 0 && dirs[len(dirs)-1] == dir {
+				continue // Ignore duplicates within a cluster.
+			}
+			dirsByPubkey[pubkey] = append(dirsByPubkey[pubkey], dir)
+		}
+	}
+
+	var resp []sharedValidator
+	for pubkey, dirs := range dirsByPubkey {
+		if len(dirs) < 2 {
+			continue
+		}
+
+		sort.Strings(dirs)
+		resp = append(resp, sharedValidator{PubKey: pubkey, ClusterDirs: dirs})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].PubKey < resp[j].PubKey
+	})
+
+	return resp
+}
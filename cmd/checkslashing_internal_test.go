@@ -0,0 +1,72 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestCheckSlashing(t *testing.T) {
+	lockA, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+	lockB, _, _ := cluster.NewForT(t, 2, 3, 4, 1)
+
+	dir := t.TempDir()
+	dirA, dirB := path.Join(dir, "a"), path.Join(dir, "b")
+	require.NoError(t, os.MkdirAll(dirA, 0o755))
+	require.NoError(t, os.MkdirAll(nodeDir(dirB, 0), 0o755)) // Created cluster layout.
+
+	writeLockFile(t, path.Join(dirA, "cluster-lock.json"), lockA)
+	writeLockFile(t, path.Join(nodeDir(dirB, 0), "cluster-lock.json"), lockB)
+
+	conf := checkSlashingConfig{ClusterDirs: []string{dirA, dirB}}
+
+	var buf bytes.Buffer
+	err := runCheckSlashing(context.Background(), &buf, conf)
+	require.NoError(t, err)
+	require.Equal(t, "No validators shared between clusters\n", buf.String())
+
+	// Import the second validator of A into B.
+	lockB.Validators = append([]cluster.DistValidator(nil), lockB.Validators...)
+	lockB.Validators[0] = lockA.Validators[1]
+	writeLockFile(t, path.Join(nodeDir(dirB, 0), "cluster-lock.json"), lockB)
+
+	buf.Reset()
+	err = runCheckSlashing(context.Background(), &buf, conf)
+	require.ErrorContains(t, err, "slashing risk")
+	require.Contains(t, buf.String(), "Found 1 validators shared between clusters")
+	require.Contains(t, buf.String(), lockA.Validators[1].PublicKeyHex())
+	require.NotContains(t, buf.String(), lockA.Validators[0].PublicKeyHex())
+	require.Contains(t, buf.String(), dirA)
+	require.Contains(t, buf.String(), dirB)
+
+	t.Run("single cluster", func(t *testing.T) {
+		err := runCheckSlashing(context.Background(), &buf, checkSlashingConfig{ClusterDirs: []string{dirA}})
+		require.ErrorContains(t, err, "at least two cluster directories required")
+	})
+
+	t.Run("missing lock", func(t *testing.T) {
+		err := runCheckSlashing(context.Background(), &buf, checkSlashingConfig{ClusterDirs: []string{dirA, dir}})
+		require.ErrorContains(t, err, "cluster lock not found")
+	})
+}
+
+func TestSharedValidators(t *testing.T) {
+	shared := sharedValidators(map[string][]string{
+		"a": {"0x01", "0x02", "0x02"},
+		"b": {"0x03", "0x01"},
+		"c": {"0x01", "0x03"},
+	})
+
+	require.Equal(t, []sharedValidator{
+		{PubKey: "0x01", ClusterDirs: []string{"a", "b", "c"}},
+		{PubKey: "0x03", ClusterDirs: []string{"b", "c"}},
+	}, shared)
+}
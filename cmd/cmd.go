@@ -34,7 +34,11 @@ const (
 func New() *cobra.Command {
 	return newRootCmd(
 		newVersionCmd(runVersionCmd),
-		newEnrCmd(runNewENR),
+		newEnrCmd(runNewENR,
+			newEnrEncryptCmd(runEnrEncrypt),
+			newEnrDecryptCmd(runEnrDecrypt),
+			newEnrVerifyCmd(runEnrVerify),
+		),
 		newRunCmd(app.Run),
 		newRelayCmd(relay.Run),
 		newDKGCmd(dkg.Run),
@@ -43,7 +47,43 @@ func New() *cobra.Command {
 			newCreateEnrCmd(runCreateEnrCmd),
 			newCreateClusterCmd(runCreateCluster),
 		),
+		newAddCmd(
+			newAddValidatorsCmd(runAddValidators),
+		),
 		newCombineCmd(newCombineFunc),
+		newTestCmd(
+			newTestPerformanceCmd(runTestPerformance),
+			newTestKeymanagerCmd(runTestKeymanager),
+			newTestP2PCmd(runTestP2P),
+		),
+		newDiffCmd(
+			newDiffLockCmd(runDiffLock),
+		),
+		newDescribeCmd(
+			newDescribeClusterCmd(runDescribeCluster),
+		),
+		newCheckCmd(
+			newCheckSlashingCmd(runCheckSlashing),
+		),
+		newShowCmd(
+			newShowPubkeysCmd(runShowPubkeys),
+		),
+		newExportCmd(
+			newExportENRsCmd(runExportENRs),
+		),
+		newVerifyCmd(
+			newVerifyLockSigCmd(runVerifyLockSig),
+			newVerifyDepositCmd(runVerifyDeposit),
+		),
+		newRegenerateCmd(
+			newRegenerateDepositCmd(runRegenerateDeposit),
+		),
+		newConvertCmd(
+			newConvertLockCmd(runConvertLock),
+		),
+		newSubmitCmd(
+			newSubmitDepositsCmd(runSubmitDeposits),
+		),
 	)
 }
 
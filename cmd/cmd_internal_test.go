@@ -19,6 +19,7 @@ import (
 	"github.com/obolnetwork/charon/app"
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/core/consensus"
 	"github.com/obolnetwork/charon/p2p"
 )
 
@@ -73,15 +74,19 @@ func TestCmdFlags(t *testing.T) {
 					Enabled:   nil,
 					Disabled:  nil,
 				},
-				LockFile:               ".charon/cluster-lock.json",
-				PrivKeyFile:            ".charon/charon-enr-private-key",
-				SimnetValidatorKeysDir: ".charon/validator_keys",
-				SimnetSlotDuration:     time.Second,
-				MonitoringAddr:         "127.0.0.1:3620",
-				ValidatorAPIAddr:       "127.0.0.1:3600",
-				BeaconNodeAddrs:        []string{"http://beacon.node"},
-				JaegerAddr:             "",
-				JaegerService:          "charon",
+				LockFile:                ".charon/cluster-lock.json",
+				PrivKeyFile:             ".charon/charon-enr-private-key",
+				SimnetValidatorKeysDir:  ".charon/validator_keys",
+				SimnetSlotDuration:      time.Second,
+				MonitoringAddr:          "127.0.0.1:3620",
+				ConsensusRoundTimeout:   consensus.DefaultRoundTimeout(),
+				ConsensusBroadcastLimit: consensus.DefaultBroadcastLimit,
+				ConsensusBroadcastBurst: consensus.DefaultBroadcastBurst,
+				ConsensusSilentPeers:    consensus.DefaultSilentPeerInstances,
+				ValidatorAPIAddr:        "127.0.0.1:3600",
+				BeaconNodeAddrs:         []string{"http://beacon.node"},
+				JaegerAddr:              "",
+				JaegerService:           "charon",
 			},
 		},
 		{
@@ -116,8 +121,8 @@ func TestCmdFlags(t *testing.T) {
 					return nil
 				}),
 				newCreateCmd(
-					newCreateEnrCmd(func(_ io.Writer, datadir string) error {
-						require.Equal(t, test.Datadir, datadir)
+					newCreateEnrCmd(func(_ io.Writer, config createEnrConfig) error {
+						require.Equal(t, test.Datadir, config.DataDir)
 
 						return nil
 					}),
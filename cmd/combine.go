@@ -8,13 +8,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/combine"
 )
 
-func newCombineCmd(runFunc func(ctx context.Context, clusterDir string, force bool) error) *cobra.Command {
+func newCombineCmd(runFunc func(ctx context.Context, clusterDir string, force bool, lockPasswordFile string) error) *cobra.Command {
 	var (
-		clusterDir string
-		force      bool
+		clusterDir       string
+		force            bool
+		lockPasswordFile string
 	)
 
 	cmd := &cobra.Command{
@@ -23,7 +25,7 @@ func newCombineCmd(runFunc func(ctx context.Context, clusterDir string, force bo
 		Long:  "Combines the private key shares from a threshold of operators in a distributed validator cluster into a set of validator private keys that can be imported into a standard Ethereum validator client.\n\nWarning: running the resulting private keys in a validator alongside the original distributed validator cluster *will* result in slashing.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFunc(cmd.Context(), clusterDir, force)
+			return runFunc(cmd.Context(), clusterDir, force, lockPasswordFile)
 		},
 	}
 
@@ -32,12 +34,18 @@ func newCombineCmd(runFunc func(ctx context.Context, clusterDir string, force bo
 		&clusterDir,
 		&force,
 	)
+	bindLockPasswordFlag(cmd.Flags(), &lockPasswordFile)
 
 	return cmd
 }
 
-func newCombineFunc(ctx context.Context, clusterDir string, force bool) error {
-	return combine.Combine(ctx, clusterDir, force)
+func newCombineFunc(ctx context.Context, clusterDir string, force bool, lockPasswordFile string) error {
+	password, err := cluster.LoadLockPassword(lockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	return combine.Combine(ctx, clusterDir, force, combine.WithLockPassword(password))
 }
 
 func bindCombineFlags(flags *pflag.FlagSet, clusterDir *string, force *bool) {
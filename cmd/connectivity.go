@@ -0,0 +1,135 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// connectivityDialTimeout is the timeout of each operator connectivity check dial.
+const connectivityDialTimeout = 5 * time.Second
+
+// peerDialer dials the peer's advertised addresses, returning an error if it is unreachable.
+type peerDialer func(ctx context.Context, p p2p.Peer) error
+
+func bindCheckConnectivityFlag(flags *pflag.FlagSet, checkConnectivity *bool) {
+	flags.BoolVar(checkConnectivity, "check-connectivity", false, "Dials the advertised address of each operator ENR and warns about unreachable operators. This is advisory only, the charon node of each operator must be running for it to be reachable.")
+}
+
+// newLibP2PDialer returns a peer dialer using a new ephemeral libp2p host and a function to close the host.
+func newLibP2PDialer() (peerDialer, func(), error) {
+	key, err := k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "generate key")
+	}
+
+	tcpNode, err := libp2p.New(
+		libp2p.Identity((*crypto.Secp256k1PrivateKey)(key)),
+		libp2p.NoListenAddrs,
+		libp2p.UserAgent("obolnetwork-charon/"+version.Version),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "new libp2p node")
+	}
+
+	dial := func(ctx context.Context, p p2p.Peer) error {
+		if len(p.Addrs) == 0 {
+			return errors.New("no advertised addresses in enr")
+		}
+
+		if err := tcpNode.Connect(ctx, p.AddrInfo()); err != nil {
+			return errors.Wrap(err, "dial peer")
+		}
+
+		return nil
+	}
+
+	return dial, func() { _ = tcpNode.Close() }, nil
+}
+
+// checkConnectivity dials the operators of the definition using a new libp2p dialer, see checkOperatorConnectivity.
+func checkConnectivity(ctx context.Context, def cluster.Definition) error {
+	dial, closeFunc, err := newLibP2PDialer()
+	if err != nil {
+		return err
+	}
+	defer closeFunc()
+
+	_, err = checkOperatorConnectivity(ctx, def, dial)
+
+	return err
+}
+
+// checkOperatorConnectivity dials each operator's advertised ENR address and logs a warning for each unreachable operator.
+// Operators without an advertised address are skipped. It is advisory only and returns the indexes of the unreachable operators.
+func checkOperatorConnectivity(ctx context.Context, def cluster.Definition, dial peerDialer) ([]int, error) {
+	for _, op := range def.Operators {
+		if op.ENR == "" {
+			log.Info(ctx, "Skipping connectivity check since the definition doesn't contain operator ENRs")
+			return nil, nil
+		}
+	}
+
+	peers, err := def.Peers()
+	if err != nil {
+		return nil, err
+	}
+
+	// Peers are created without addresses, so populate them from the ENRs.
+	for i, op := range def.Operators {
+		record, err := enr.Parse(op.ENR)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode enr", z.Str("enr", op.ENR))
+		}
+
+		peers[i].Addrs, err = p2p.AddrsFromENR(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var unreachable []int
+	for _, p := range peers {
+		if len(p.Addrs) == 0 {
+			// Operators behind NAT only reachable via relays don't advertise an address.
+			log.Info(ctx, "Operator has no advertised address, skipped", z.Int("operator", p.Index), z.Str("peer", p.Name))
+			continue
+		}
+
+		dialCtx, cancel := context.WithTimeout(ctx, connectivityDialTimeout)
+		err := dial(dialCtx, p)
+		cancel()
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			log.Warn(ctx, "Operator unreachable, ensure its charon node is running and publicly reachable", err,
+				z.Int("operator", p.Index), z.Str("peer", p.Name), z.Any("addrs", p.Addrs))
+			unreachable = append(unreachable, p.Index)
+
+			continue
+		}
+
+		log.Debug(ctx, "Operator reachable", z.Int("operator", p.Index), z.Str("peer", p.Name))
+	}
+
+	if len(unreachable) == 0 {
+		log.Info(ctx, "No unreachable operators", z.Int("operators", len(peers)))
+	}
+
+	return unreachable, nil
+}
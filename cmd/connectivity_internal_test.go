@@ -0,0 +1,138 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestCheckOperatorConnectivity(t *testing.T) {
+	lock, p2pKeys, _ := cluster.NewForT(t, 1, 3, 4, 0)
+
+	const (
+		unreachableIdx = 2
+		relayOnlyIdx   = 3
+	)
+
+	// Advertise addresses for all operators, except the relay-only operator.
+	for i, key := range p2pKeys {
+		if i == relayOnlyIdx {
+			continue
+		}
+
+		record, err := enr.New(key, enr.WithIP(net.IPv4(127, 0, 0, 1)), enr.WithTCP(3610+i))
+		require.NoError(t, err)
+		lock.Operators[i].ENR = record.String()
+	}
+
+	var buf zaptest.Buffer
+	log.InitLogfmtForT(t, &buf)
+
+	var dialed []int
+	stubDialer := func(_ context.Context, p p2p.Peer) error {
+		dialed = append(dialed, p.Index)
+		if p.Index == unreachableIdx {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	}
+
+	unreachable, err := checkOperatorConnectivity(context.Background(), lock.Definition, stubDialer)
+	require.NoError(t, err)
+	require.Equal(t, []int{unreachableIdx}, unreachable)
+	require.Equal(t, []int{0, 1, 2}, dialed)
+
+	require.Contains(t, buf.String(), "Operator unreachable")
+	require.Contains(t, buf.String(), "connection refused")
+	require.Contains(t, buf.String(), "operator=2")
+	require.Contains(t, buf.String(), `msg="Operator has no advertised address, skipped" operator=3`)
+	require.NotContains(t, buf.String(), `msg="Operator unreachable, ensure its charon node is running and publicly reachable" operator=3`)
+
+	t.Run("no enrs", func(t *testing.T) {
+		def := lock.Definition
+		def.Operators = []cluster.Operator{{}, {}, {}, {}}
+
+		unreachable, err := checkOperatorConnectivity(context.Background(), def, func(context.Context, p2p.Peer) error {
+			require.Fail(t, "unexpected dial")
+			return nil
+		})
+		require.NoError(t, err)
+		require.Empty(t, unreachable)
+	})
+
+	t.Run("libp2p dialer", func(t *testing.T) {
+		_, p2pKeys, _ := cluster.NewForT(t, 1, 1, 1, 0)
+		remote, err := libp2p.New(
+			libp2p.Identity((*crypto.Secp256k1PrivateKey)(p2pKeys[0])),
+			libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		)
+		require.NoError(t, err)
+		defer remote.Close()
+
+		dial, closeFunc, err := newLibP2PDialer()
+		require.NoError(t, err)
+		defer closeFunc()
+
+		require.NoError(t, dial(context.Background(), p2p.Peer{ID: remote.ID(), Addrs: remote.Addrs()}))
+		require.ErrorContains(t, dial(context.Background(), p2p.Peer{ID: remote.ID()}), "no advertised addresses")
+
+		addrs := remote.Addrs()
+		require.NoError(t, remote.Close())
+
+		// Use a new dialer, since the existing one may still be connected.
+		dial2, closeFunc2, err := newLibP2PDialer()
+		require.NoError(t, err)
+		defer closeFunc2()
+
+		require.Error(t, dial2(context.Background(), p2p.Peer{ID: remote.ID(), Addrs: addrs}))
+	})
+
+	t.Run("enr addresses", func(t *testing.T) {
+		lock, p2pKeys, _ := cluster.NewForT(t, 1, 1, 1, 0)
+		remote, err := libp2p.New(
+			libp2p.Identity((*crypto.Secp256k1PrivateKey)(p2pKeys[0])),
+			libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		)
+		require.NoError(t, err)
+		defer remote.Close()
+
+		port, err := remote.Addrs()[0].ValueForProtocol(ma.P_TCP)
+		require.NoError(t, err)
+		tcpPort, err := strconv.Atoi(port)
+		require.NoError(t, err)
+
+		record, err := enr.New(p2pKeys[0], enr.WithIP(net.IPv4(127, 0, 0, 1)), enr.WithTCP(tcpPort))
+		require.NoError(t, err)
+
+		def := lock.Definition
+		def.Operators = []cluster.Operator{{ENR: record.String()}}
+
+		var addrs []ma.Multiaddr
+		unreachable, err := checkOperatorConnectivity(context.Background(), def, func(_ context.Context, p p2p.Peer) error {
+			addrs = p.Addrs
+			return nil
+		})
+		require.NoError(t, err)
+		require.Empty(t, unreachable)
+		require.Len(t, addrs, 1)
+		require.Equal(t, "/ip4/127.0.0.1/tcp/"+port, addrs[0].String())
+
+		require.NoError(t, checkConnectivity(context.Background(), def))
+	})
+}
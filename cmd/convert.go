@@ -0,0 +1,19 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func newConvertCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert charon artifacts offline",
+		Long:  "Convert charon artifacts offline between versions, e.g. to temporarily run an older charon version.",
+	}
+
+	root.AddCommand(cmds...)
+
+	titledHelp(root)
+
+	return root
+}
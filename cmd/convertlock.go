@@ -0,0 +1,162 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+type convertLockConfig struct {
+	LockFile         string
+	LockPasswordFile string
+	ToVersion        string
+	OutputFile       string
+}
+
+func newConvertLockCmd(runFunc func(context.Context, io.Writer, convertLockConfig) error) *cobra.Command {
+	var conf convertLockConfig
+
+	cmd := &cobra.Command{
+		Use:   "cluster-lock",
+		Short: "Convert a cluster lock file to another version",
+		Long: "Converts a cluster lock file to another supported version, upgrading or downgrading it. " +
+			"Conversions that would lose data, e.g. deposit data when downgrading, are refused. " +
+			"Note the signatures of a converted lock are invalid, since the version is part of the signed hashes, " +
+			"so the converted lock can only be used with --no-verify.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindConvertLockFlags(cmd.Flags(), &conf)
+	mustMarkFlagRequired(cmd, "lock-file")
+	mustMarkFlagRequired(cmd, "to-version")
+
+	return cmd
+}
+
+func bindConvertLockFlags(flags *pflag.FlagSet, config *convertLockConfig) {
+	flags.StringVar(&config.LockFile, "lock-file", "", "The path to the cluster-lock.json file to convert.")
+	bindLockPasswordFlag(flags, &config.LockPasswordFile)
+	flags.StringVar(&config.ToVersion, "to-version", "", "The cluster lock version to convert to, e.g. v1.5.0.")
+	flags.StringVar(&config.OutputFile, "output-file", "", "The path to write the converted cluster lock to. Defaults to printing it to stdout.")
+}
+
+// runConvertLock converts the configured cluster lock file to the configured version.
+func runConvertLock(ctx context.Context, w io.Writer, conf convertLockConfig) error {
+	lock, err := readLockFile(conf.LockFile, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	} else if err := lock.VerifyHashes(); err != nil {
+		return errors.Wrap(err, "verify cluster lock hashes")
+	}
+
+	converted, err := convertLock(lock, conf.ToVersion)
+	if err != nil {
+		return err
+	}
+
+	log.Warn(ctx, "Signatures of the converted cluster lock are invalid, run charon with --no-verify", nil,
+		z.Str("from_version", lock.Version), z.Str("to_version", converted.Version))
+
+	password, err := cluster.LoadLockPassword(conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	if password != "" {
+		b, err = cluster.EncryptLock(converted, password)
+	} else {
+		b, err = json.MarshalIndent(converted, "", " ")
+	}
+	if err != nil {
+		return errors.Wrap(err, "marshal cluster lock")
+	}
+
+	if conf.OutputFile == "" {
+		_, _ = fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	if err := os.WriteFile(conf.OutputFile, b, 0o400); err != nil { // read-only
+		return errors.Wrap(err, "write cluster lock", z.Str("file", conf.OutputFile))
+	}
+
+	_, _ = fmt.Fprintf(w, "Converted cluster lock from %s to %s: %s\n", lock.Version, converted.Version, conf.OutputFile)
+
+	return nil
+}
+
+// convertIgnoredFields are the lock fields expected to change when converting versions.
+var convertIgnoredFields = map[string]bool{
+	"lock_hash":                          true,
+	"cluster_definition.definition_hash": true,
+	"cluster_definition.config_hash":     true,
+	"cluster_definition.version":         true,
+}
+
+// convertLock returns the lock converted to the version with updated hashes. The conversion is verified by
+// a json round trip, returning an error if any data of the lock is not supported by the version.
+func convertLock(lock cluster.Lock, version string) (cluster.Lock, error) {
+	if !cluster.SupportedVersion(version) {
+		return cluster.Lock{}, errors.New("unsupported cluster lock version", z.Str("version", version))
+	}
+
+	// Definitions without a tss scheme use the default scheme, so it isn't lost when converting to versions not supporting it.
+	if !cluster.SupportTSSScheme(version) && lock.Definition.TSSScheme == tblsv2.DefaultTSSScheme {
+		lock.Definition.TSSScheme = ""
+	}
+
+	converted := lock
+	converted.Version = version
+
+	var err error
+	converted.Definition, err = converted.Definition.SetDefinitionHashes()
+	if err != nil {
+		return cluster.Lock{}, err
+	}
+
+	converted, err = converted.SetLockHash()
+	if err != nil {
+		return cluster.Lock{}, err
+	}
+
+	b, err := json.Marshal(converted)
+	if err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "marshal cluster lock")
+	}
+
+	var resp cluster.Lock
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "unmarshal converted cluster lock")
+	}
+
+	var lost []string
+	for _, diff := range diffLocks(lock, resp) {
+		if !convertIgnoredFields[diff.Field] {
+			lost = append(lost, diff.Field)
+		}
+	}
+
+	if len(lost) > 0 {
+		return cluster.Lock{}, errors.New("conversion would lose cluster lock data not supported by version",
+			z.Str("version", version), z.Any("fields", lost))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestConvertLock(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+	require.Equal(t, "v1.5.0", lock.Version)
+
+	t.Run("upgrade", func(t *testing.T) {
+		dir := t.TempDir()
+		lockFile, outputFile := path.Join(dir, "cluster-lock.json"), path.Join(dir, "converted.json")
+		writeLockFile(t, lockFile, lock)
+
+		var buf bytes.Buffer
+		err := runConvertLock(context.Background(), &buf, convertLockConfig{
+			LockFile:   lockFile,
+			ToVersion:  "v1.6.0",
+			OutputFile: outputFile,
+		})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "Converted cluster lock from v1.5.0 to v1.6.0")
+
+		converted, err := readLockFile(outputFile, "")
+		require.NoError(t, err)
+		require.Equal(t, "v1.6.0", converted.Version)
+		require.NoError(t, converted.VerifyHashes())
+		require.NotEqual(t, lock.LockHash, converted.LockHash)
+		for _, diff := range diffLocks(lock, converted) {
+			require.True(t, convertIgnoredFields[diff.Field], diff.Field)
+		}
+
+		// Downgrading without v1.6.0 data restores the original lock.
+		downgraded, err := convertLock(converted, "v1.5.0")
+		require.NoError(t, err)
+		require.Empty(t, diffLocks(lock, downgraded))
+	})
+
+	t.Run("lossy downgrade", func(t *testing.T) {
+		upgraded, err := convertLock(lock, "v1.6.0")
+		require.NoError(t, err)
+
+		upgraded.Validators = append([]cluster.DistValidator(nil), upgraded.Validators...)
+		upgraded.Validators[1].DepositData = cluster.DepositData{
+			PubKey:                upgraded.Validators[1].PubKey,
+			WithdrawalCredentials: testutil.RandomBytes32(),
+			Amount:                32000000000,
+			Signature:             testutil.RandomBytes96(),
+		}
+		upgraded.Validators[1].Graffiti = "graffiti"
+		upgraded, err = upgraded.SetLockHash()
+		require.NoError(t, err)
+
+		_, err = convertLock(upgraded, "v1.5.0")
+		require.ErrorContains(t, err, "conversion would lose cluster lock data not supported by version")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := convertLock(lock, "v0.9.0")
+		require.ErrorContains(t, err, "unsupported cluster lock version")
+	})
+}
@@ -12,19 +12,26 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/pushmetrics"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/eth2util/keymanager"
 	"github.com/obolnetwork/charon/eth2util/keystore"
@@ -37,29 +44,65 @@ const (
 	defaultWithdrawalAddr = "0x0000000000000000000000000000000000000000"
 	defaultNetwork        = "goerli"
 	minNodes              = 4
+	unsafeMinNodesFloor   = 2
+	maxNameLen            = 64
+	maxGraffitiLen        = 32
+	maxBuilderRelays      = 16
+	maxBuilderRelayLen    = 256
+	// validatorConfigVersion is the cluster definition version of new definitions with custom validator graffiti,
+	// builder relays, observers or a non-default tss scheme, since these are only supported by the draft v1.6.0 lock.
+	validatorConfigVersion = "v1.6.0"
+
+	publishModeFull     = "full"
+	publishModeHashOnly = "hash-only"
 )
 
 type clusterConfig struct {
+	ConfigFile      string
 	Name            string
 	ClusterDir      string
-	DefFile         string
+	DefFiles        []string
 	KeymanagerAddrs []string
 	Clean           bool
 
 	NumNodes          int
+	NumObservers      int
 	Threshold         int
 	FeeRecipientAddrs []string
 	WithdrawalAddrs   []string
 	Network           string
 	NumDVs            int
 
+	DepositContractAddr string
+	DepositChainID      int64
+	WithdrawalCredTypes []string
+	Graffiti            []string
+	BuilderRelays       []string
+	TSSScheme           string
+
 	SplitKeys    bool
 	SplitKeysDir string
 
-	InsecureKeys bool
+	InsecureKeys   bool
+	UnsafeMinNodes int
+	NoDepositData  bool
+	DepositDataSSZ bool
+	WriteHashes    bool
+
+	DepositRemoteSignerURL string
+
+	CheckConnectivity bool
 
 	PublishAddr string
 	Publish     bool
+	PublishMode string
+
+	PushgatewayAddr string
+
+	LockPasswordFile string
+
+	EntropyFile string
+	Entropy     io.Reader // Entropy source of the definition nonces, defaults to crypto/rand if nil.
 }
 
 func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig) error) *cobra.Command {
@@ -71,40 +114,140 @@ func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig)
 		Long: "Creates a local charon cluster configuration including validator keys, charon p2p keys, cluster-lock.json and a deposit-data.json. " +
 			"See flags for supported features.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadConfigFile(cmd.Flags(), conf.ConfigFile); err != nil {
+				return err
+			}
+
 			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
 		},
 	}
 
+	cmd.Flags().StringVar(&conf.ConfigFile, "config-file", "", "Optional path to a YAML or JSON file containing flag values keyed by flag name. Flags provided on the command line take precedence.")
 	bindClusterFlags(cmd.Flags(), &conf)
 	bindInsecureFlags(cmd.Flags(), &conf.InsecureKeys)
+	bindUnsafeMinNodesFlag(cmd.Flags(), &conf.UnsafeMinNodes)
+	bindCheckConnectivityFlag(cmd.Flags(), &conf.CheckConnectivity)
+	bindPushgatewayFlag(cmd.Flags(), &conf.PushgatewayAddr)
+	bindLockPasswordFlag(cmd.Flags(), &conf.LockPasswordFile)
+	cmd.Flags().BoolVar(&conf.WriteHashes, "write-hashes", false, "Write the hex encoded cluster definition and lock hashes to definition-hash.txt and lock-hash.txt in the cluster directory, e.g. for automation pipelines.")
+	cmd.Flags().BoolVar(&conf.NoDepositData, "no-deposit-data", false, "Skip generating deposit data, e.g. when splitting keys of already active validators. The cluster lock will not contain deposit data.")
+	cmd.Flags().BoolVar(&conf.DepositDataSSZ, "deposit-data-ssz", false, "Additionally write the deposit data as a deposit-data.ssz file containing the SSZ encoded list of consensus spec DepositData containers.")
 
 	return cmd
 }
 
+// loadConfigFile sets the flags not provided on the command line from the YAML or JSON config file keyed by flag name.
+// Unknown keys are rejected.
+func loadConfigFile(flags *pflag.FlagSet, file string) error {
+	if file == "" {
+		return nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return errors.Wrap(err, "read config file", z.Str("file", file))
+	}
+
+	keys := v.AllKeys()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		flag := flags.Lookup(key)
+		if flag == nil || key == "config-file" {
+			return errors.New("unknown config file key", z.Str("key", key), z.Str("file", file))
+		} else if flag.Changed {
+			continue // Command line flags take precedence.
+		}
+
+		val := fmt.Sprint(v.Get(key))
+		if list, ok := v.Get(key).([]any); ok {
+			var vals []string
+			for _, elem := range list {
+				vals = append(vals, fmt.Sprint(elem))
+			}
+			val = strings.Join(vals, ",")
+		}
+
+		if err := flags.Set(key, val); err != nil {
+			return errors.Wrap(err, "set config file value", z.Str("key", key))
+		}
+	}
+
+	return nil
+}
+
 func bindClusterFlags(flags *pflag.FlagSet, config *clusterConfig) {
-	flags.StringVar(&config.Name, "name", "", "The cluster name")
+	flags.StringVar(&config.Name, "name", "", "The cluster name. Defaults to a name generated from the cluster definition hash if empty.")
 	flags.StringVar(&config.ClusterDir, "cluster-dir", ".charon/cluster", "The target folder to create the cluster in.")
-	flags.StringVar(&config.DefFile, "definition-file", "", "Optional path to a cluster definition file or an HTTP URL. This overrides all other configuration flags.")
+	flags.StringSliceVar(&config.DefFiles, "definition-file", nil, "Optional path to a cluster definition file or an HTTP URL. This overrides all other configuration flags. Repeat the flag to provide mirrors, each is tried in order until one succeeds.")
 	flags.StringSliceVar(&config.KeymanagerAddrs, "keymanager-addresses", nil, "Comma separated list of keymanager URLs to import validator key shares to. Note that multiple addresses are required, one for each node in the cluster, with node0's keyshares being imported to the first address, node1's keyshares to the second, and so on.")
 	flags.IntVarP(&config.NumNodes, "nodes", "", minNodes, "The number of charon nodes in the cluster. Minimum is 4.")
+	flags.IntVar(&config.NumObservers, "num-observers", 0, "The number of non-voting observer nodes, the last nodes of the cluster. Observers don't sign and don't count towards the quorum or threshold, so no validator keys are created for them. Requires cluster lock version v1.6.0 or later.")
 	flags.IntVarP(&config.Threshold, "threshold", "", 0, "Optional override of threshold required for signature reconstruction. Defaults to ceil(n*2/3) if zero. Warning, non-default values decrease security.")
 	flags.StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each validator. Either provide a single fee recipient address or fee recipient addresses for each validator.")
 	flags.StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
-	flags.StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, gnosis, goerli, kiln, ropsten, sepolia.")
+	flags.StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, gnosis, goerli, kiln, ropsten, sepolia, holesky.")
+	flags.StringVar(&config.DepositContractAddr, "deposit-contract-address", "", "Optional deposit contract address to include in the deposit data, required for custom networks with their own deposit contract. Requires --deposit-chain-id.")
+	flags.Int64Var(&config.DepositChainID, "deposit-chain-id", 0, "Optional chain ID to include in the deposit data alongside the deposit contract address. Requires --deposit-contract-address.")
+	flags.StringSliceVar(&config.WithdrawalCredTypes, "withdrawal-credential-types", nil, "Comma separated list of withdrawal credential types for each validator. Options: 0x01 (execution), 0x02 (compounding). Either provide a single type or types for each validator. Defaults to 0x01.")
+	flags.StringSliceVar(&config.Graffiti, "graffiti", nil, "Optional comma separated list of custom graffiti (max 32 bytes) included in blocks proposed by each validator. Either provide a single graffiti or graffiti for each validator. Requires cluster lock version v1.6.0 or later.")
+	flags.StringSliceVar(&config.BuilderRelays, "builder-relays", nil, "Optional comma separated list of builder relay URLs preferred by all validators for builder API block proposals. Validator builder registrations are also submitted to these relays. Requires cluster lock version v1.6.0 or later.")
+	flags.StringVar(&config.TSSScheme, "tss-scheme", tblsv2.DefaultTSSScheme, "Threshold secret sharing scheme used to split the validator keys. Options: "+strings.Join(tblsv2.TSSSchemes(), ", ")+". Non-default schemes require cluster lock version v1.6.0 or later.")
 	flags.BoolVar(&config.Clean, "clean", false, "Delete the cluster directory before generating it.")
 	flags.IntVar(&config.NumDVs, "num-validators", 1, "The number of distributed validators needed in the cluster.")
 	flags.BoolVar(&config.SplitKeys, "split-existing-keys", false, "Split an existing validator's private key into a set of distributed validator private key shares. Does not re-create deposit data for this key.")
-	flags.StringVar(&config.SplitKeysDir, "split-keys-dir", "", "Directory containing keys to split. Expects keys in keystore-*.json and passwords in keystore-*.txt. Requires --split-existing-keys.")
+	flags.StringVar(&config.SplitKeysDir, "split-keys-dir", "", "Directory containing keys to split. Expects keys in keystore-*.json and passwords in keystore-*.txt, or in CHARON_KEYSTORE_PASSWORD_<n> (or shared CHARON_KEYSTORE_PASSWORD) environment variables for keystore-<n>.json. Requires --split-existing-keys.")
+	flags.StringVar(&config.DepositRemoteSignerURL, "deposit-remote-signer-url", "", "Optional URL of a Web3Signer style remote signer, e.g. backed by an HSM, holding the validator keys to sign the deposit data with instead of in-memory keys. Signing roots are posted to <url>/api/v1/eth2/sign/<pubkey>.")
+	flags.StringVar(&config.EntropyFile, "entropy-file", "", "Optional path to a custom entropy source, e.g. a hardware RNG device like /dev/hwrng, used to generate the cluster definition nonces. Defaults to crypto/rand.")
 	flags.StringVar(&config.PublishAddr, "publish-address", "https://api.obol.tech", "The URL to publish the lock file to.")
 	flags.BoolVar(&config.Publish, "publish", false, "Publish lock file to obol-api.")
+	flags.StringVar(&config.PublishMode, "publish-mode", publishModeFull, "What to publish to obol-api when --publish is set. Options: full (the complete lock file), hash-only (only the lock and definition hashes with minimal metadata).")
 }
 
 func bindInsecureFlags(flags *pflag.FlagSet, insecureKeys *bool) {
 	flags.BoolVar(insecureKeys, "insecure-keys", false, "Generates insecure keystore files. This should never be used. It is not supported on mainnet.")
 }
 
-func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) error {
-	var err error
+func bindUnsafeMinNodesFlag(flags *pflag.FlagSet, unsafeMinNodes *int) {
+	flags.IntVar(unsafeMinNodes, "unsafe-min-nodes", 0, "Overrides the minimum number of nodes (4) for research setups on test networks, e.g. 2 or 3. Requires --insecure-keys and a threshold of at least 2. This is insecure and should never be used. It is not supported on mainnet.")
+}
+
+func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) (err error) {
+	var def cluster.Definition
+	timer := newPhaseTimer()
+	if conf.PushgatewayAddr != "" {
+		t0 := time.Now()
+		defer func() {
+			pushOperationMetrics(ctx, conf.PushgatewayAddr, pushmetrics.Operation{
+				Name:          "create_cluster",
+				Duration:      time.Since(t0),
+				NumValidators: def.NumValidators,
+				Phases:        timer.Durations(),
+				Err:           err,
+			})
+		}()
+	}
+
+	if conf.Publish && conf.PublishMode != "" && conf.PublishMode != publishModeFull && conf.PublishMode != publishModeHashOnly {
+		return errors.New("unsupported publish mode", z.Str("mode", conf.PublishMode))
+	}
+
+	// Only existing keys can be held by a remote signer, new keys are generated in-memory.
+	if conf.DepositRemoteSignerURL != "" && (!conf.SplitKeys || conf.NoDepositData) {
+		return errors.New("--deposit-remote-signer-url requires --split-existing-keys and deposit data")
+	}
+
+	depositOpts, err := depositMarshalOpts(conf)
+	if err != nil {
+		return err
+	}
+
+	lockPassword, err := cluster.LoadLockPassword(conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
 	if conf.Clean {
 		// Remove previous directories
 		if err = os.RemoveAll(conf.ClusterDir); err != nil {
@@ -120,13 +263,22 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		conf.Network = eth2util.Goerli.Name
 	}
 
-	var def cluster.Definition
-	if conf.DefFile != "" { // Load definition from DefFile
-		def, err = loadDefinition(ctx, conf.DefFile)
+	if len(conf.DefFiles) > 0 { // Load definition from DefFiles
+		def, err = loadDefinition(ctx, conf.DefFiles)
 		if err != nil {
 			return err
 		}
 	} else { // Create new definition from cluster config
+		if conf.EntropyFile != "" {
+			f, err := os.Open(conf.EntropyFile)
+			if err != nil {
+				return errors.Wrap(err, "open entropy file")
+			}
+			defer f.Close()
+
+			conf.Entropy = f
+		}
+
 		def, err = newDefFromConfig(ctx, conf)
 		if err != nil {
 			return err
@@ -135,21 +287,58 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 
 	numNodes := len(def.Operators)
 	// Validate definition
-	err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def)
+	err = validateDefAll(ctx, conf.InsecureKeys, conf.UnsafeMinNodes, conf.KeymanagerAddrs, def)
 	if err != nil {
 		return err
 	}
 
+	if conf.CheckConnectivity {
+		if err := checkConnectivity(ctx, def); err != nil {
+			return err
+		}
+	}
+
+	graffiti, err := validatorGraffiti(conf.Graffiti, def.NumValidators)
+	if err != nil {
+		return err
+	} else if len(graffiti) > 0 && !cluster.SupportGraffiti(def.Version) {
+		return errors.New("graffiti not supported by cluster definition version", z.Str("version", def.Version))
+	}
+
+	if err := validateBuilderRelays(conf.BuilderRelays); err != nil {
+		return err
+	} else if len(conf.BuilderRelays) > 0 && !cluster.SupportBuilderRelays(def.Version) {
+		return errors.New("builder relays not supported by cluster definition version", z.Str("version", def.Version))
+	}
+
+	scheme, err := tblsv2.GetTSSScheme(def.TSSScheme)
+	if err != nil {
+		return err
+	} else if scheme.Name() != tblsv2.DefaultTSSScheme && !cluster.SupportTSSScheme(def.Version) {
+		return errors.New("tss scheme not supported by cluster definition version", z.Str("version", def.Version))
+	} else if conf.TSSScheme != "" && conf.TSSScheme != tblsv2.DefaultTSSScheme && conf.TSSScheme != scheme.Name() {
+		return errors.New("tss scheme doesn't match cluster definition",
+			z.Str("tss_scheme", conf.TSSScheme), z.Str("definition_tss_scheme", scheme.Name()))
+	}
+	timer.Mark(phaseDefinition)
+
 	// Get root bls secrets
 	secrets, err := getKeys(conf.SplitKeys, conf.SplitKeysDir, def.NumValidators)
 	if err != nil {
 		return err
 	}
+	timer.Mark(phaseKeyGeneration)
+
+	prefixes, err := withdrawalPrefixes(conf.WithdrawalCredTypes, len(secrets))
+	if err != nil {
+		return err
+	}
 	// Generate threshold bls key shares
-	pubkeys, shareSets, err := getTSSShares(secrets, def.Threshold, numNodes)
+	pubkeys, shareSets, err := getTSSShares(scheme, secrets, def.Threshold, numNodes)
 	if err != nil {
 		return err
 	}
+	timer.Mark(phaseTSSSplit)
 
 	// Create cluster directory at the given location.
 	if err := os.MkdirAll(conf.ClusterDir, 0o755); err != nil {
@@ -161,32 +350,69 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 	if err != nil {
 		return err
 	}
+	for i := range ops { // Retain the operators' voting configuration.
+		ops[i].Weight = def.Operators[i].Weight
+		ops[i].Observer = def.Operators[i].Observer
+	}
 	def.Operators = ops
 
+	// Operators change the definition hash, so recalculate it to match the marshalled lock.
+	def, err = def.SetDefinitionHashes()
+	if err != nil {
+		return err
+	}
+
+	observers := observerIdxs(def)
 	keysToDisk := len(conf.KeymanagerAddrs) == 0
 	if keysToDisk { // Save keys to disk
-		if err = writeKeysToDisk(numNodes, conf.ClusterDir, conf.InsecureKeys, shareSets); err != nil {
+		if err = writeKeysToDisk(numNodes, conf.ClusterDir, conf.InsecureKeys, shareSets, observers); err != nil {
 			return err
 		}
 	} else { // Or else save keys to keymanager
-		if err = writeKeysToKeymanager(ctx, conf.KeymanagerAddrs, numNodes, shareSets); err != nil {
+		if err = writeKeysToKeymanager(ctx, conf.KeymanagerAddrs, numNodes, shareSets, observers); err != nil {
 			return err
 		}
 	}
+	timer.Mark(phaseDiskWrite)
 
 	network, err := eth2util.ForkVersionToNetwork(def.ForkVersion)
 	if err != nil {
 		return err
 	}
 
-	depositDatas, err := createDepositDatas(def.WithdrawalAddresses(), network, secrets)
-	if err != nil {
-		return err
+	if keysToDisk {
+		if err = writeSlashingProtection(shareSets, network, conf.ClusterDir, numNodes, observers); err != nil {
+			return err
+		}
+		timer.Mark(phaseDiskWrite)
 	}
 
-	// Write deposit-data file
-	if err = writeDepositData(depositDatas, network, conf.ClusterDir, numNodes); err != nil {
-		return err
+	var depositDatas []eth2p0.DepositData
+	if !conf.NoDepositData {
+		signers := localSigners(secrets)
+		if conf.DepositRemoteSignerURL != "" {
+			signers, err = remoteSigners(conf.DepositRemoteSignerURL, secrets)
+			if err != nil {
+				return err
+			}
+		}
+
+		depositDatas, err = signDepositDatas(signers, def.WithdrawalAddresses(), prefixes, network)
+		if err != nil {
+			return err
+		}
+		timer.Mark(phaseDepositSigning)
+
+		// Write deposit-data file
+		if err = writeDepositData(depositDatas, network, conf.ClusterDir, numNodes, depositOpts...); err != nil {
+			return err
+		}
+		if conf.DepositDataSSZ {
+			if err = writeDepositDataSSZ(depositDatas, network, conf.ClusterDir, numNodes); err != nil {
+				return err
+			}
+		}
+		timer.Mark(phaseDiskWrite)
 	}
 
 	vals, err := getValidators(pubkeys, shareSets, depositDatas)
@@ -194,6 +420,14 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
+	for i, g := range graffiti {
+		vals[i].Graffiti = g
+	}
+
+	for i := range vals {
+		vals[i].BuilderRelays = conf.BuilderRelays
+	}
+
 	lock := cluster.Lock{
 		Definition: def,
 		Validators: vals,
@@ -203,45 +437,168 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
+	if err = lock.VerifyDepositData(); err != nil {
+		return err
+	}
+
 	// Write cluster-lock file
 	if conf.Publish {
-		if err = writeLockToAPI(ctx, conf.PublishAddr, lock); err != nil {
+		if err = writeLockToAPI(ctx, conf.PublishAddr, conf.PublishMode, lock); err != nil {
 			log.Warn(ctx, "Couldn't publish lock file to Obol API", err)
 		}
 	}
 
-	if err = writeLock(lock, conf.ClusterDir, numNodes, shareSets); err != nil {
+	if err = writeLock(lock, conf.ClusterDir, numNodes, shareSets, lockPassword); err != nil {
 		return err
 	}
+	timer.Mark(phaseLock)
+
+	if conf.WriteHashes {
+		if err = writeHashes(lock, conf.ClusterDir); err != nil {
+			return err
+		}
+		timer.Mark(phaseDiskWrite)
+	}
+
+	log.Info(ctx, "Cluster creation phase durations", timer.Fields()...)
 
 	if conf.SplitKeys {
 		writeWarning(w)
 	}
 
-	writeOutput(w, conf.SplitKeys, conf.ClusterDir, numNodes, keysToDisk)
+	writeOutput(w, conf.SplitKeys, conf.ClusterDir, numNodes, def.Threshold, keysToDisk, !conf.NoDepositData)
 
 	return nil
 }
 
+// Cluster creation phases timed by runCreateCluster.
+const (
+	phaseDefinition     = "definition"
+	phaseKeyGeneration  = "key_generation"
+	phaseTSSSplit       = "tss_split"
+	phaseDiskWrite      = "disk_write"
+	phaseDepositSigning = "deposit_signing"
+	phaseLock           = "lock_signing" // Includes writing the lock files.
+)
+
+// phaseTimer accumulates the durations of the consecutive phases of an operation.
+type phaseTimer struct {
+	last      time.Time
+	order     []string
+	durations map[string]time.Duration
+}
+
+func newPhaseTimer() *phaseTimer {
+	return &phaseTimer{
+		last:      time.Now(),
+		durations: make(map[string]time.Duration),
+	}
+}
+
+// Mark attributes the time since the previous mark to the phase, repeated phases accumulate.
+func (t *phaseTimer) Mark(phase string) {
+	now := time.Now()
+	if _, ok := t.durations[phase]; !ok {
+		t.order = append(t.order, phase)
+	}
+	t.durations[phase] += now.Sub(t.last)
+	t.last = now
+}
+
+// Durations returns a copy of the accumulated phase durations.
+func (t *phaseTimer) Durations() map[string]time.Duration {
+	resp := make(map[string]time.Duration)
+	for phase, d := range t.durations {
+		resp[phase] = d
+	}
+
+	return resp
+}
+
+// Fields returns the phase durations as log fields in the order the phases started.
+func (t *phaseTimer) Fields() []z.Field {
+	var resp []z.Field
+	for _, phase := range t.order {
+		resp = append(resp, z.Str(phase, t.durations[phase].String()))
+	}
+
+	return resp
+}
+
+// writeHashes writes the hex encoded definition and lock hashes to files in the cluster directory.
+func writeHashes(lock cluster.Lock, clusterDir string) error {
+	files := map[string][]byte{
+		"definition-hash.txt": lock.DefinitionHash,
+		"lock-hash.txt":       lock.LockHash,
+	}
+
+	for name, hash := range files {
+		//nolint:gosec // Hashes are public.
+		if err := os.WriteFile(path.Join(clusterDir, name), []byte(fmt.Sprintf("%#x\n", hash)), 0o644); err != nil {
+			return errors.Wrap(err, "write hash file", z.Str("file", name))
+		}
+	}
+
+	return nil
+}
+
+// signer signs messages with a BLS private key. It abstracts in-memory keys from threshold and remote signers.
+type signer interface {
+	// PublicKey returns the public key of the signing key.
+	PublicKey() (tblsv2.PublicKey, error)
+	// Sign returns the signature of the message.
+	Sign(msg []byte) (tblsv2.Signature, error)
+}
+
+// localSigner is a signer using an in-memory private key.
+type localSigner struct {
+	secret tblsv2.PrivateKey
+}
+
+func (s localSigner) PublicKey() (tblsv2.PublicKey, error) {
+	pk, err := tblsv2.SecretToPublicKey(s.secret)
+	if err != nil {
+		return tblsv2.PublicKey{}, errors.Wrap(err, "secret to pubkey")
+	}
+
+	return pk, nil
+}
+
+func (s localSigner) Sign(msg []byte) (tblsv2.Signature, error) {
+	return tblsv2.Sign(s.secret, msg)
+}
+
+// localSigners returns local signers of the secrets.
+func localSigners(secrets []tblsv2.PrivateKey) []signer {
+	var resp []signer
+	for _, secret := range secrets {
+		resp = append(resp, localSigner{secret: secret})
+	}
+
+	return resp
+}
+
 // signDepositDatas returns Distributed Validator pubkeys and deposit data signatures corresponding to each pubkey.
-func signDepositDatas(secrets []tblsv2.PrivateKey, withdrawalAddresses []string, network string) ([]eth2p0.DepositData, error) {
-	if len(secrets) != len(withdrawalAddresses) {
+func signDepositDatas(signers []signer, withdrawalAddresses []string, prefixes []byte, network string) ([]eth2p0.DepositData, error) {
+	if len(signers) != len(withdrawalAddresses) {
 		return nil, errors.New("insufficient withdrawal addresses")
+	} else if len(signers) != len(prefixes) {
+		return nil, errors.New("insufficient withdrawal credential types")
 	}
 
 	var datas []eth2p0.DepositData
-	for i, secret := range secrets {
+	for i, signer := range signers {
 		withdrawalAddr, err := eth2util.ChecksumAddress(withdrawalAddresses[i])
 		if err != nil {
 			return nil, err
 		}
 
-		pk, err := tblsv2.SecretToPublicKey(secret)
+		pk, err := signer.PublicKey()
 		if err != nil {
-			return nil, errors.Wrap(err, "secret to pubkey")
+			return nil, err
 		}
 
-		msg, err := deposit.NewMessage(eth2p0.BLSPubKey(pk), withdrawalAddr)
+		msg, err := deposit.NewMessageWithPrefix(eth2p0.BLSPubKey(pk), withdrawalAddr, prefixes[i])
 		if err != nil {
 			return nil, err
 		}
@@ -251,7 +608,7 @@ func signDepositDatas(secrets []tblsv2.PrivateKey, withdrawalAddresses []string,
 			return nil, err
 		}
 
-		sig, err := tblsv2.Sign(secret, sigRoot[:])
+		sig, err := signer.Sign(sigRoot[:])
 		if err != nil {
 			return nil, err
 		}
@@ -267,14 +624,14 @@ func signDepositDatas(secrets []tblsv2.PrivateKey, withdrawalAddresses []string,
 	return datas, nil
 }
 
-// getTSSShares splits the secrets and returns the threshold key shares.
-func getTSSShares(secrets []tblsv2.PrivateKey, threshold, numNodes int) ([]tblsv2.PublicKey, [][]tblsv2.PrivateKey, error) {
+// getTSSShares splits the secrets using the threshold secret sharing scheme and returns the threshold key shares.
+func getTSSShares(scheme tblsv2.TSSScheme, secrets []tblsv2.PrivateKey, threshold, numNodes int) ([]tblsv2.PublicKey, [][]tblsv2.PrivateKey, error) {
 	var (
 		dvs    []tblsv2.PublicKey
 		splits [][]tblsv2.PrivateKey
 	)
 	for _, secret := range secrets {
-		shares, err := tblsv2.ThresholdSplit(secret, uint(numNodes), uint(threshold))
+		shares, err := scheme.Split(secret, uint(numNodes), uint(threshold))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -318,7 +675,7 @@ func getKeys(splitKeys bool, splitKeysDir string, numDVs int) ([]tblsv2.PrivateK
 			return nil, errors.New("--split-keys-dir required when splitting keys")
 		}
 
-		return keystore.LoadKeys(splitKeysDir)
+		return keystore.LoadKeysWithPasswords(splitKeysDir, keystore.EnvPasswords())
 	}
 
 	var secrets []tblsv2.PrivateKey
@@ -335,18 +692,68 @@ func getKeys(splitKeys bool, splitKeysDir string, numDVs int) ([]tblsv2.PrivateK
 }
 
 // createDepositDatas creates a slice of deposit datas using the provided parameters and returns it.
-func createDepositDatas(withdrawalAddresses []string, network string, secrets []tblsv2.PrivateKey) ([]eth2p0.DepositData, error) {
+func createDepositDatas(withdrawalAddresses []string, prefixes []byte, network string, secrets []tblsv2.PrivateKey) ([]eth2p0.DepositData, error) {
 	if len(secrets) != len(withdrawalAddresses) {
 		return nil, errors.New("insufficient withdrawal addresses")
 	}
 
-	return signDepositDatas(secrets, withdrawalAddresses, network)
+	return signDepositDatas(localSigners(secrets), withdrawalAddresses, prefixes, network)
+}
+
+// withdrawalPrefixes returns the withdrawal credentials prefix of each validator given either a single
+// withdrawal credential type or a type per validator. It defaults to 0x01 execution credentials.
+func withdrawalPrefixes(types []string, numVals int) ([]byte, error) {
+	if len(types) == 0 {
+		types = []string{"0x01"}
+	}
+
+	if len(types) != numVals && len(types) != 1 {
+		return nil, errors.New("insufficient withdrawal credential types",
+			z.Int("expected", numVals), z.Int("got", len(types)))
+	}
+
+	var resp []byte
+	for i := 0; i < numVals; i++ {
+		typ := types[0]
+		if len(types) > 1 {
+			typ = types[i]
+		}
+
+		switch strings.TrimSpace(typ) {
+		case "0x01":
+			resp = append(resp, deposit.ExecutionWithdrawalPrefix)
+		case "0x02":
+			resp = append(resp, deposit.CompoundingWithdrawalPrefix)
+		default:
+			return nil, errors.New("unsupported withdrawal credential type", z.Str("type", typ))
+		}
+	}
+
+	return resp, nil
+}
+
+// depositMarshalOpts returns the deposit data marshal options for the configured deposit contract.
+func depositMarshalOpts(conf clusterConfig) ([]deposit.MarshalOption, error) {
+	if conf.DepositContractAddr == "" && conf.DepositChainID == 0 {
+		return nil, nil
+	} else if conf.DepositContractAddr == "" || conf.DepositChainID == 0 {
+		return nil, errors.New("deposit contract address and chain ID must be provided together")
+	}
+
+	addr, err := eth2util.ChecksumAddress(conf.DepositContractAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit contract address")
+	}
+
+	return []deposit.MarshalOption{deposit.WithDepositContract(addr, conf.DepositChainID)}, nil
 }
 
 // writeDepositData writes deposit data to disk for the DVs for all peers in a cluster.
-func writeDepositData(depositDatas []eth2p0.DepositData, network string, clusterDir string, numNodes int) error {
+func writeDepositData(depositDatas []eth2p0.DepositData, network string, clusterDir string, numNodes int,
+	opts ...deposit.MarshalOption,
+) error {
 	// Serialize the deposit data into bytes
-	bytes, err := deposit.MarshalDepositData(depositDatas, network)
+	bytes, err := deposit.MarshalDepositData(depositDatas, network, opts...)
 	if err != nil {
 		return err
 	}
@@ -362,17 +769,66 @@ func writeDepositData(depositDatas []eth2p0.DepositData, network string, cluster
 	return nil
 }
 
-// writeLock creates a cluster lock and writes it to disk for all peers.
-func writeLock(lock cluster.Lock, clusterDir string, numNodes int, shareSets [][]tblsv2.PrivateKey) error {
-	var err error
-	lock.SignatureAggregate, err = aggSign(shareSets, lock.LockHash)
+// writeDepositDataSSZ writes the ssz encoded deposit data sidecar file to the cluster directory of each node.
+func writeDepositDataSSZ(depositDatas []eth2p0.DepositData, network string, clusterDir string, numNodes int) error {
+	b, err := deposit.MarshalDepositDataSSZ(depositDatas, network)
 	if err != nil {
 		return err
 	}
 
-	b, err := json.MarshalIndent(lock, "", " ")
+	for i := 0; i < numNodes; i++ {
+		file := path.Join(nodeDir(clusterDir, i), "deposit-data.ssz")
+		if err := os.WriteFile(file, b, 0o400); err != nil { // read-only
+			return errors.Wrap(err, "write deposit data ssz")
+		}
+	}
+
+	return nil
+}
+
+// writeSlashingProtection writes a minimal EIP-3076 slashing protection interchange file to the validator keys
+// directory of each node, establishing a low watermark at the current slot of the node's validator key shares.
+func writeSlashingProtection(shareSets [][]tblsv2.PrivateKey, network string, clusterDir string, numNodes int, observers map[int]bool) error {
+	now := time.Now()
+	for i := 0; i < numNodes; i++ {
+		if observers[i] {
+			continue // Observers don't sign.
+		}
+
+		var pubshares [][]byte
+		for _, shares := range shareSets {
+			pubshare, err := tblsv2.SecretToPublicKey(shares[i])
+			if err != nil {
+				return err
+			}
+
+			pubshares = append(pubshares, append([]byte(nil), pubshare[:]...))
+		}
+
+		interchange, err := eip3076.NewBaseline(network, pubshares, now)
+		if err != nil {
+			return err
+		}
+
+		b, err := json.MarshalIndent(interchange, "", " ")
+		if err != nil {
+			return errors.Wrap(err, "marshal slashing protection")
+		}
+
+		file := path.Join(nodeDir(clusterDir, i), "validator_keys", "slashing-protection.json")
+		if err := os.WriteFile(file, b, 0o444); err != nil { // read-only
+			return errors.Wrap(err, "write slashing protection")
+		}
+	}
+
+	return nil
+}
+
+// writeLock creates a cluster lock and writes it to disk for all peers, encrypted if a password is provided.
+func writeLock(lock cluster.Lock, clusterDir string, numNodes int, shareSets [][]tblsv2.PrivateKey, password string) error {
+	b, err := marshalSignedLock(lock, shareSets, password)
 	if err != nil {
-		return errors.Wrap(err, "marshal cluster lock")
+		return err
 	}
 
 	for i := 0; i < numNodes; i++ {
@@ -386,8 +842,45 @@ func writeLock(lock cluster.Lock, clusterDir string, numNodes int, shareSets [][
 	return nil
 }
 
+// marshalSignedLock returns the cluster lock signed by the key shares, encrypted if a password is provided.
+func marshalSignedLock(lock cluster.Lock, shareSets [][]tblsv2.PrivateKey, password string) ([]byte, error) {
+	var err error
+	var signerSets [][]signer
+	for _, shares := range shareSets {
+		signerSets = append(signerSets, localSigners(shares))
+	}
+
+	lock.SignatureAggregate, err = aggSign(signerSets, lock.LockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the aggregate signature before writing to disk, since an invalid lock is only detected later at runtime.
+	if err = verifyAggSign(lock); err != nil {
+		// Identify the validator with inconsistent key shares, since the global aggregate doesn't.
+		if valErr := verifyValidatorAggSigs(lock, signerSets); valErr != nil {
+			return nil, valErr
+		}
+
+		return nil, err
+	}
+
+	var b []byte
+	if password != "" {
+		b, err = cluster.EncryptLock(lock, password)
+	} else {
+		b, err = json.MarshalIndent(lock, "", " ")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal cluster lock")
+	}
+
+	return b, nil
+}
+
 // getValidators returns distributed validators from the provided dv public keys and keyshares.
 // It creates new peers from the provided config and saves validator keys to disk for each peer.
+// Deposit data is omitted from the validators if no deposit datas are provided.
 func getValidators(dvsPubkeys []tblsv2.PublicKey, dvPrivShares [][]tblsv2.PrivateKey, depositDatas []eth2p0.DepositData) ([]cluster.DistValidator, error) {
 	var vals []cluster.DistValidator
 	for idx, dv := range dvsPubkeys {
@@ -403,6 +896,15 @@ func getValidators(dvsPubkeys []tblsv2.PublicKey, dvPrivShares [][]tblsv2.Privat
 			pubshares = append(pubshares, pubk[:])
 		}
 
+		if len(depositDatas) == 0 {
+			vals = append(vals, cluster.DistValidator{
+				PubKey:    dv[:],
+				PubShares: pubshares,
+			})
+
+			continue
+		}
+
 		depositIdx := -1
 		for i, dd := range depositDatas {
 			if [48]byte(dd.PublicKey) != dv {
@@ -431,19 +933,25 @@ func getValidators(dvsPubkeys []tblsv2.PublicKey, dvPrivShares [][]tblsv2.Privat
 	return vals, nil
 }
 
-// writeKeysToKeymanager writes validator keys to the provided keymanager addresses.
-func writeKeysToKeymanager(ctx context.Context, addrs []string, numNodes int, shareSets [][]tblsv2.PrivateKey) error {
+// writeKeysToKeymanager writes validator keys to the provided keymanager addresses, skipping observers.
+func writeKeysToKeymanager(ctx context.Context, addrs []string, numNodes int, shareSets [][]tblsv2.PrivateKey, observers map[int]bool) error {
 	// Ping all keymanager addresses to check if they are accessible to avoid partial writes
 	var clients []keymanager.Client
 	for i := 0; i < numNodes; i++ {
-		cl := keymanager.New(addrs[i])
-		if err := cl.VerifyConnection(ctx); err != nil {
-			return err
+		cl := keymanager.New(addrs[i], "")
+		if !observers[i] {
+			if err := cl.VerifyConnection(ctx); err != nil {
+				return err
+			}
 		}
 		clients = append(clients, cl)
 	}
 
 	for i := 0; i < numNodes; i++ {
+		if observers[i] {
+			continue
+		}
+
 		var (
 			keystores []keystore.Keystore
 			passwords []string
@@ -462,13 +970,19 @@ func writeKeysToKeymanager(ctx context.Context, addrs []string, numNodes int, sh
 			keystores = append(keystores, store)
 		}
 
+		node := fmt.Sprintf("node%d", i)
+		addr := redact("keymanager-addresses", addrs[i]) // Keymanager URLs may contain credentials.
+		keymanagerImportCounter.WithLabelValues(node, addr).Inc()
+
 		err := clients[i].ImportKeystores(ctx, keystores, passwords)
 		if err != nil {
-			log.Error(ctx, "Failed to import keys", err, z.Str("addr", addrs[i]))
+			keymanagerImportFailedCounter.WithLabelValues(node, addr).Inc()
+			log.Error(ctx, "Failed to import keys", err, z.Str("addr", addr))
+
 			return err
 		}
 
-		log.Info(ctx, "Imported key shares to keymanager", z.Str("node", fmt.Sprintf("node%d", i)), z.Str("addr", addrs[i]))
+		log.Info(ctx, "Imported key shares to keymanager", z.Str("node", node), z.Str("addr", addr))
 	}
 
 	log.Info(ctx, "Imported all validator keys to respective keymanagers")
@@ -476,9 +990,13 @@ func writeKeysToKeymanager(ctx context.Context, addrs []string, numNodes int, sh
 	return nil
 }
 
-// writeKeysToDisk writes validator keyshares to disk. It assumes that the directory for each node already exists.
-func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, shareSets [][]tblsv2.PrivateKey) error {
+// writeKeysToDisk writes validator keyshares to disk, skipping observers. It assumes that the directory for each node already exists.
+func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, shareSets [][]tblsv2.PrivateKey, observers map[int]bool) error {
 	for i := 0; i < numNodes; i++ {
+		if observers[i] {
+			continue // Observers don't sign, so don't require key shares.
+		}
+
 		var secrets []tblsv2.PrivateKey
 		for _, shares := range shareSets {
 			secrets = append(secrets, shares[i])
@@ -503,6 +1021,18 @@ func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, shareSe
 	return nil
 }
 
+// observerIdxs returns the indexes of the non-voting observer operators.
+func observerIdxs(def cluster.Definition) map[int]bool {
+	resp := make(map[int]bool)
+	for i, op := range def.Operators {
+		if op.Observer {
+			resp[i] = true
+		}
+	}
+
+	return resp
+}
+
 // getOperators returns a list of `n` operators. It also creates a new directory corresponding to each node.
 func getOperators(n int, clusterDir string) ([]cluster.Operator, error) {
 	var ops []cluster.Operator
@@ -530,21 +1060,50 @@ func newDefFromConfig(ctx context.Context, conf clusterConfig) (cluster.Definiti
 		return cluster.Definition{}, err
 	}
 
+	if conf.NumObservers < 0 || conf.NumObservers >= conf.NumNodes {
+		return cluster.Definition{}, errors.New("invalid number of observers", z.Int("observers", conf.NumObservers))
+	}
+
 	var ops []cluster.Operator
 	for i := 0; i < conf.NumNodes; i++ {
-		ops = append(ops, cluster.Operator{})
+		ops = append(ops, cluster.Operator{Observer: i >= conf.NumNodes-conf.NumObservers})
+	}
+	// Observers don't sign, so the default threshold is based on the voting nodes.
+	threshold := safeThreshold(ctx, conf.NumNodes-conf.NumObservers, conf.Threshold)
+
+	entropy := conf.Entropy
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+
+	var opts []func(*cluster.Definition)
+	if len(conf.Graffiti) > 0 || len(conf.BuilderRelays) > 0 || conf.NumObservers > 0 || (conf.TSSScheme != "" && conf.TSSScheme != tblsv2.DefaultTSSScheme) {
+		opts = append(opts, cluster.WithVersion(validatorConfigVersion))
+	}
+	if conf.TSSScheme != "" && conf.TSSScheme != tblsv2.DefaultTSSScheme {
+		opts = append(opts, cluster.WithTSSScheme(conf.TSSScheme))
 	}
-	threshold := safeThreshold(ctx, conf.NumNodes, conf.Threshold)
 
-	def, err := cluster.NewDefinition(conf.Name, conf.NumDVs, threshold, feeRecipientAddrs,
-		withdrawalAddrs, forkVersion, cluster.Creator{}, ops, rand.Reader)
+	def, err := cluster.NewDefinition(strings.TrimSpace(conf.Name), conf.NumDVs, threshold, feeRecipientAddrs,
+		withdrawalAddrs, forkVersion, cluster.Creator{}, ops, entropy, opts...)
 	if err != nil {
 		return cluster.Definition{}, err
 	}
 
+	if def.Name == "" {
+		return generateName(def)
+	}
+
 	return def, nil
 }
 
+// generateName returns the definition named with a deterministic human friendly name derived from its definition hash.
+func generateName(def cluster.Definition) (cluster.Definition, error) {
+	def.Name = p2p.HashName(def.DefinitionHash)
+
+	return def.SetDefinitionHashes()
+}
+
 // newPeer returns a new peer ENR, generating a p2pkey in node directory.
 func newPeer(clusterDir string, peerIdx int) (enr.Record, error) {
 	dir := nodeDir(clusterDir, peerIdx)
@@ -558,7 +1117,7 @@ func newPeer(clusterDir string, peerIdx int) (enr.Record, error) {
 }
 
 // writeOutput writes the cluster generation output.
-func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int, keysToDisk bool) {
+func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int, threshold int, keysToDisk bool, depositData bool) {
 	var sb strings.Builder
 	_, _ = sb.WriteString("Created charon cluster:\n")
 	_, _ = sb.WriteString(fmt.Sprintf(" --split-existing-keys=%v\n", splitKeys))
@@ -567,68 +1126,230 @@ func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int,
 	_, _ = sb.WriteString(fmt.Sprintf("├─ node[0-%d]/\t\t\tDirectory for each node\n", numNodes-1))
 	_, _ = sb.WriteString("│  ├─ charon-enr-private-key\tCharon networking private key for node authentication\n")
 	_, _ = sb.WriteString("│  ├─ cluster-lock.json\t\tCluster lock defines the cluster lock file which is signed by all nodes\n")
-	_, _ = sb.WriteString("│  ├─ deposit-data.json\t\tDeposit data file is used to activate a Distributed Validator on DV Launchpad\n")
+	if depositData {
+		_, _ = sb.WriteString("│  ├─ deposit-data.json\t\tDeposit data file is used to activate a Distributed Validator on DV Launchpad\n")
+	}
 	if keysToDisk {
 		_, _ = sb.WriteString("│  ├─ validator_keys\t\tValidator keystores and password\n")
 		_, _ = sb.WriteString("│  │  ├─ keystore-*.json\tValidator private share key for duty signing\n")
 		_, _ = sb.WriteString("│  │  ├─ keystore-*.txt\t\tKeystore password files for keystore-*.json\n")
+		_, _ = sb.WriteString("│  │  ├─ slashing-protection.json\tEIP-3076 slashing protection baseline to import into the validator client\n")
+	}
+
+	signing, liveness := faultTolerance(numNodes, threshold)
+	_, _ = sb.WriteString("\n")
+	_, _ = sb.WriteString(fmt.Sprintf("This cluster of %d nodes with threshold %d tolerates %d offline operator(s) for signing and %d for liveness.\n",
+		numNodes, threshold, signing, liveness))
+	if liveness == 0 {
+		_, _ = sb.WriteString("Warning: all operators must be online for the cluster to perform its duties.\n")
 	}
 
 	_, _ = fmt.Fprint(out, sb.String())
 }
 
+// faultTolerance returns the number of offline operators the cluster tolerates for signing and liveness.
+// Signing requires threshold partial signatures, while liveness additionally requires a consensus quorum
+// of cluster.Threshold(numNodes) nodes to decide on duty data.
+func faultTolerance(numNodes, threshold int) (signing int, liveness int) {
+	signing = numNodes - threshold
+	if signing < 0 {
+		signing = 0
+	}
+
+	liveness = numNodes - cluster.Threshold(numNodes)
+	if signing < liveness {
+		liveness = signing
+	}
+
+	return signing, liveness
+}
+
 // nodeDir returns a node directory.
 func nodeDir(clusterDir string, i int) string {
 	return fmt.Sprintf("%s/node%d", clusterDir, i)
 }
 
-// validateDef returns an error if the provided cluster definition is invalid.
-func validateDef(ctx context.Context, insecureKeys bool, keymanagerAddrs []string, def cluster.Definition) error {
-	if def.NumValidators == 0 {
-		return errors.New("cannot create cluster with zero validators, specify at least one")
+// validateDef returns an error if the provided cluster definition is invalid, failing fast on the first problem found.
+func validateDef(ctx context.Context, insecureKeys bool, unsafeMinNodes int, keymanagerAddrs []string, def cluster.Definition) error {
+	if errs := defProblems(ctx, insecureKeys, unsafeMinNodes, keymanagerAddrs, def); len(errs) > 0 {
+		return errs[0]
 	}
 
-	if len(def.Operators) < minNodes {
-		return errors.New("insufficient number of nodes (min = 4)", z.Int("num_nodes", len(def.Operators)))
+	return nil
+}
+
+// validateDefAll returns a single error listing all problems found in the provided cluster definition,
+// allowing operators to fix all misconfigurations at once.
+func validateDefAll(ctx context.Context, insecureKeys bool, unsafeMinNodes int, keymanagerAddrs []string, def cluster.Definition) error {
+	errs := defProblems(ctx, insecureKeys, unsafeMinNodes, keymanagerAddrs, def)
+	if len(errs) == 0 {
+		return nil
+	} else if len(errs) == 1 {
+		return errs[0]
 	}
 
-	if len(keymanagerAddrs) > 0 && (len(keymanagerAddrs) != len(def.Operators)) {
-		return errors.New("insufficient no of keymanager addresses", z.Int("expected", len(def.Operators)), z.Int("got", len(keymanagerAddrs)))
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return errors.New("invalid cluster definition: "+strings.Join(msgs, "; "), z.Int("problems", len(errs)))
+}
+
+// validateName returns an error if the cluster name is too long, has surrounding whitespace or contains control characters.
+func validateName(name string) error {
+	if utf8.RuneCountInString(name) > maxNameLen {
+		return errors.New("name too long", z.Int("max", maxNameLen), z.Int("length", utf8.RuneCountInString(name)))
+	} else if strings.TrimSpace(name) != name {
+		return errors.New("name has leading or trailing whitespace", z.Str("name", name))
+	}
+
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return errors.New("name contains invalid characters", z.Str("name", fmt.Sprintf("%q", name)))
+		}
+	}
+
+	return nil
+}
+
+// defProblems returns all problems found in the provided cluster definition.
+// A non-zero unsafeMinNodes relaxes the minimum number of nodes (but not below 2) on non-mainnet networks,
+// it requires insecure keys and a threshold of at least 2.
+func defProblems(ctx context.Context, insecureKeys bool, unsafeMinNodes int, keymanagerAddrs []string, def cluster.Definition) []error {
+	var errs []error
+
+	if def.NumValidators == 0 {
+		errs = append(errs, errors.New("cannot create cluster with zero validators, specify at least one"))
 	}
 
 	network, err := eth2util.ForkVersionToNetwork(def.ForkVersion)
 	if err != nil {
-		return err
+		errs = append(errs, err)
+	}
+
+	nodesFloor := minNodes
+	if unsafeMinNodes != 0 {
+		if unsafeMinNodes < unsafeMinNodesFloor || unsafeMinNodes > minNodes {
+			errs = append(errs, errors.New("invalid unsafe minimum number of nodes",
+				z.Int("unsafe_min_nodes", unsafeMinNodes), z.Int("floor", unsafeMinNodesFloor)))
+		} else if isMainNetwork(network) {
+			errs = append(errs, errors.New("unsafe minimum number of nodes not supported on mainnet"))
+		} else if !insecureKeys {
+			errs = append(errs, errors.New("unsafe minimum number of nodes requires --insecure-keys"))
+		} else if def.Threshold < unsafeMinNodesFloor {
+			errs = append(errs, errors.New("unsafe minimum number of nodes requires a threshold of at least 2",
+				z.Int("threshold", def.Threshold)))
+		} else {
+			nodesFloor = unsafeMinNodes
+			log.Warn(ctx, "Unsafe minimum number of nodes configured. ONLY DO THIS DURING TESTING", nil,
+				z.Int("unsafe_min_nodes", unsafeMinNodes))
+		}
+	}
+
+	if len(def.Operators) < nodesFloor {
+		errs = append(errs, errors.New("insufficient number of nodes",
+			z.Int("min", nodesFloor), z.Int("num_nodes", len(def.Operators))))
+	}
+
+	if err := cluster.VerifyVoting(def.Operators, def.Threshold); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(keymanagerAddrs) > 0 && (len(keymanagerAddrs) != len(def.Operators)) {
+		errs = append(errs, errors.New("insufficient no of keymanager addresses", z.Int("expected", len(def.Operators)), z.Int("got", len(keymanagerAddrs))))
 	}
 
 	if insecureKeys && isMainNetwork(network) {
-		return errors.New("insecure keys not supported on mainnet")
+		errs = append(errs, errors.New("insecure keys not supported on mainnet"))
 	} else if insecureKeys {
 		log.Warn(ctx, "Insecure keystores configured. ONLY DO THIS DURING TESTING", nil)
 	}
 
 	if def.Name == "" {
-		return errors.New("name not provided")
+		errs = append(errs, errors.New("name not provided"))
+	} else if err := validateName(def.Name); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, warning := range checkENRSeqs(def.Operators) {
+		log.Warn(ctx, "Potentially stale operator ENR", nil, z.Str("reason", warning))
+	}
+
+	if network == "" {
+		return errs // Network dependent checks require a known network.
 	}
 
 	if !eth2util.ValidNetwork(network) {
-		return errors.New("unsupported network", z.Str("network", network))
+		errs = append(errs, errors.New("unsupported network", z.Str("network", network)))
+	}
+
+	if err := validateWithdrawalAddrs(def.WithdrawalAddresses(), network); err != nil {
+		errs = append(errs, err)
 	}
 
-	return validateWithdrawalAddrs(def.WithdrawalAddresses(), network)
+	return errs
 }
 
-// aggSign returns a bls aggregate signatures of the message signed by all the shares.
-func aggSign(secrets [][]tblsv2.PrivateKey, message []byte) ([]byte, error) {
-	var sigs []tblsv2.Signature
-	for _, shares := range secrets {
-		for _, share := range shares {
-			sig, err := tblsv2.Sign(share, message)
-			if err != nil {
-				return nil, err
+// checkENRSeqs returns warnings for operator ENRs that are potentially stale.
+// Operators with the same identity but conflicting sequence numbers are flagged,
+// as well as zero sequence numbers if other operators use positive sequence numbers.
+func checkENRSeqs(ops []cluster.Operator) []string {
+	var (
+		records  []enr.Record
+		positive bool
+	)
+	for _, op := range ops {
+		if op.ENR == "" {
+			continue // Operators are not populated yet when creating a new definition.
+		}
+
+		record, err := enr.Parse(op.ENR)
+		if err != nil {
+			continue // Invalid ENRs are detected when verifying the definition.
+		}
+
+		records = append(records, record)
+		positive = positive || record.Seq() > 0
+	}
+
+	var (
+		warnings []string
+		seqs     = make(map[string]int)
+	)
+	for i, record := range records {
+		pubkey := fmt.Sprintf("%#x", record.PubKey.SerializeCompressed())
+		if seq, ok := seqs[pubkey]; ok {
+			if seq != record.Seq() {
+				warnings = append(warnings, fmt.Sprintf("operator %d ENR sequence %d conflicts with sequence %d of the same node", i, record.Seq(), seq))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("operator %d ENR duplicated", i))
 			}
-			sigs = append(sigs, sig)
+
+			continue
+		}
+		seqs[pubkey] = record.Seq()
+
+		if positive && record.Seq() == 0 {
+			warnings = append(warnings, fmt.Sprintf("operator %d ENR has zero sequence number", i))
+		}
+	}
+
+	return warnings
+}
+
+// aggSign returns a single global bls aggregate signature of the message signed by all the signers of all sets,
+// e.g. the key shares of all validators. This is the grouping of the cluster lock signature aggregate, verified
+// against all public shares of all validators. Since bls aggregation is commutative, the order of the sets and
+// of the signers within each set doesn't affect the result.
+func aggSign(signerSets [][]signer, message []byte) ([]byte, error) {
+	var sigs []tblsv2.Signature
+	for _, signers := range signerSets {
+		setSigs, err := signAll(signers, message)
+		if err != nil {
+			return nil, err
 		}
+		sigs = append(sigs, setSigs...)
 	}
 
 	aggSig, err := tblsv2.Aggregate(sigs)
@@ -639,9 +1360,126 @@ func aggSign(secrets [][]tblsv2.PrivateKey, message []byte) ([]byte, error) {
 	return aggSig[:], nil
 }
 
+// aggSignPerSet returns one bls aggregate signature of the message per signer set, e.g. one per validator
+// if each set contains the key shares of a validator. Each aggregate verifies against the public keys of its set only.
+func aggSignPerSet(signerSets [][]signer, message []byte) ([][]byte, error) {
+	var resp [][]byte
+	for _, signers := range signerSets {
+		sigs, err := signAll(signers, message)
+		if err != nil {
+			return nil, err
+		}
+
+		aggSig, err := tblsv2.Aggregate(sigs)
+		if err != nil {
+			return nil, errors.Wrap(err, "aggregate signatures")
+		}
+
+		resp = append(resp, aggSig[:])
+	}
+
+	return resp, nil
+}
+
+// signAll returns the signatures of the message by each signer.
+func signAll(signers []signer, message []byte) ([]tblsv2.Signature, error) {
+	var sigs []tblsv2.Signature
+	for _, signer := range signers {
+		sig, err := signer.Sign(message)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	return sigs, nil
+}
+
+// verifyAggSign returns an error if the lock aggregate signature isn't valid for the
+// lock hash and the public shares of all the validators.
+func verifyAggSign(lock cluster.Lock) error {
+	sig, err := tblsconv2.SignatureFromBytes(lock.SignatureAggregate)
+	if err != nil {
+		return err
+	}
+
+	var pubkeys []tblsv2.PublicKey
+	for _, val := range lock.Validators {
+		for _, share := range val.PubShares {
+			pubkey, err := tblsconv2.PubkeyFromBytes(share)
+			if err != nil {
+				return err
+			}
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+
+	if err := tblsv2.VerifyAggregate(pubkeys, sig, lock.LockHash); err != nil {
+		return errors.Wrap(err, "verify lock signature aggregate")
+	}
+
+	return nil
+}
+
+// verifyValidatorAggSigs returns an error identifying the first validator whose per validator aggregate signature
+// of the lock hash isn't valid for its public shares. The signer sets must be the key shares of each validator.
+func verifyValidatorAggSigs(lock cluster.Lock, signerSets [][]signer) error {
+	aggSigs, err := aggSignPerSet(signerSets, lock.LockHash)
+	if err != nil {
+		return err
+	} else if len(aggSigs) != len(lock.Validators) {
+		return errors.New("signer sets don't match validators")
+	}
+
+	for i, val := range lock.Validators {
+		sig, err := tblsconv2.SignatureFromBytes(aggSigs[i])
+		if err != nil {
+			return err
+		}
+
+		var pubshares []tblsv2.PublicKey
+		for _, share := range val.PubShares {
+			pubshare, err := tblsconv2.PubkeyFromBytes(share)
+			if err != nil {
+				return err
+			}
+			pubshares = append(pubshares, pubshare)
+		}
+
+		if err := tblsv2.VerifyAggregate(pubshares, sig, lock.LockHash); err != nil {
+			return errors.Wrap(err, "verify lock signature aggregate", z.Int("validator_index", i))
+		}
+	}
+
+	return nil
+}
+
 // loadDefinition returns the cluster definition from disk or an HTTP URL. It also verifies signatures
-// and hashes before returning the definition.
-func loadDefinition(ctx context.Context, defFile string) (cluster.Definition, error) {
+// and hashes before returning the definition. If multiple mirror sources are provided, each is tried
+// in order until one succeeds.
+func loadDefinition(ctx context.Context, defFiles []string) (cluster.Definition, error) {
+	if len(defFiles) == 1 {
+		return loadDefinitionSource(ctx, defFiles[0])
+	}
+
+	var errs []string
+	for _, source := range defFiles {
+		def, err := loadDefinitionSource(ctx, source)
+		if err != nil {
+			log.Warn(ctx, "Failed loading cluster definition, trying next", err, z.Str("source", source))
+			errs = append(errs, fmt.Sprintf("%s: %v", source, err))
+
+			continue
+		}
+
+		return def, nil
+	}
+
+	return cluster.Definition{}, errors.New("failed loading definition from all sources", z.Any("errors", errs))
+}
+
+// loadDefinitionSource returns the verified cluster definition from a single disk path or HTTP URL.
+func loadDefinitionSource(ctx context.Context, defFile string) (cluster.Definition, error) {
 	var def cluster.Definition
 
 	// Fetch definition from network if URI is provided
@@ -660,6 +1498,10 @@ func loadDefinition(ctx context.Context, defFile string) (cluster.Definition, er
 			return cluster.Definition{}, errors.Wrap(err, "read definition")
 		}
 
+		if err = cluster.VerifyDefinitionJSON(buf); err != nil {
+			return cluster.Definition{}, errors.Wrap(err, "invalid definition file", z.Str("path", defFile))
+		}
+
 		if err = json.Unmarshal(buf, &def); err != nil {
 			return cluster.Definition{}, errors.Wrap(err, "unmarshal definition")
 		}
@@ -710,10 +1552,21 @@ func randomHex64() (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
-// writeLockToAPI posts the lock file to obol-api.
-func writeLockToAPI(ctx context.Context, publishAddr string, lock cluster.Lock) error {
+// writeLockToAPI posts the lock file to obol-api. Only the lock hashes and minimal metadata are posted
+// if the publish mode is hash-only.
+func writeLockToAPI(ctx context.Context, publishAddr string, publishMode string, lock cluster.Lock) error {
 	cl := obolapi.New(publishAddr)
 
+	if publishMode == publishModeHashOnly {
+		if err := cl.PublishLockHash(ctx, lock); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Published lock hash", z.Str("addr", publishAddr))
+
+		return nil
+	}
+
 	if err := cl.PublishLock(ctx, lock); err != nil {
 		return err
 	}
@@ -723,27 +1576,72 @@ func writeLockToAPI(ctx context.Context, publishAddr string, lock cluster.Lock)
 	return nil
 }
 
-// validateAddresses checks if we have sufficient addresses. It also fills addresses slices if only one is provided.
+// validateAddresses checks that either a single address or exactly one address per validator is provided.
+// It returns new slices with a single address expanded to all validators, the provided slices are not modified.
 func validateAddresses(numVals int, feeRecipientAddrs []string, withdrawalAddrs []string) ([]string, []string, error) {
 	if len(feeRecipientAddrs) != numVals && len(feeRecipientAddrs) != 1 {
-		return nil, nil, errors.New("insufficient fee recipient addresses")
+		return nil, nil, errors.New("invalid number of fee recipient addresses, expected a single address or exactly one per validator",
+			z.Int("addresses", len(feeRecipientAddrs)), z.Int("validators", numVals))
 	}
 
 	if len(withdrawalAddrs) != numVals && len(withdrawalAddrs) != 1 {
-		return nil, nil, errors.New("insufficient withdrawal addresses")
+		return nil, nil, errors.New("invalid number of withdrawal addresses, expected a single address or exactly one per validator",
+			z.Int("addresses", len(withdrawalAddrs)), z.Int("validators", numVals))
 	}
 
-	if len(feeRecipientAddrs) == 1 {
-		for i := 1; i < numVals; i++ {
-			feeRecipientAddrs = append(feeRecipientAddrs, feeRecipientAddrs[0])
+	return expandAddresses(numVals, feeRecipientAddrs), expandAddresses(numVals, withdrawalAddrs), nil
+}
+
+// validatorGraffiti returns the custom graffiti of each validator, repeating the graffiti if only one is provided.
+// It returns nil if no graffiti is provided.
+func validatorGraffiti(graffiti []string, numVals int) ([]string, error) {
+	if len(graffiti) == 0 {
+		return nil, nil
+	} else if len(graffiti) != numVals && len(graffiti) != 1 {
+		return nil, errors.New("invalid number of graffiti, expected a single graffiti or exactly one per validator",
+			z.Int("graffiti", len(graffiti)), z.Int("validators", numVals))
+	}
+
+	for _, g := range graffiti {
+		if len(g) > maxGraffitiLen {
+			return nil, errors.New("graffiti too long", z.Int("max", maxGraffitiLen), z.Int("length", len(g)), z.Str("graffiti", g))
 		}
 	}
 
-	if len(withdrawalAddrs) == 1 {
-		for i := 1; i < numVals; i++ {
-			withdrawalAddrs = append(withdrawalAddrs, withdrawalAddrs[0])
+	return expandAddresses(numVals, graffiti), nil
+}
+
+// validateBuilderRelays returns an error if the builder relays are not unique HTTP/HTTPS URLs (max 256 bytes).
+func validateBuilderRelays(relays []string) error {
+	if len(relays) > maxBuilderRelays {
+		return errors.New("too many builder relays", z.Int("max", maxBuilderRelays), z.Int("relays", len(relays)))
+	}
+
+	dedup := make(map[string]bool)
+	for _, relay := range relays {
+		if !validURI(relay) {
+			return errors.New("invalid builder relay URL", z.Str("relay", relay))
+		} else if len(relay) > maxBuilderRelayLen {
+			return errors.New("builder relay URL too long", z.Int("max", maxBuilderRelayLen), z.Str("relay", relay))
+		} else if dedup[relay] {
+			return errors.New("duplicate builder relay URL", z.Str("relay", relay))
 		}
+		dedup[relay] = true
+	}
+
+	return nil
+}
+
+// expandAddresses returns a new slice of numVals addresses, repeating the address if only one is provided.
+func expandAddresses(numVals int, addrs []string) []string {
+	resp := make([]string, 0, numVals)
+	if len(addrs) != 1 {
+		return append(resp, addrs...)
+	}
+
+	for i := 0; i < numVals; i++ {
+		resp = append(resp, addrs[0])
 	}
 
-	return feeRecipientAddrs, withdrawalAddrs, nil
+	return resp
 }
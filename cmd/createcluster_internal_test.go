@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
+	mrand "math/rand"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -16,12 +18,20 @@ import (
 	"strings"
 	"testing"
 
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"go.uber.org/zap/zaptest"
 
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/eth2util/keystore"
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
@@ -37,7 +47,7 @@ func TestMain(m *testing.M) {
 
 func TestCreateCluster(t *testing.T) {
 	defPath := "../cluster/examples/cluster-definition-002.json"
-	def, err := loadDefinition(context.Background(), defPath)
+	def, err := loadDefinition(context.Background(), []string{defPath})
 	require.NoError(t, err)
 
 	// Serve definition over network
@@ -101,13 +111,13 @@ func TestCreateCluster(t *testing.T) {
 		{
 			Name: "solo flow definition from disk",
 			Config: clusterConfig{
-				DefFile: defPath,
+				DefFiles: []string{defPath},
 			},
 		},
 		{
 			Name: "solo flow definition from network",
 			Config: clusterConfig{
-				DefFile: srv.URL,
+				DefFiles: []string{srv.URL},
 			},
 		},
 	}
@@ -182,7 +192,7 @@ func testCreateCluster(t *testing.T, conf clusterConfig, def cluster.Definition)
 
 		require.Equal(t, lock.Definition.NumValidators, len(vals))
 
-		if conf.DefFile != "" {
+		if len(conf.DefFiles) > 0 {
 			// Config hash and creator should remain the same
 			require.Equal(t, def.ConfigHash, lock.ConfigHash)
 			require.Equal(t, def.Creator, lock.Creator)
@@ -219,20 +229,20 @@ func TestValidateDef(t *testing.T) {
 		require.NoError(t, err)
 		def.ForkVersion = gnosis
 
-		err = validateDef(ctx, false, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, false, 0, conf.KeymanagerAddrs, def)
 		require.Error(t, err, "zero address")
 	})
 
 	t.Run("fork versions", func(t *testing.T) {
 		def := definition
-		err = validateDef(ctx, false, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, false, 0, conf.KeymanagerAddrs, def)
 		require.NoError(t, err)
 
 		mainnet, err := hex.DecodeString(strings.TrimPrefix(eth2util.Mainnet.ForkVersionHex, "0x"))
 		require.NoError(t, err)
 		def.ForkVersion = mainnet
 
-		err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
 		require.Error(t, err, "zero address")
 	})
 
@@ -240,36 +250,234 @@ func TestValidateDef(t *testing.T) {
 		conf := conf
 		conf.KeymanagerAddrs = []string{"127.0.0.1:1234"}
 
-		err = validateDef(ctx, true, conf.KeymanagerAddrs, definition)
+		err = validateDef(ctx, true, 0, conf.KeymanagerAddrs, definition)
 		require.Error(t, err)
 	})
 
 	t.Run("insecure keys", func(t *testing.T) {
 		conf := conf
-		err = validateDef(ctx, true, conf.KeymanagerAddrs, definition) // Validate with insecure keys set to true
+		err = validateDef(ctx, true, 0, conf.KeymanagerAddrs, definition) // Validate with insecure keys set to true
 		require.NoError(t, err)
 	})
 
 	t.Run("insufficient number of nodes", func(t *testing.T) {
 		def := definition
 		def.Operators = nil
-		err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
 		require.ErrorContains(t, err, "insufficient number of nodes")
 	})
 
+	t.Run("unsafe min nodes", func(t *testing.T) {
+		def := definition
+		def.Operators = def.Operators[:3]
+
+		err := validateDef(ctx, true, 0, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "insufficient number of nodes")
+
+		require.NoError(t, validateDef(ctx, true, 3, conf.KeymanagerAddrs, def))
+
+		err = validateDef(ctx, false, 3, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "unsafe minimum number of nodes requires --insecure-keys")
+
+		for _, unsafeMinNodes := range []int{1, 5} {
+			err = validateDef(ctx, true, unsafeMinNodes, conf.KeymanagerAddrs, def)
+			require.ErrorContains(t, err, "invalid unsafe minimum number of nodes")
+		}
+
+		lowThreshold := def
+		lowThreshold.Operators = def.Operators[:2]
+		lowThreshold.Threshold = 1
+		err = validateDef(ctx, true, 2, conf.KeymanagerAddrs, lowThreshold)
+		require.ErrorContains(t, err, "unsafe minimum number of nodes requires a threshold of at least 2")
+
+		mainnet, err := hex.DecodeString(strings.TrimPrefix(eth2util.Mainnet.ForkVersionHex, "0x"))
+		require.NoError(t, err)
+		def.ForkVersion = mainnet
+
+		err = validateDef(ctx, true, 3, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "unsafe minimum number of nodes not supported on mainnet")
+	})
+
 	t.Run("name not provided", func(t *testing.T) {
 		def := definition
 		def.Name = ""
-		err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
 		require.ErrorContains(t, err, "name not provided")
 	})
 
+	t.Run("name too long", func(t *testing.T) {
+		def := definition
+		def.Name = strings.Repeat("a", maxNameLen+1)
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "name too long")
+	})
+
+	t.Run("name with control characters", func(t *testing.T) {
+		def := definition
+		def.Name = "test\ncluster"
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "name contains invalid characters")
+	})
+
+	t.Run("valid name", func(t *testing.T) {
+		def := definition
+		def.Name = "Obol Cluster-1 ✓"
+		require.NoError(t, validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def))
+	})
+
+	t.Run("name trimmed", func(t *testing.T) {
+		conf := conf
+		conf.Name = "  test  "
+		def, err := newDefFromConfig(ctx, conf)
+		require.NoError(t, err)
+		require.Equal(t, "test", def.Name)
+		require.NoError(t, validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def))
+	})
+
+	t.Run("custom entropy", func(t *testing.T) {
+		newDef := func() cluster.Definition {
+			conf := conf
+			conf.Entropy = mrand.New(mrand.NewSource(1)) //nolint:gosec // Deterministic reader for testing.
+			def, err := newDefFromConfig(ctx, conf)
+			require.NoError(t, err)
+
+			return def
+		}
+
+		def1, def2 := newDef(), newDef()
+		require.Equal(t, def1.UUID, def2.UUID)
+		require.Equal(t, def1.ValidatorAddresses, def2.ValidatorAddresses)
+
+		// The default crypto/rand entropy source is not reproducible.
+		random, err := newDefFromConfig(ctx, conf)
+		require.NoError(t, err)
+		require.NotEqual(t, def1.UUID, random.UUID)
+	})
+
+	t.Run("generated name", func(t *testing.T) {
+		conf := conf
+		conf.Name = ""
+		def, err := newDefFromConfig(ctx, conf)
+		require.NoError(t, err)
+		require.NotEmpty(t, def.Name)
+		require.NoError(t, validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def))
+		require.NoError(t, def.VerifyHashes())
+
+		// The name is derived from the unnamed definition, so regenerating it is stable.
+		unnamed := def
+		unnamed.Name = ""
+		unnamed, err = unnamed.SetDefinitionHashes()
+		require.NoError(t, err)
+		renamed, err := generateName(unnamed)
+		require.NoError(t, err)
+		require.Equal(t, def.Name, renamed.Name)
+		require.Equal(t, def.DefinitionHash, renamed.DefinitionHash)
+	})
+
 	t.Run("zero validators provided", func(t *testing.T) {
 		def := definition
 		def.NumValidators = 0
-		err = validateDef(ctx, conf.InsecureKeys, conf.KeymanagerAddrs, def)
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
 		require.ErrorContains(t, err, "cannot create cluster with zero validators, specify at least one")
 	})
+
+	t.Run("all problems", func(t *testing.T) {
+		def := definition
+		def.NumValidators = 0
+		def.Operators = nil
+		def.Name = ""
+
+		err = validateDef(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "cannot create cluster with zero validators")
+		require.NotContains(t, err.Error(), "insufficient number of nodes")
+
+		err = validateDefAll(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, def)
+		require.ErrorContains(t, err, "cannot create cluster with zero validators")
+		require.ErrorContains(t, err, "insufficient number of nodes")
+		require.ErrorContains(t, err, "name not provided")
+
+		require.NoError(t, validateDefAll(ctx, conf.InsecureKeys, 0, conf.KeymanagerAddrs, definition))
+	})
+}
+
+func TestLoadDefinitionMirrors(t *testing.T) {
+	defPath := "../cluster/examples/cluster-definition-002.json"
+	defBytes, err := os.ReadFile(defPath)
+	require.NoError(t, err)
+
+	var corrupt, good int
+	corruptSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		corrupt++
+		_, _ = w.Write(bytes.Replace(defBytes, []byte(`"threshold": 3`), []byte(`"threshold": 2`), 1))
+	}))
+	defer corruptSrv.Close()
+
+	goodSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		good++
+		_, _ = w.Write(defBytes)
+	}))
+	defer goodSrv.Close()
+
+	expect, err := loadDefinition(context.Background(), []string{defPath})
+	require.NoError(t, err)
+
+	def, err := loadDefinition(context.Background(), []string{corruptSrv.URL, goodSrv.URL})
+	require.NoError(t, err)
+	require.Equal(t, expect.DefinitionHash, def.DefinitionHash)
+	require.Equal(t, 1, corrupt)
+	require.Equal(t, 1, good)
+
+	_, err = loadDefinition(context.Background(), []string{corruptSrv.URL, corruptSrv.URL})
+	require.ErrorContains(t, err, "failed loading definition from all sources")
+}
+
+func TestCheckENRSeqs(t *testing.T) {
+	key1, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	key2, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	newENR := func(key *k1.PrivateKey, seq int) string {
+		r, err := enr.NewWithSeq(key, seq)
+		require.NoError(t, err)
+
+		return r.String()
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ops := []cluster.Operator{{ENR: newENR(key1, 0)}, {ENR: newENR(key2, 0)}, {}}
+		require.Empty(t, checkENRSeqs(ops))
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		ops := []cluster.Operator{
+			{ENR: newENR(key1, 2)},
+			{ENR: newENR(key2, 0)}, // Zero seq
+			{ENR: newENR(key1, 1)}, // Conflicting seq
+		}
+
+		var buf zaptest.Buffer
+		log.InitLogfmtForT(t, &buf)
+
+		def := cluster.Definition{
+			Name:          "test",
+			NumValidators: 1,
+			Operators:     append(ops, cluster.Operator{}),
+		}
+		def.ForkVersion, err = hex.DecodeString(strings.TrimPrefix(eth2util.Goerli.ForkVersionHex, "0x"))
+		require.NoError(t, err)
+
+		_ = validateDef(context.Background(), false, 0, nil, def)
+
+		warnings := checkENRSeqs(ops)
+		require.Len(t, warnings, 2)
+		require.Contains(t, warnings[0], "operator 1 ENR has zero sequence number")
+		require.Contains(t, warnings[1], "operator 2 ENR sequence 1 conflicts with sequence 2")
+
+		require.Contains(t, buf.String(), "Potentially stale operator ENR")
+		require.Contains(t, buf.String(), "zero sequence number")
+		require.Contains(t, buf.String(), "conflicts with sequence")
+	})
 }
 
 func TestMultipleAddresses(t *testing.T) {
@@ -279,7 +487,7 @@ func TestMultipleAddresses(t *testing.T) {
 			FeeRecipientAddrs: []string{},
 			WithdrawalAddrs:   []string{},
 		})
-		require.ErrorContains(t, err, "insufficient fee recipient addresses")
+		require.ErrorContains(t, err, "invalid number of fee recipient addresses, expected a single address or exactly one per validator")
 	})
 
 	t.Run("insufficient addresses from remote URL", func(t *testing.T) {
@@ -299,11 +507,75 @@ func TestMultipleAddresses(t *testing.T) {
 		}))
 		defer srv.Close()
 
-		err := runCreateCluster(context.Background(), io.Discard, clusterConfig{DefFile: srv.URL, NumNodes: minNodes})
+		err := runCreateCluster(context.Background(), io.Discard, clusterConfig{DefFiles: []string{srv.URL}, NumNodes: minNodes})
 		require.ErrorContains(t, err, "num_validators not matching validators length")
 	})
 }
 
+func TestWithdrawalCredentialTypes(t *testing.T) {
+	conf := clusterConfig{
+		Name:                t.Name(),
+		ClusterDir:          t.TempDir(),
+		NumNodes:            minNodes,
+		NumDVs:              3,
+		Network:             defaultNetwork,
+		WithdrawalAddrs:     []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs:   []string{defaultWithdrawalAddr},
+		WithdrawalCredTypes: []string{"0x01", "0x02", "0x01"},
+		InsecureKeys:        true,
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+
+	// Deposit data is sorted by pubkey, so map pubkeys to the configured prefix by validator order.
+	prefixes := make(map[string]byte)
+	for i, prefix := range []byte{0x01, 0x02, 0x01} {
+		prefixes[hex.EncodeToString(lock.Validators[i].PubKey)] = prefix
+	}
+
+	b, err := os.ReadFile(path.Join(nodeDir(conf.ClusterDir, 0), "deposit-data.json"))
+	require.NoError(t, err)
+
+	var depositDatas []struct {
+		PubKey                string `json:"pubkey"`
+		WithdrawalCredentials string `json:"withdrawal_credentials"`
+		Signature             string `json:"signature"`
+	}
+	require.NoError(t, json.Unmarshal(b, &depositDatas))
+	require.Len(t, depositDatas, 3)
+
+	for _, dd := range depositDatas {
+		prefix, ok := prefixes[dd.PubKey]
+		require.True(t, ok)
+		require.True(t, strings.HasPrefix(dd.WithdrawalCredentials, fmt.Sprintf("%02x", prefix)))
+
+		pubkey, err := hex.DecodeString(dd.PubKey)
+		require.NoError(t, err)
+		sig, err := hex.DecodeString(dd.Signature)
+		require.NoError(t, err)
+
+		msg, err := deposit.NewMessageWithPrefix(eth2p0.BLSPubKey(pubkey), defaultWithdrawalAddr, prefix)
+		require.NoError(t, err)
+		require.Equal(t, dd.WithdrawalCredentials, hex.EncodeToString(msg.WithdrawalCredentials))
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, defaultNetwork)
+		require.NoError(t, err)
+		require.NoError(t, tblsv2.Verify(tblsv2.PublicKey(pubkey), sigRoot[:], tblsv2.Signature(sig)))
+	}
+
+	t.Run("invalid types", func(t *testing.T) {
+		_, err := withdrawalPrefixes([]string{"0x01", "0x02"}, 3)
+		require.ErrorContains(t, err, "insufficient withdrawal credential types")
+
+		_, err = withdrawalPrefixes([]string{"0x00"}, 3)
+		require.ErrorContains(t, err, "unsupported withdrawal credential type")
+	})
+}
+
 // TestKeymanager tests keymanager support by letting create cluster command split a single secret and then receiving those keyshares using test
 // keymanager servers. These shares are then combined to create the combined share which is then compared to the original secret that was split.
 func TestKeymanager(t *testing.T) {
@@ -389,6 +661,39 @@ func TestKeymanager(t *testing.T) {
 	})
 }
 
+// TestKeymanagerMetrics tests that keymanager import counters are incremented for both successful and failed imports.
+func TestKeymanagerMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	okSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okSrv.Close()
+
+	failSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failSrv.Close()
+
+	addrs := []string{okSrv.URL, failSrv.URL}
+
+	secret, err := tblsv2.GenerateSecretKey()
+	require.NoError(t, err)
+	shareSets := [][]tblsv2.PrivateKey{{secret, secret}}
+
+	count := func(counter *prometheus.CounterVec, node int) float64 {
+		return promtestutil.ToFloat64(counter.WithLabelValues(fmt.Sprintf("node%d", node), addrs[node]))
+	}
+
+	err = writeKeysToKeymanager(ctx, addrs, len(addrs), shareSets, nil)
+	require.Error(t, err)
+
+	require.EqualValues(t, 1, count(keymanagerImportCounter, 0))
+	require.EqualValues(t, 0, count(keymanagerImportFailedCounter, 0))
+	require.EqualValues(t, 1, count(keymanagerImportCounter, 1))
+	require.EqualValues(t, 1, count(keymanagerImportFailedCounter, 1))
+}
+
 // TestPublish tests support for uploading the cluster lockfile to obol-api.
 func TestPublish(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -430,6 +735,248 @@ func TestPublish(t *testing.T) {
 	})
 }
 
+func TestWriteLockVerifiesAggSig(t *testing.T) {
+	const numNodes = 4
+
+	lock, _, shares := cluster.NewForT(t, 2, 3, numNodes, 0)
+	lock.SignatureAggregate = nil
+
+	dir := t.TempDir()
+	for i := 0; i < numNodes; i++ {
+		require.NoError(t, os.MkdirAll(nodeDir(dir, i), 0o755))
+	}
+
+	t.Run("valid shares", func(t *testing.T) {
+		dir := t.TempDir()
+		for i := 0; i < numNodes; i++ {
+			require.NoError(t, os.MkdirAll(nodeDir(dir, i), 0o755))
+		}
+
+		require.NoError(t, writeLock(lock, dir, numNodes, shares, ""))
+		require.FileExists(t, path.Join(nodeDir(dir, 0), "cluster-lock.json"))
+	})
+
+	t.Run("bad share", func(t *testing.T) {
+		badShares := make([][]tblsv2.PrivateKey, len(shares))
+		for i := range shares {
+			badShares[i] = append([]tblsv2.PrivateKey(nil), shares[i]...)
+		}
+
+		var err error
+		badShares[1][2], err = tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+
+		err = writeLock(lock, dir, numNodes, badShares, "")
+		require.ErrorContains(t, err, "verify lock signature aggregate")
+		require.Error(t, verifyValidatorAggSigs(lock, [][]signer{localSigners(shares[0]), localSigners(badShares[1])}))
+		require.NoError(t, verifyValidatorAggSigs(lock, [][]signer{localSigners(shares[0]), localSigners(shares[1])}))
+
+		for i := 0; i < numNodes; i++ {
+			require.NoFileExists(t, path.Join(nodeDir(dir, i), "cluster-lock.json"))
+		}
+	})
+}
+
+func TestCustomSigner(t *testing.T) {
+	const numDVs = 2
+
+	var (
+		signers []signer
+		stubs   []*stubSigner
+	)
+	for i := 0; i < numDVs; i++ {
+		secret, err := tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+
+		stub := &stubSigner{secret: secret}
+		stubs = append(stubs, stub)
+		signers = append(signers, stub)
+	}
+
+	prefixes, err := withdrawalPrefixes(nil, numDVs)
+	require.NoError(t, err)
+
+	datas, err := signDepositDatas(signers, []string{defaultWithdrawalAddr, defaultWithdrawalAddr}, prefixes, defaultNetwork)
+	require.NoError(t, err)
+	require.Len(t, datas, numDVs)
+
+	for i, data := range datas {
+		require.Equal(t, 1, stubs[i].calls)
+
+		pubkey, err := stubs[i].PublicKey()
+		require.NoError(t, err)
+		require.EqualValues(t, pubkey, data.PublicKey)
+
+		msg, err := deposit.NewMessage(data.PublicKey, defaultWithdrawalAddr)
+		require.NoError(t, err)
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, defaultNetwork)
+		require.NoError(t, err)
+
+		require.NoError(t, tblsv2.Verify(pubkey, sigRoot[:], tblsv2.Signature(data.Signature)))
+	}
+
+	msg := []byte("lock hash")
+	aggSig, err := aggSign([][]signer{signers}, msg)
+	require.NoError(t, err)
+
+	var pubkeys []tblsv2.PublicKey
+	for _, stub := range stubs {
+		require.Equal(t, 2, stub.calls)
+
+		pubkey, err := stub.PublicKey()
+		require.NoError(t, err)
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	sig, err := tblsconv2.SignatureFromBytes(aggSig)
+	require.NoError(t, err)
+	require.NoError(t, tblsv2.VerifyAggregate(pubkeys, sig, msg))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	const numDVs = 2
+
+	var secrets []tblsv2.PrivateKey
+	keys := make(map[string]tblsv2.PrivateKey)
+	for i := 0; i < numDVs; i++ {
+		secret, err := tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+		pubkey, err := tblsv2.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		secrets = append(secrets, secret)
+		keys[fmt.Sprintf("%#x", pubkey)] = secret
+	}
+
+	// Stub remote signer holding the secrets, signing with the first secret for unknown keys.
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		var req remoteSignReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "DEPOSIT", req.Type)
+
+		secret, ok := keys[path.Base(r.URL.Path)]
+		if !ok {
+			secret = secrets[0]
+		}
+
+		root, err := hex.DecodeString(strings.TrimPrefix(req.SigningRoot, "0x"))
+		require.NoError(t, err)
+		sig, err := tblsv2.Sign(secret, root)
+		require.NoError(t, err)
+
+		require.NoError(t, json.NewEncoder(w).Encode(remoteSignResp{Signature: fmt.Sprintf("%#x", sig)}))
+	}))
+	defer srv.Close()
+
+	signers, err := remoteSigners(srv.URL, secrets)
+	require.NoError(t, err)
+
+	prefixes, err := withdrawalPrefixes(nil, numDVs)
+	require.NoError(t, err)
+
+	datas, err := signDepositDatas(signers, []string{defaultWithdrawalAddr, defaultWithdrawalAddr}, prefixes, defaultNetwork)
+	require.NoError(t, err)
+	require.Equal(t, numDVs, calls)
+
+	// Marshalling verifies the deposit signatures against the validator public keys.
+	_, err = deposit.MarshalDepositData(datas, defaultNetwork)
+	require.NoError(t, err)
+
+	t.Run("unknown key", func(t *testing.T) {
+		other, err := tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+
+		signers, err := remoteSigners(srv.URL, []tblsv2.PrivateKey{other})
+		require.NoError(t, err)
+
+		_, err = signDepositDatas(signers, []string{defaultWithdrawalAddr}, prefixes[:1], defaultNetwork)
+		require.ErrorContains(t, err, "invalid remote signature")
+	})
+
+	t.Run("requires split keys", func(t *testing.T) {
+		conf := clusterConfig{
+			Name:                   t.Name(),
+			ClusterDir:             t.TempDir(),
+			NumNodes:               minNodes,
+			NumDVs:                 1,
+			Network:                defaultNetwork,
+			WithdrawalAddrs:        []string{defaultWithdrawalAddr},
+			FeeRecipientAddrs:      []string{defaultWithdrawalAddr},
+			InsecureKeys:           true,
+			DepositRemoteSignerURL: srv.URL,
+		}
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "requires --split-existing-keys")
+	})
+}
+
+// stubSigner is a stub signer that counts signing requests.
+type stubSigner struct {
+	secret tblsv2.PrivateKey
+	calls  int
+}
+
+func (s *stubSigner) PublicKey() (tblsv2.PublicKey, error) {
+	return tblsv2.SecretToPublicKey(s.secret)
+}
+
+func (s *stubSigner) Sign(msg []byte) (tblsv2.Signature, error) {
+	s.calls++
+
+	return tblsv2.Sign(s.secret, msg)
+}
+
+// TestPublishHashOnly tests that only the lock hashes are published to obol-api in hash-only mode.
+func TestPublishHashOnly(t *testing.T) {
+	result := make(chan []byte, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/lock/hash", r.URL.Path)
+
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		defer r.Body.Close()
+
+		w.WriteHeader(http.StatusOK)
+		result <- data
+	}))
+	defer srv.Close()
+
+	conf := clusterConfig{
+		Name:              t.Name(),
+		NumNodes:          minNodes,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		PublishAddr:       srv.URL,
+		Publish:           true,
+		PublishMode:       publishModeHashOnly,
+		ClusterDir:        t.TempDir(),
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	data := <-result
+	require.Contains(t, string(data), "lock_hash")
+	require.NotContains(t, string(data), "distributed_validators")
+	require.NotContains(t, string(data), "public_shares")
+	require.NotContains(t, string(data), "deposit_data")
+
+	t.Run("invalid mode", func(t *testing.T) {
+		conf := conf
+		conf.PublishMode = "invalid"
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "unsupported publish mode")
+	})
+}
+
 // mockKeymanagerReq is a mock keymanager request for use in tests.
 type mockKeymanagerReq struct {
 	Keystores []keystore.Keystore `json:"keystores"`
@@ -510,3 +1057,598 @@ func newObolAPIHandler(ctx context.Context, t *testing.T, result chan<- struct{}
 		}
 	})
 }
+
+func TestClusterConfigFile(t *testing.T) {
+	file := path.Join(t.TempDir(), "cluster.yaml")
+	err := os.WriteFile(file, []byte(`
+name: file-cluster
+nodes: 7
+num-validators: 3
+fee-recipient-addresses: ["0x000000000000000000000000000000000000dead", "0x000000000000000000000000000000000000beef"]
+insecure-keys: true
+`), 0o644)
+	require.NoError(t, err)
+
+	run := func(t *testing.T, args ...string) (clusterConfig, error) {
+		t.Helper()
+
+		var resp clusterConfig
+		cmd := newCreateClusterCmd(func(_ context.Context, _ io.Writer, conf clusterConfig) error {
+			resp = conf
+			return nil
+		})
+		cmd.SetArgs(args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+
+		return resp, cmd.ExecuteContext(context.Background())
+	}
+
+	conf, err := run(t, "--config-file", file, "--nodes", "5")
+	require.NoError(t, err)
+	require.Equal(t, "file-cluster", conf.Name)
+	require.Equal(t, 5, conf.NumNodes) // Flag overrides file.
+	require.Equal(t, 3, conf.NumDVs)
+	require.Equal(t, []string{"0x000000000000000000000000000000000000dead", "0x000000000000000000000000000000000000beef"}, conf.FeeRecipientAddrs)
+	require.True(t, conf.InsecureKeys)
+	require.Equal(t, defaultNetwork, conf.Network) // Defaults retained.
+
+	t.Run("unknown key", func(t *testing.T) {
+		file := path.Join(t.TempDir(), "cluster.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"name": "test", "unknown-flag": 1}`), 0o644))
+
+		_, err := run(t, "--config-file", file)
+		require.ErrorContains(t, err, "unknown config file key")
+	})
+}
+
+func TestNoDepositData(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            2,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		NoDepositData:     true,
+	}
+
+	var buf bytes.Buffer
+	err := runCreateCluster(context.Background(), &buf, conf)
+	require.NoError(t, err)
+	require.NotContains(t, buf.String(), "deposit-data.json")
+
+	for i := 0; i < conf.NumNodes; i++ {
+		require.NoFileExists(t, path.Join(nodeDir(conf.ClusterDir, i), "deposit-data.json"))
+
+		lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, i), "cluster-lock.json"), "")
+		require.NoError(t, err)
+		require.NoError(t, lock.VerifyHashes())
+		require.NoError(t, lock.VerifySignatures())
+		require.Len(t, lock.Validators, conf.NumDVs)
+
+		for _, val := range lock.Validators {
+			require.Empty(t, val.DepositData.Signature)
+		}
+	}
+}
+
+func TestValidateAddresses(t *testing.T) {
+	const (
+		addr1 = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+		addr2 = "0x08ef6a66a4f315aa250d2e748de0bfe5a6121096"
+	)
+
+	t.Run("single address expanded", func(t *testing.T) {
+		// Spare capacity would be overwritten by appending to the input.
+		feeRecipients := append(make([]string, 0, 4), addr1)
+		withdrawals := append(make([]string, 0, 4), addr2)
+		spare := feeRecipients[:4]
+		spare[1] = "unchanged"
+
+		fees, withdraws, err := validateAddresses(3, feeRecipients, withdrawals)
+		require.NoError(t, err)
+		require.Equal(t, []string{addr1, addr1, addr1}, fees)
+		require.Equal(t, []string{addr2, addr2, addr2}, withdraws)
+
+		require.Equal(t, []string{addr1}, feeRecipients)
+		require.Equal(t, []string{addr2}, withdrawals)
+		require.Equal(t, "unchanged", spare[1])
+	})
+
+	t.Run("one per validator", func(t *testing.T) {
+		fees, withdraws, err := validateAddresses(2, []string{addr1, addr2}, []string{addr2, addr1})
+		require.NoError(t, err)
+		require.Equal(t, []string{addr1, addr2}, fees)
+		require.Equal(t, []string{addr2, addr1}, withdraws)
+	})
+
+	t.Run("neither single nor one per validator", func(t *testing.T) {
+		_, _, err := validateAddresses(3, []string{addr1, addr2}, []string{addr1})
+		require.ErrorContains(t, err, "invalid number of fee recipient addresses, expected a single address or exactly one per validator")
+
+		_, _, err = validateAddresses(3, []string{addr1}, []string{addr1, addr2})
+		require.ErrorContains(t, err, "invalid number of withdrawal addresses, expected a single address or exactly one per validator")
+	})
+}
+
+func TestFaultTolerance(t *testing.T) {
+	tests := []struct {
+		nodes     int
+		threshold int
+		signing   int
+		liveness  int
+	}{
+		{nodes: 4, threshold: 3, signing: 1, liveness: 1},
+		{nodes: 7, threshold: 5, signing: 2, liveness: 2},
+		{nodes: 10, threshold: 7, signing: 3, liveness: 3},
+		{nodes: 4, threshold: 4, signing: 0, liveness: 0},
+		{nodes: 4, threshold: 2, signing: 2, liveness: 1}, // Consensus quorum limits liveness.
+		{nodes: 6, threshold: 3, signing: 3, liveness: 2},
+		{nodes: 1, threshold: 1, signing: 0, liveness: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_of_%d", tt.threshold, tt.nodes), func(t *testing.T) {
+			signing, liveness := faultTolerance(tt.nodes, tt.threshold)
+			require.Equal(t, tt.signing, signing)
+			require.Equal(t, tt.liveness, liveness)
+		})
+	}
+}
+
+func TestAggSignGrouping(t *testing.T) {
+	const (
+		numVals  = 3
+		numNodes = 4
+	)
+
+	lock, _, shares := cluster.NewForT(t, numVals, 3, numNodes, 0)
+	msg := lock.LockHash
+
+	var signerSets [][]signer
+	for _, valShares := range shares {
+		signerSets = append(signerSets, localSigners(valShares))
+	}
+
+	valPubshares := func(val cluster.DistValidator) []tblsv2.PublicKey {
+		var resp []tblsv2.PublicKey
+		for _, share := range val.PubShares {
+			pubshare, err := tblsconv2.PubkeyFromBytes(share)
+			require.NoError(t, err)
+			resp = append(resp, pubshare)
+		}
+
+		return resp
+	}
+
+	t.Run("global", func(t *testing.T) {
+		aggSig, err := aggSign(signerSets, msg)
+		require.NoError(t, err)
+
+		var pubshares []tblsv2.PublicKey
+		for _, val := range lock.Validators {
+			pubshares = append(pubshares, valPubshares(val)...)
+		}
+
+		sig, err := tblsconv2.SignatureFromBytes(aggSig)
+		require.NoError(t, err)
+		require.NoError(t, tblsv2.VerifyAggregate(pubshares, sig, msg))
+
+		// The order of the sets doesn't affect the global aggregate.
+		reversed := [][]signer{signerSets[2], signerSets[1], signerSets[0]}
+		reversedSig, err := aggSign(reversed, msg)
+		require.NoError(t, err)
+		require.Equal(t, aggSig, reversedSig)
+	})
+
+	t.Run("per validator", func(t *testing.T) {
+		aggSigs, err := aggSignPerSet(signerSets, msg)
+		require.NoError(t, err)
+		require.Len(t, aggSigs, numVals)
+
+		for i, val := range lock.Validators {
+			sig, err := tblsconv2.SignatureFromBytes(aggSigs[i])
+			require.NoError(t, err)
+			require.NoError(t, tblsv2.VerifyAggregate(valPubshares(val), sig, msg))
+
+			// Per validator aggregates don't verify against the other validators' public shares.
+			other := lock.Validators[(i+1)%numVals]
+			require.Error(t, tblsv2.VerifyAggregate(valPubshares(other), sig, msg))
+		}
+	})
+}
+
+func TestWriteHashes(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		WriteHashes:       true,
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+
+	defHash, err := os.ReadFile(path.Join(conf.ClusterDir, "definition-hash.txt"))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%#x\n", lock.DefinitionHash), string(defHash))
+
+	lockHash, err := os.ReadFile(path.Join(conf.ClusterDir, "lock-hash.txt"))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%#x\n", lock.LockHash), string(lockHash))
+}
+
+func TestCreateClusterPhaseDurations(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		PushgatewayAddr:   srv.URL,
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	require.Contains(t, string(body), "charon_operation_phase_duration_seconds")
+	for _, phase := range []string{
+		phaseDefinition,
+		phaseKeyGeneration,
+		phaseTSSSplit,
+		phaseDiskWrite,
+		phaseDepositSigning,
+		phaseLock,
+	} {
+		require.Contains(t, string(body), phase)
+	}
+}
+
+func TestPhaseTimer(t *testing.T) {
+	timer := newPhaseTimer()
+	timer.Mark("a")
+	timer.Mark("b")
+	timer.Mark("a")
+
+	durations := timer.Durations()
+	require.Len(t, durations, 2)
+	require.Positive(t, durations["a"])
+
+	fields := timer.Fields()
+	require.Len(t, fields, 2)
+}
+
+func TestSlashingProtectionBaseline(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            3,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+
+	_, root, err := eth2util.NetworkToGenesis(conf.Network)
+	require.NoError(t, err)
+
+	for i := 0; i < conf.NumNodes; i++ {
+		b, err := os.ReadFile(path.Join(nodeDir(conf.ClusterDir, i), "validator_keys", "slashing-protection.json"))
+		require.NoError(t, err)
+
+		var interchange eip3076.Interchange
+		require.NoError(t, json.Unmarshal(b, &interchange))
+		require.Equal(t, "5", interchange.Metadata.InterchangeFormatVersion)
+		require.Equal(t, root, interchange.Metadata.GenesisValidatorsRoot)
+		require.Len(t, interchange.Data, len(lock.Validators))
+
+		for j, val := range lock.Validators {
+			data := interchange.Data[j]
+			require.Equal(t, fmt.Sprintf("%#x", val.PubShares[i]), data.PubKey)
+			require.NotEqual(t, val.PublicKeyHex(), data.PubKey)
+			require.Len(t, data.SignedBlocks, 1)
+			require.Len(t, data.SignedAttestations, 1)
+			require.NotEqual(t, "0", data.SignedBlocks[0].Slot)
+			require.Equal(t, data.SignedAttestations[0].SourceEpoch, data.SignedAttestations[0].TargetEpoch)
+		}
+	}
+}
+
+func TestValidatorGraffiti(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            2,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		Graffiti:          []string{"validator zero", "validator one"},
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+	require.NoError(t, lock.VerifyHashes())
+	require.True(t, cluster.SupportGraffiti(lock.Version))
+	require.Len(t, lock.Validators, 2)
+
+	for i, val := range lock.Validators {
+		require.Equal(t, conf.Graffiti[i], val.Graffiti)
+	}
+
+	t.Run("single", func(t *testing.T) {
+		graffiti, err := validatorGraffiti([]string{"all"}, 3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"all", "all", "all"}, graffiti)
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		_, err := validatorGraffiti([]string{"a", "b"}, 3)
+		require.ErrorContains(t, err, "invalid number of graffiti")
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, err := validatorGraffiti([]string{strings.Repeat("a", maxGraffitiLen+1)}, 1)
+		require.ErrorContains(t, err, "graffiti too long")
+	})
+}
+
+func TestCreateClusterObservers(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          5,
+		NumObservers:      1,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+	require.NoError(t, lock.VerifyHashes())
+	require.True(t, cluster.SupportObservers(lock.Version))
+	require.Equal(t, cluster.Threshold(4), lock.Threshold)
+
+	for i, op := range lock.Operators {
+		observer := i == 4
+		require.Equal(t, observer, op.Observer)
+
+		// Observers don't sign, so don't have key shares.
+		keysDir := path.Join(nodeDir(conf.ClusterDir, i), "validator_keys")
+		if observer {
+			require.NoDirExists(t, keysDir)
+		} else {
+			require.DirExists(t, keysDir)
+		}
+	}
+
+	t.Run("too many observers", func(t *testing.T) {
+		conf := conf
+		conf.ClusterDir = t.TempDir()
+		conf.NumObservers = conf.NumNodes
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "invalid number of observers")
+	})
+
+	t.Run("threshold exceeds voting nodes", func(t *testing.T) {
+		conf := conf
+		conf.ClusterDir = t.TempDir()
+		conf.Threshold = 5
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "insufficient non-observer operators for signing threshold")
+	})
+}
+
+func TestBuilderRelays(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            2,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		BuilderRelays:     []string{"https://0xabcd@relay0.example.com", "https://relay1.example.com"},
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+	require.NoError(t, lock.VerifyHashes())
+	require.True(t, cluster.SupportBuilderRelays(lock.Version))
+	require.Len(t, lock.Validators, 2)
+
+	for _, val := range lock.Validators {
+		require.Equal(t, conf.BuilderRelays, val.BuilderRelays)
+	}
+
+	t.Run("invalid url", func(t *testing.T) {
+		err := validateBuilderRelays([]string{"relay.example.com"})
+		require.ErrorContains(t, err, "invalid builder relay URL")
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := validateBuilderRelays([]string{"https://relay.example.com", "https://relay.example.com"})
+		require.ErrorContains(t, err, "duplicate builder relay URL")
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		var relays []string
+		for i := 0; i <= maxBuilderRelays; i++ {
+			relays = append(relays, fmt.Sprintf("https://relay%d.example.com", i))
+		}
+		err := validateBuilderRelays(relays)
+		require.ErrorContains(t, err, "too many builder relays")
+	})
+}
+
+// testTSSScheme is an alternative threshold secret sharing scheme wrapping the default scheme.
+type testTSSScheme struct {
+	tblsv2.TSSScheme
+}
+
+func (testTSSScheme) Name() string {
+	return "test"
+}
+
+func TestTSSScheme(t *testing.T) {
+	defaultScheme, err := tblsv2.GetTSSScheme(tblsv2.DefaultTSSScheme)
+	require.NoError(t, err)
+	tblsv2.RegisterTSSScheme(testTSSScheme{TSSScheme: defaultScheme})
+
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            1,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		TSSScheme:         "test",
+	}
+
+	err = runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+	require.NoError(t, err)
+	require.NoError(t, lock.VerifyHashes())
+	require.True(t, cluster.SupportTSSScheme(lock.Version))
+	require.Equal(t, "test", lock.Definition.TSSScheme)
+
+	t.Run("unknown", func(t *testing.T) {
+		conf := conf
+		conf.ClusterDir = t.TempDir()
+		conf.TSSScheme = "unknown"
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "unknown tss scheme")
+	})
+
+	defPath := path.Join(t.TempDir(), "cluster-definition.json")
+	b, err := json.Marshal(lock.Definition)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(defPath, b, 0o644))
+
+	t.Run("definition file", func(t *testing.T) {
+		conf := clusterConfig{
+			ClusterDir:   t.TempDir(),
+			DefFiles:     []string{defPath},
+			InsecureKeys: true,
+			TSSScheme:    tblsv2.DefaultTSSScheme,
+		}
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.NoError(t, err)
+
+		lock, err := readLockFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"), "")
+		require.NoError(t, err)
+		require.NoError(t, lock.VerifyHashes())
+		require.Equal(t, "test", lock.Definition.TSSScheme)
+	})
+
+	t.Run("definition file mismatch", func(t *testing.T) {
+		def := lock.Definition
+		def.TSSScheme = ""
+		def, err := def.SetDefinitionHashes()
+		require.NoError(t, err)
+
+		defPath := path.Join(t.TempDir(), "cluster-definition.json")
+		b, err := json.Marshal(def)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(defPath, b, 0o644))
+
+		conf := clusterConfig{
+			ClusterDir:   t.TempDir(),
+			DefFiles:     []string{defPath},
+			InsecureKeys: true,
+			TSSScheme:    "test",
+		}
+
+		err = runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "tss scheme doesn't match cluster definition")
+	})
+}
+
+func TestDepositDataSSZ(t *testing.T) {
+	conf := clusterConfig{
+		Name:              t.Name(),
+		ClusterDir:        t.TempDir(),
+		NumNodes:          minNodes,
+		NumDVs:            2,
+		Network:           defaultNetwork,
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		InsecureKeys:      true,
+		DepositDataSSZ:    true,
+	}
+
+	err := runCreateCluster(context.Background(), io.Discard, conf)
+	require.NoError(t, err)
+
+	for i := 0; i < conf.NumNodes; i++ {
+		b, err := os.ReadFile(path.Join(nodeDir(conf.ClusterDir, i), "deposit-data.ssz"))
+		require.NoError(t, err)
+
+		depositDatas, err := deposit.UnmarshalDepositDataSSZ(b)
+		require.NoError(t, err)
+		require.Len(t, depositDatas, conf.NumDVs)
+
+		b, err = os.ReadFile(path.Join(nodeDir(conf.ClusterDir, i), "deposit-data.json"))
+		require.NoError(t, err)
+
+		var entries []depositEntry
+		require.NoError(t, json.Unmarshal(b, &entries))
+
+		// Both files are sorted by pubkey.
+		for j, depositData := range depositDatas {
+			require.Equal(t, fmt.Sprintf("%x", depositData.PublicKey), entries[j].PubKey)
+			require.Equal(t, fmt.Sprintf("%x", depositData.WithdrawalCredentials), entries[j].WithdrawalCredentials)
+		}
+	}
+}
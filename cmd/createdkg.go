@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/enr"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 )
 
 type createDKGConfig struct {
@@ -30,6 +32,8 @@ type createDKGConfig struct {
 	Network           string
 	DKGAlgo           string
 	OperatorENRs      []string
+	CheckConnectivity bool
+	TSSScheme         string
 }
 
 func newCreateDKGCmd(runFunc func(context.Context, createDKGConfig) error) *cobra.Command {
@@ -59,9 +63,11 @@ func bindCreateDKGFlags(cmd *cobra.Command, config *createDKGConfig) {
 	cmd.Flags().IntVarP(&config.Threshold, "threshold", "t", 0, "Optional override of threshold required for signature reconstruction. Defaults to ceil(n*2/3) if zero. Warning, non-default values decrease security.")
 	cmd.Flags().StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each validator. Either provide a single fee recipient address or fee recipient addresses for each validator.")
 	cmd.Flags().StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
-	cmd.Flags().StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, gnosis, goerli, kiln, ropsten, sepolia.")
+	cmd.Flags().StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, gnosis, goerli, kiln, ropsten, sepolia, holesky.")
 	cmd.Flags().StringVar(&config.DKGAlgo, "dkg-algorithm", "default", "DKG algorithm to use; default, keycast, frost")
+	cmd.Flags().StringVar(&config.TSSScheme, "tss-scheme", tblsv2.DefaultTSSScheme, "Threshold secret sharing scheme used by the keycast DKG algorithm to split the validator keys. Options: "+strings.Join(tblsv2.TSSSchemes(), ", ")+". Non-default schemes require cluster definition version v1.6.0 or later.")
 	cmd.Flags().StringSliceVar(&config.OperatorENRs, operatorENRs, nil, "[REQUIRED] Comma-separated list of each operator's Charon ENR address.")
+	bindCheckConnectivityFlag(cmd.Flags(), &config.CheckConnectivity)
 
 	mustMarkFlagRequired(cmd, operatorENRs)
 }
@@ -132,13 +138,26 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 		return err
 	}
 
+	opts := []func(*cluster.Definition){
+		func(d *cluster.Definition) {
+			d.DKGAlgorithm = conf.DKGAlgo
+		},
+	}
+
+	scheme, err := tblsv2.GetTSSScheme(conf.TSSScheme)
+	if err != nil {
+		return err
+	} else if scheme.Name() != tblsv2.DefaultTSSScheme {
+		if conf.DKGAlgo != "keycast" {
+			return errors.New("tss scheme only supported by keycast dkg algorithm", z.Str("scheme", scheme.Name()))
+		}
+		opts = append(opts, cluster.WithVersion(validatorConfigVersion), cluster.WithTSSScheme(scheme.Name()))
+	}
+
 	def, err := cluster.NewDefinition(
 		conf.Name, conf.NumValidators, conf.Threshold,
 		conf.FeeRecipientAddrs, conf.WithdrawalAddrs,
-		forkVersion, cluster.Creator{}, operators, crand.Reader,
-		func(d *cluster.Definition) {
-			d.DKGAlgorithm = conf.DKGAlgo
-		})
+		forkVersion, cluster.Creator{}, operators, crand.Reader, opts...)
 	if err != nil {
 		return err
 	}
@@ -150,6 +169,12 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 		return err
 	}
 
+	if conf.CheckConnectivity {
+		if err := checkConnectivity(ctx, def); err != nil {
+			return err
+		}
+	}
+
 	b, err := json.MarshalIndent(def, "", " ")
 	if err != nil {
 		return errors.Wrap(err, "marshal definition")
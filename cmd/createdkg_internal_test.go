@@ -4,11 +4,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 )
 
 func TestCreateDkgValid(t *testing.T) {
@@ -34,6 +38,47 @@ func TestCreateDkgValid(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCreateDkgTSSScheme(t *testing.T) {
+	defaultScheme, err := tblsv2.GetTSSScheme(tblsv2.DefaultTSSScheme)
+	require.NoError(t, err)
+	tblsv2.RegisterTSSScheme(testTSSScheme{TSSScheme: defaultScheme})
+
+	conf := createDKGConfig{
+		OutputDir:         t.TempDir(),
+		NumValidators:     1,
+		FeeRecipientAddrs: []string{defaultWithdrawalAddr},
+		WithdrawalAddrs:   []string{defaultWithdrawalAddr},
+		Network:           defaultNetwork,
+		DKGAlgo:           "keycast",
+		TSSScheme:         "test",
+		OperatorENRs: []string{
+			"enr:-JG4QFI0llFYxSoTAHm24OrbgoVx77dL6Ehl1Ydys39JYoWcBhiHrRhtGXDTaygWNsEWFb1cL7a1Bk0klIdaNuXplKWGAYGv0Gt7gmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQL6bcis0tFXnbqG4KuywxT5BLhtmijPFApKCDJNl3mXFYN0Y3CCDhqDdWRwgg4u",
+			"enr:-JG4QPnqHa7FU3PBqGxpV5L0hjJrTUqv8Wl6_UTHt-rELeICWjvCfcVfwmax8xI_eJ0ntI3ly9fgxAsmABud6-yBQiuGAYGv0iYPgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQMLLCMZ5Oqi_sdnBfdyhmysZMfFm78PgF7Y9jitTJPSroN0Y3CCPoODdWRwgj6E",
+			"enr:-JG4QDKNYm_JK-w6NuRcUFKvJAlq2L4CwkECelzyCVrMWji4YnVRn8AqQEL5fTQotPL2MKxiKNmn2k6XEINtq-6O3Z2GAYGvzr_LgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQKlO7fSaBa3h48CdM-qb_Xb2_hSrJOy6nNjR0mapAqMboN0Y3CCDhqDdWRwgg4u",
+			"enr:-JG4QKu734_MXQklKrNHe9beXIsIV5bqv58OOmsjWmp6CF5vJSHNinYReykn7-IIkc5-YsoF8Hva1Q3pl7_gUj5P9cOGAYGv0jBLgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQMM3AvPhXGCUIzBl9VFOw7VQ6_m8dGifVfJ1YXrvZsaZoN0Y3CCDhqDdWRwgg4u",
+		},
+	}
+
+	t.Run("frost", func(t *testing.T) {
+		conf := conf
+		conf.DKGAlgo = "frost"
+
+		err := runCreateDKG(context.Background(), conf)
+		require.ErrorContains(t, err, "tss scheme only supported by keycast dkg algorithm")
+	})
+
+	require.NoError(t, runCreateDKG(context.Background(), conf))
+
+	b, err := os.ReadFile(path.Join(conf.OutputDir, "cluster-definition.json"))
+	require.NoError(t, err)
+
+	var def cluster.Definition
+	require.NoError(t, json.Unmarshal(b, &def))
+	require.NoError(t, def.VerifyHashes())
+	require.True(t, cluster.SupportTSSScheme(def.Version))
+	require.Equal(t, "test", def.TSSScheme)
+}
+
 func TestCreateDkgInvalid(t *testing.T) {
 	validENRs := []string{
 		"enr:-JG4QFI0llFYxSoTAHm24OrbgoVx77dL6Ehl1Ydys39JYoWcBhiHrRhtGXDTaygWNsEWFb1cL7a1Bk0klIdaNuXplKWGAYGv0Gt7gmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQL6bcis0tFXnbqG4KuywxT5BLhtmijPFApKCDJNl3mXFYN0Y3CCDhqDdWRwgg4u",
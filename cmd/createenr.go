@@ -5,6 +5,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,28 +16,61 @@ import (
 	"github.com/obolnetwork/charon/p2p"
 )
 
-func newCreateEnrCmd(runFunc func(io.Writer, string) error) *cobra.Command {
-	var dataDir string
+type createEnrConfig struct {
+	DataDir string
+	Num     int
+	OutDir  string
+}
+
+func newCreateEnrCmd(runFunc func(io.Writer, createEnrConfig) error) *cobra.Command {
+	var conf createEnrConfig
 
 	cmd := &cobra.Command{
 		Use:   "enr",
 		Short: "Create an Ethereum Node Record (ENR) private key to identify this charon client",
+		Long:  "Create an Ethereum Node Record (ENR) private key to identify this charon client. Use --out to generate multiple keys ahead of a DKG, allowing operators to exchange ENRs before running `create dkg`.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFunc(cmd.OutOrStdout(), dataDir)
+			return runFunc(cmd.OutOrStdout(), conf)
 		},
 	}
 
-	bindDataDirFlag(cmd.Flags(), &dataDir)
+	bindDataDirFlag(cmd.Flags(), &conf.DataDir)
+	cmd.Flags().IntVar(&conf.Num, "num", 1, "The number of ENR private keys to create. Requires --out if more than one.")
+	cmd.Flags().StringVar(&conf.OutDir, "out", "", "Optional directory to create the ENR private keys in, one node* subdirectory per key. Overrides --data-dir.")
 
 	return cmd
 }
 
-// runCreateEnrCmd stores a new charon-enr-private-key to disk and prints the ENR for the provided config.
+// runCreateEnrCmd stores new charon-enr-private-keys to disk and prints the ENRs for the provided config.
+// It returns an error if any key already exists.
+func runCreateEnrCmd(w io.Writer, conf createEnrConfig) error {
+	if conf.Num < 1 {
+		return errors.New("number of keys must be at least one", z.Int("num", conf.Num))
+	}
+
+	if conf.OutDir == "" {
+		if conf.Num > 1 {
+			return errors.New("--out required when creating multiple keys")
+		}
+
+		return createEnr(w, conf.DataDir)
+	}
+
+	for i := 0; i < conf.Num; i++ {
+		if err := createEnr(w, nodeDir(conf.OutDir, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createEnr stores a new charon-enr-private-key to the data dir and prints its ENR.
 // It returns an error if the key already exists.
-func runCreateEnrCmd(w io.Writer, dataDir string) error {
-	_, err := p2p.LoadPrivKey(dataDir)
-	if err == nil {
+func createEnr(w io.Writer, dataDir string) error {
+	// Check for existence rather than loading, since the key may be encrypted.
+	if _, err := os.Stat(p2p.KeyPath(dataDir)); err == nil {
 		return errors.New("charon-enr-private-key already exists", z.Str("enr_path", p2p.KeyPath(dataDir)))
 	}
 
@@ -3,15 +3,52 @@
 package cmd
 
 import (
+	"bytes"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
 )
 
 func TestRunCreateEnr(t *testing.T) {
 	temp := t.TempDir()
 
-	err := runCreateEnrCmd(io.Discard, temp)
+	err := runCreateEnrCmd(io.Discard, createEnrConfig{DataDir: temp, Num: 1})
+	require.NoError(t, err)
+}
+
+func TestRunCreateEnrs(t *testing.T) {
+	const num = 3
+	out := t.TempDir()
+
+	var buf bytes.Buffer
+	err := runCreateEnrCmd(&buf, createEnrConfig{Num: num, OutDir: out})
 	require.NoError(t, err)
+
+	var records []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "enr:") {
+			records = append(records, line)
+		}
+	}
+	require.Len(t, records, num)
+
+	for i, record := range records {
+		key, err := p2p.LoadPrivKey(nodeDir(out, i))
+		require.NoError(t, err)
+
+		r, err := enr.Parse(record)
+		require.NoError(t, err)
+		require.True(t, key.PubKey().IsEqual(r.PubKey))
+	}
+
+	err = runCreateEnrCmd(io.Discard, createEnrConfig{Num: num, OutDir: out})
+	require.ErrorContains(t, err, "already exists")
+
+	err = runCreateEnrCmd(io.Discard, createEnrConfig{Num: num, DataDir: t.TempDir()})
+	require.ErrorContains(t, err, "--out required")
 }
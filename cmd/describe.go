@@ -0,0 +1,19 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func newDescribeCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "describe",
+		Short: "Describe charon artifacts",
+		Long:  "Describe charon artifacts in alternative formats, useful for documentation and debugging.",
+	}
+
+	root.AddCommand(cmds...)
+
+	titledHelp(root)
+
+	return root
+}
@@ -0,0 +1,113 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+const describeFormatDot = "dot"
+
+type describeClusterConfig struct {
+	LockFile         string
+	LockPasswordFile string
+	Format           string
+}
+
+func newDescribeClusterCmd(runFunc func(context.Context, io.Writer, describeClusterConfig) error) *cobra.Command {
+	var conf describeClusterConfig
+
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Describe the cluster topology",
+		Long:  "Describes the cluster operators and the distributed validators they jointly control. The dot format can be rendered with Graphviz, e.g. `dot -Tpng`.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindDescribeClusterFlags(cmd.Flags(), &conf)
+
+	return cmd
+}
+
+func bindDescribeClusterFlags(flags *pflag.FlagSet, config *describeClusterConfig) {
+	flags.StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster.")
+	bindLockPasswordFlag(flags, &config.LockPasswordFile)
+	flags.StringVar(&config.Format, "format", describeFormatDot, "The output format. Only dot (Graphviz) is supported.")
+}
+
+// runDescribeCluster writes the cluster topology of the configured lock file in the configured format.
+func runDescribeCluster(_ context.Context, w io.Writer, conf describeClusterConfig) error {
+	if conf.Format != describeFormatDot {
+		return errors.New("unsupported format", z.Str("format", conf.Format))
+	}
+
+	lock, err := readLockFile(conf.LockFile, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	dot, err := clusterDot(lock)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprint(w, dot)
+
+	return nil
+}
+
+// clusterDot returns the Graphviz DOT representation of the cluster operators
+// and the distributed validators they jointly control.
+func clusterDot(lock cluster.Lock) (string, error) {
+	peers, err := lock.Peers()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "digraph cluster {\n")
+	_, _ = fmt.Fprintf(&b, "  label=%q;\n", fmt.Sprintf("%s (threshold %d of %d)", lock.Name, lock.Threshold, len(peers)))
+	_, _ = fmt.Fprintf(&b, "  rankdir=LR;\n")
+
+	_, _ = fmt.Fprintf(&b, "\n  node [shape=box];\n")
+	for _, p := range peers {
+		_, _ = fmt.Fprintf(&b, "  %q [label=%q];\n", operatorNode(p.Index), fmt.Sprintf("%d: %s\n%s", p.Index, p.Name, p.ID))
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n  node [shape=ellipse];\n")
+	for i, val := range lock.Validators {
+		_, _ = fmt.Fprintf(&b, "  %q [label=%q];\n", validatorNode(i), val.PublicKeyHex())
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n")
+	for _, p := range peers {
+		for i := range lock.Validators {
+			_, _ = fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", operatorNode(p.Index), validatorNode(i), fmt.Sprintf("share %d", p.ShareIdx()))
+		}
+	}
+
+	_, _ = fmt.Fprintf(&b, "}\n")
+
+	return b.String(), nil
+}
+
+func operatorNode(idx int) string {
+	return fmt.Sprintf("operator%d", idx)
+}
+
+func validatorNode(idx int) string {
+	return fmt.Sprintf("validator%d", idx)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestDescribeCluster(t *testing.T) {
+	const (
+		numVals  = 2
+		numNodes = 4
+	)
+
+	lock, _, _ := cluster.NewForT(t, numVals, 3, numNodes, 0)
+
+	file := path.Join(t.TempDir(), "cluster-lock.json")
+	writeLockFile(t, file, lock)
+
+	var buf bytes.Buffer
+	err := runDescribeCluster(context.Background(), &buf, describeClusterConfig{LockFile: file, Format: describeFormatDot})
+	require.NoError(t, err)
+
+	dot := buf.String()
+	require.True(t, strings.HasPrefix(dot, "digraph cluster {"))
+
+	peers, err := lock.Peers()
+	require.NoError(t, err)
+
+	for _, p := range peers {
+		require.Contains(t, dot, fmt.Sprintf("%q [label=\"%d: %s\\n%s\"]", operatorNode(p.Index), p.Index, p.Name, p.ID))
+
+		for i := range lock.Validators {
+			require.Contains(t, dot, fmt.Sprintf("%q -> %q", operatorNode(p.Index), validatorNode(i)))
+		}
+	}
+
+	for i, val := range lock.Validators {
+		require.Contains(t, dot, fmt.Sprintf("%q [label=%q]", validatorNode(i), val.PublicKeyHex()))
+	}
+
+	require.Equal(t, numNodes*numVals, strings.Count(dot, "->"))
+
+	err = runDescribeCluster(context.Background(), &buf, describeClusterConfig{LockFile: file, Format: "png"})
+	require.ErrorContains(t, err, "unsupported format")
+}
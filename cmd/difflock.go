@@ -0,0 +1,172 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+type diffLockConfig struct {
+	LockFileA        string
+	LockFileB        string
+	LockPasswordFile string
+}
+
+func newDiffLockCmd(runFunc func(context.Context, io.Writer, diffLockConfig) error) *cobra.Command {
+	var conf diffLockConfig
+
+	cmd := &cobra.Command{
+		Use:   "cluster-lock",
+		Short: "Compare two cluster lock files",
+		Long:  "Parses two cluster lock files and prints the differences of their definition and validator fields.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindDiffLockFlags(cmd, &conf)
+
+	return cmd
+}
+
+func bindDiffLockFlags(cmd *cobra.Command, config *diffLockConfig) {
+	bindDiffLockFileFlags(cmd.Flags(), config)
+	bindLockPasswordFlag(cmd.Flags(), &config.LockPasswordFile)
+	mustMarkFlagRequired(cmd, "a")
+	mustMarkFlagRequired(cmd, "b")
+}
+
+func bindDiffLockFileFlags(flags *pflag.FlagSet, config *diffLockConfig) {
+	flags.StringVar(&config.LockFileA, "a", "", "The path to the first cluster lock file.")
+	flags.StringVar(&config.LockFileB, "b", "", "The path to the second cluster lock file.")
+}
+
+// runDiffLock prints the differences between the two configured cluster lock files.
+func runDiffLock(_ context.Context, w io.Writer, conf diffLockConfig) error {
+	a, err := readLockFile(conf.LockFileA, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	b, err := readLockFile(conf.LockFileB, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	diffs := diffLocks(a, b)
+	if len(diffs) == 0 {
+		_, _ = fmt.Fprintln(w, "Cluster locks are identical")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Cluster locks differ in %d fields:\n", len(diffs))
+	for _, diff := range diffs {
+		_, _ = fmt.Fprintf(w, "%s\n  a: %s\n  b: %s\n", diff.Field, diff.A, diff.B)
+	}
+
+	return nil
+}
+
+// readLockFile returns the cluster lock parsed from the file at the provided path,
+// decrypted with the password in the optional password file if encrypted.
+func readLockFile(path string, passwordFile string) (cluster.Lock, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "read cluster lock")
+	}
+
+	password, err := cluster.LoadLockPassword(passwordFile)
+	if err != nil {
+		return cluster.Lock{}, err
+	}
+
+	return cluster.DecryptLock(b, password)
+}
+
+// lockDiff is a single field difference between two cluster locks.
+type lockDiff struct {
+	Field string
+	A     string
+	B     string
+}
+
+// diffLocks returns the differences between the two cluster locks.
+func diffLocks(a, b cluster.Lock) []lockDiff {
+	var diffs []lockDiff
+	add := func(field string, valA, valB any) {
+		strA, strB := fmt.Sprint(valA), fmt.Sprint(valB)
+		if strA == strB {
+			return
+		}
+		diffs = append(diffs, lockDiff{Field: field, A: strA, B: strB})
+	}
+	hex := func(b []byte) string {
+		return fmt.Sprintf("%#x", b)
+	}
+
+	add("lock_hash", hex(a.LockHash), hex(b.LockHash))
+	add("cluster_definition.definition_hash", hex(a.DefinitionHash), hex(b.DefinitionHash))
+	add("cluster_definition.config_hash", hex(a.ConfigHash), hex(b.ConfigHash))
+	add("cluster_definition.name", a.Name, b.Name)
+	add("cluster_definition.uuid", a.UUID, b.UUID)
+	add("cluster_definition.version", a.Version, b.Version)
+	add("cluster_definition.timestamp", a.Timestamp, b.Timestamp)
+	add("cluster_definition.num_validators", a.NumValidators, b.NumValidators)
+	add("cluster_definition.threshold", a.Threshold, b.Threshold)
+	add("cluster_definition.dkg_algorithm", a.DKGAlgorithm, b.DKGAlgorithm)
+	add("cluster_definition.fork_version", hex(a.ForkVersion), hex(b.ForkVersion))
+	add("cluster_definition.tss_scheme", a.Definition.TSSScheme, b.Definition.TSSScheme)
+	add("cluster_definition.creator.address", a.Creator.Address, b.Creator.Address)
+	add("cluster_definition.creator.config_signature", hex(a.Creator.ConfigSignature), hex(b.Creator.ConfigSignature))
+
+	add("cluster_definition.operators.length", len(a.Operators), len(b.Operators))
+	for i := 0; i < len(a.Operators) && i < len(b.Operators); i++ {
+		field := fmt.Sprintf("cluster_definition.operators[%d]", i)
+		add(field+".address", a.Operators[i].Address, b.Operators[i].Address)
+		add(field+".enr", a.Operators[i].ENR, b.Operators[i].ENR)
+		add(field+".config_signature", hex(a.Operators[i].ConfigSignature), hex(b.Operators[i].ConfigSignature))
+		add(field+".enr_signature", hex(a.Operators[i].ENRSignature), hex(b.Operators[i].ENRSignature))
+		add(field+".weight", a.Operators[i].Weight, b.Operators[i].Weight)
+		add(field+".observer", a.Operators[i].Observer, b.Operators[i].Observer)
+	}
+
+	add("cluster_definition.validators.length", len(a.ValidatorAddresses), len(b.ValidatorAddresses))
+	for i := 0; i < len(a.ValidatorAddresses) && i < len(b.ValidatorAddresses); i++ {
+		field := fmt.Sprintf("cluster_definition.validators[%d]", i)
+		add(field+".fee_recipient_address", a.ValidatorAddresses[i].FeeRecipientAddress, b.ValidatorAddresses[i].FeeRecipientAddress)
+		add(field+".withdrawal_address", a.ValidatorAddresses[i].WithdrawalAddress, b.ValidatorAddresses[i].WithdrawalAddress)
+	}
+
+	add("distributed_validators.length", len(a.Validators), len(b.Validators))
+	for i := 0; i < len(a.Validators) && i < len(b.Validators); i++ {
+		valA, valB := a.Validators[i], b.Validators[i]
+		field := fmt.Sprintf("distributed_validators[%d]", i)
+		add(field+".distributed_public_key", hex(valA.PubKey), hex(valB.PubKey))
+
+		add(field+".public_shares.length", len(valA.PubShares), len(valB.PubShares))
+		for j := 0; j < len(valA.PubShares) && j < len(valB.PubShares); j++ {
+			add(fmt.Sprintf("%s.public_shares[%d]", field, j), hex(valA.PubShares[j]), hex(valB.PubShares[j]))
+		}
+
+		add(field+".deposit_data.pubkey", hex(valA.DepositData.PubKey), hex(valB.DepositData.PubKey))
+		add(field+".deposit_data.withdrawal_credentials", hex(valA.DepositData.WithdrawalCredentials), hex(valB.DepositData.WithdrawalCredentials))
+		add(field+".deposit_data.amount", valA.DepositData.Amount, valB.DepositData.Amount)
+		add(field+".deposit_data.signature", hex(valA.DepositData.Signature), hex(valB.DepositData.Signature))
+		add(field+".graffiti", valA.Graffiti, valB.Graffiti)
+		add(field+".builder_relays", valA.BuilderRelays, valB.BuilderRelays)
+	}
+
+	add("signature_aggregate", hex(a.SignatureAggregate), hex(b.SignatureAggregate))
+
+	return diffs
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestDiffLock(t *testing.T) {
+	lockA, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+
+	lockB := lockA
+	lockB.Threshold = 2
+	lockB.Validators = append([]cluster.DistValidator(nil), lockA.Validators...)
+	lockB.Validators[1].PubKey = testutil.RandomBytes48()
+
+	diffs := diffLocks(lockA, lockB)
+	require.Len(t, diffs, 2)
+	require.Equal(t, "cluster_definition.threshold", diffs[0].Field)
+	require.Equal(t, "3", diffs[0].A)
+	require.Equal(t, "2", diffs[0].B)
+	require.Equal(t, "distributed_validators[1].distributed_public_key", diffs[1].Field)
+
+	require.Empty(t, diffLocks(lockA, lockA))
+
+	t.Run("operators and tss scheme", func(t *testing.T) {
+		lockB := lockA
+		lockB.Definition.TSSScheme = "other"
+		lockB.Operators = append([]cluster.Operator(nil), lockA.Operators...)
+		lockB.Operators[0].Weight = 2
+		lockB.Operators[2].Observer = true
+
+		diffs := diffLocks(lockA, lockB)
+		require.Len(t, diffs, 3)
+		require.Equal(t, "cluster_definition.tss_scheme", diffs[0].Field)
+		require.Equal(t, "other", diffs[0].B)
+		require.Equal(t, "cluster_definition.operators[0].weight", diffs[1].Field)
+		require.Equal(t, "0", diffs[1].A)
+		require.Equal(t, "2", diffs[1].B)
+		require.Equal(t, "cluster_definition.operators[2].observer", diffs[2].Field)
+		require.Equal(t, "false", diffs[2].A)
+		require.Equal(t, "true", diffs[2].B)
+	})
+
+	t.Run("command", func(t *testing.T) {
+		dir := t.TempDir()
+		fileA, fileB := path.Join(dir, "a.json"), path.Join(dir, "b.json")
+		writeLockFile(t, fileA, lockA)
+		writeLockFile(t, fileB, lockA)
+
+		var buf bytes.Buffer
+		err := runDiffLock(context.Background(), &buf, diffLockConfig{LockFileA: fileA, LockFileB: fileB})
+		require.NoError(t, err)
+		require.Equal(t, "Cluster locks are identical\n", buf.String())
+
+		lockB := lockA
+		lockB.Name = "other"
+		writeLockFile(t, fileB, lockB)
+
+		buf.Reset()
+		err = runDiffLock(context.Background(), &buf, diffLockConfig{LockFileA: fileA, LockFileB: fileB})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "cluster_definition.name")
+		require.Contains(t, buf.String(), "lock_hash")
+	})
+}
+
+func writeLockFile(t *testing.T, file string, lock cluster.Lock) {
+	t.Helper()
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, b, 0o644))
+}
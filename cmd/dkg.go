@@ -4,12 +4,16 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 
 	libp2plog "github.com/ipfs/go-log/v2"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/pushmetrics"
 	"github.com/obolnetwork/charon/dkg"
 )
 
@@ -29,19 +33,28 @@ this command at the same time.`,
 			}
 			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
 
-			printFlags(cmd.Context(), cmd.Flags())
+			// Cancel the ceremony on interrupt, so partial outputs are cleaned up.
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
 
-			return runFunc(cmd.Context(), config)
+			printFlags(ctx, cmd.Flags())
+
+			return runFunc(ctx, config)
 		},
 	}
 
 	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &config.PrivKeyPasswordFile)
+	bindLockPasswordFlag(cmd.Flags(), &config.LockPasswordFile)
 	bindKeymanagerAddrFlag(cmd.Flags(), &config.KeymanagerAddr)
 	bindDefDirFlag(cmd.Flags(), &config.DefFile)
 	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
 	bindP2PFlags(cmd, &config.P2P)
 	bindLogFlags(cmd.Flags(), &config.Log)
 	bindPublishFlags(cmd.Flags(), config)
+	bindPushgatewayFlag(cmd.Flags(), &config.PushgatewayAddr)
+	cmd.Flags().BoolVar(&config.DepositDataPerValidator, "deposit-data-per-validator", false, "Additionally write a separate deposit-data-<pubkey>.json file for each validator, e.g. for staged deposits.")
+	cmd.Flags().IntVar(&config.SigVerifyConcurrency, "sig-verify-concurrency", 0, "Maximum number of concurrent partial signature verifications. Defaults to the number of CPUs if zero.")
 
 	return cmd
 }
@@ -62,3 +75,14 @@ func bindPublishFlags(flags *pflag.FlagSet, config dkg.Config) {
 	flags.StringVar(&config.PublishAddr, "publish-address", "https://api.obol.tech", "The URL to publish the lock file to.")
 	flags.BoolVar(&config.Publish, "publish", false, "Publish lock file to obol-api.")
 }
+
+func bindPushgatewayFlag(flags *pflag.FlagSet, addr *string) {
+	flags.StringVar(addr, "pushgateway-address", "", "Optional Prometheus Pushgateway URL to push operation metrics to on completion.")
+}
+
+// pushOperationMetrics pushes the operation metrics to the Pushgateway, logging any error.
+func pushOperationMetrics(ctx context.Context, addr string, op pushmetrics.Operation) {
+	if err := pushmetrics.Push(ctx, addr, op); err != nil {
+		log.Warn(ctx, "Failed pushing metrics to pushgateway", err)
+	}
+}
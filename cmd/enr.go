@@ -18,7 +18,7 @@ import (
 	"github.com/obolnetwork/charon/p2p"
 )
 
-func newEnrCmd(runFunc func(io.Writer, string, bool) error) *cobra.Command {
+func newEnrCmd(runFunc func(io.Writer, string, bool) error, cmds ...*cobra.Command) *cobra.Command {
 	var (
 		dataDir string
 		verbose bool
@@ -37,12 +37,19 @@ func newEnrCmd(runFunc func(io.Writer, string, bool) error) *cobra.Command {
 	bindDataDirFlag(cmd.Flags(), &dataDir)
 	bindEnrFlags(cmd.Flags(), &verbose)
 
+	cmd.AddCommand(cmds...)
+
 	return cmd
 }
 
 // runNewENR loads the p2pkey from disk and prints the ENR for the provided config.
 func runNewENR(w io.Writer, dataDir string, verbose bool) error {
-	key, err := p2p.LoadPrivKey(dataDir)
+	password, err := p2p.LoadPrivKeyPassword("")
+	if err != nil {
+		return err
+	}
+
+	key, err := p2p.LoadPrivKeyWithPassword(dataDir, password)
 	if errors.Is(err, fs.ErrNotExist) {
 		return errors.New("private key not found. If this is your first time running this client, create one with `charon create enr`.", z.Str("enr_path", p2p.KeyPath(dataDir))) //nolint:revive
 	} else if err != nil {
@@ -3,14 +3,20 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"net"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
 )
 
 func TestRunNewEnr(t *testing.T) {
@@ -20,3 +26,58 @@ func TestRunNewEnr(t *testing.T) {
 	expected := errors.New("private key not found. If this is your first time running this client, create one with `charon create enr`.", z.Str("enr_path", p2p.KeyPath(temp)))
 	require.Equal(t, expected.Error(), got.Error())
 }
+
+func TestRunEnrVerify(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 1)
+
+	record, err := enr.NewWithSeq(key, 3, enr.WithIP(net.IPv4(127, 0, 0, 1)), enr.WithTCP(3610), enr.WithUDP(3630))
+	require.NoError(t, err)
+
+	peerID, err := p2p.PeerIDFromKey(key.PubKey())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, runEnrVerify(&buf, record.String()))
+
+	out := buf.String()
+	require.Contains(t, out, "peer name: "+p2p.PeerName(peerID))
+	require.Contains(t, out, "peer id: "+peerID.String())
+	require.Contains(t, out, fmt.Sprintf("secp256k1 pubkey: %#x", key.PubKey().SerializeCompressed()))
+	require.Contains(t, out, "addresses: tcp://127.0.0.1:3610, udp://127.0.0.1:3630")
+	require.Contains(t, out, "sequence: 3")
+
+	valid := record.String()
+
+	tests := []struct {
+		Name   string
+		ENR    string
+		ErrStr string
+	}{
+		{Name: "empty", ENR: "", ErrStr: "missing 'enr:' prefix"},
+		{Name: "missing prefix", ENR: strings.TrimPrefix(valid, "enr:"), ErrStr: "missing 'enr:' prefix"},
+		{Name: "invalid base64", ENR: "enr:!@#$", ErrStr: "invalid base64 encoding"},
+		{Name: "truncated", ENR: valid[:len(valid)-10], ErrStr: "invalid enr"},
+		{Name: "invalid signature", ENR: tamper(t, valid), ErrStr: "invalid enr"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := runEnrVerify(io.Discard, test.ENR)
+			require.ErrorContains(t, err, test.ErrStr)
+		})
+	}
+}
+
+// tamper returns the ENR with a character of its signature modified.
+func tamper(t *testing.T, enrStr string) string {
+	t.Helper()
+
+	// The signature is the first RLP element, so the 10th character is within it.
+	b := []byte(enrStr)
+	if b[10] == 'A' {
+		b[10] = 'B'
+	} else {
+		b[10] = 'A'
+	}
+
+	return string(b)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+type enrEncryptConfig struct {
+	DataDir      string
+	PasswordFile string
+}
+
+func newEnrEncryptCmd(runFunc func(context.Context, enrEncryptConfig) error) *cobra.Command {
+	var conf enrEncryptConfig
+
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the charon-enr-private-key with a password",
+		Long:  "Encrypts the plaintext charon-enr-private-key in the data directory in place with the password provided via file or the " + p2p.PrivKeyPasswordEnv + " environment variable.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), conf)
+		},
+	}
+
+	bindDataDirFlag(cmd.Flags(), &conf.DataDir)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &conf.PasswordFile)
+
+	return cmd
+}
+
+func newEnrDecryptCmd(runFunc func(context.Context, enrEncryptConfig) error) *cobra.Command {
+	var conf enrEncryptConfig
+
+	cmd := &cobra.Command{
+		Use:   "decrypt",
+		Short: "Decrypt an encrypted charon-enr-private-key",
+		Long:  "Decrypts the encrypted charon-enr-private-key in the data directory in place with the password provided via file or the " + p2p.PrivKeyPasswordEnv + " environment variable.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), conf)
+		},
+	}
+
+	bindDataDirFlag(cmd.Flags(), &conf.DataDir)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &conf.PasswordFile)
+
+	return cmd
+}
+
+// runEnrEncrypt encrypts the plaintext charon-enr-private-key on disk.
+func runEnrEncrypt(ctx context.Context, conf enrEncryptConfig) error {
+	keyPath := p2p.KeyPath(conf.DataDir)
+
+	if encrypted, err := k1util.IsEncrypted(keyPath); err != nil {
+		return err
+	} else if encrypted {
+		return errors.New("charon-enr-private-key already encrypted", z.Str("enr_path", keyPath))
+	}
+
+	password, err := p2p.LoadPrivKeyPassword(conf.PasswordFile)
+	if err != nil {
+		return err
+	} else if password == "" {
+		return errors.New("empty private key password")
+	}
+
+	key, err := p2p.LoadPrivKey(conf.DataDir)
+	if err != nil {
+		return err
+	}
+
+	if err := k1util.SaveEncrypted(key, keyPath, password); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Encrypted charon-enr-private-key", z.Str("enr_path", keyPath))
+
+	return nil
+}
+
+// runEnrDecrypt decrypts the encrypted charon-enr-private-key on disk.
+func runEnrDecrypt(ctx context.Context, conf enrEncryptConfig) error {
+	keyPath := p2p.KeyPath(conf.DataDir)
+
+	if encrypted, err := k1util.IsEncrypted(keyPath); err != nil {
+		return err
+	} else if !encrypted {
+		return errors.New("charon-enr-private-key not encrypted", z.Str("enr_path", keyPath))
+	}
+
+	password, err := p2p.LoadPrivKeyPassword(conf.PasswordFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := p2p.LoadPrivKeyWithPassword(conf.DataDir, password)
+	if err != nil {
+		return err
+	}
+
+	if err := k1util.Save(key, keyPath); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Decrypted charon-enr-private-key", z.Str("enr_path", keyPath))
+
+	return nil
+}
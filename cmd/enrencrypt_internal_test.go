@@ -0,0 +1,45 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestEnrEncrypt(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	key, err := p2p.NewSavedPrivKey(dir)
+	require.NoError(t, err)
+
+	passwordFile := path.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret\n"), 0o600))
+
+	conf := enrEncryptConfig{DataDir: dir, PasswordFile: passwordFile}
+	require.NoError(t, runEnrEncrypt(ctx, conf))
+	require.ErrorContains(t, runEnrEncrypt(ctx, conf), "already encrypted")
+
+	encrypted, err := k1util.IsEncrypted(p2p.KeyPath(dir))
+	require.NoError(t, err)
+	require.True(t, encrypted)
+
+	loaded, err := p2p.LoadPrivKeyWithPassword(dir, "secret")
+	require.NoError(t, err)
+	require.True(t, key.PubKey().IsEqual(loaded.PubKey()))
+
+	t.Setenv(p2p.PrivKeyPasswordEnv, "secret")
+	require.NoError(t, runEnrDecrypt(ctx, enrEncryptConfig{DataDir: dir}))
+
+	loaded, err = p2p.LoadPrivKey(dir)
+	require.NoError(t, err)
+	require.True(t, key.PubKey().IsEqual(loaded.PubKey()))
+}
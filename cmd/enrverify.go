@@ -0,0 +1,68 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func newEnrVerifyCmd(runFunc func(io.Writer, string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify <enr>",
+		Short: "Verify and decode an ENR",
+		Long:  "Verifies the signature of the provided Ethereum Node Record (ENR) and prints its decoded fields, useful for checking ENRs exchanged between operators.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+// runEnrVerify parses the ENR, verifying its signature, and writes its decoded fields.
+func runEnrVerify(w io.Writer, enrStr string) error {
+	record, err := enr.Parse(strings.TrimSpace(enrStr))
+	if err != nil {
+		return errors.Wrap(err, "invalid enr")
+	}
+
+	peerID, err := p2p.PeerIDFromKey(record.PubKey)
+	if err != nil {
+		return err
+	}
+
+	var addrs []string
+	if ip, ok := record.IP(); ok {
+		if port, ok := record.TCP(); ok {
+			addrs = append(addrs, "tcp://"+net.JoinHostPort(ip.String(), strconv.Itoa(port)))
+		}
+		if port, ok := record.UDP(); ok {
+			addrs = append(addrs, "udp://"+net.JoinHostPort(ip.String(), strconv.Itoa(port)))
+		}
+	}
+	if len(addrs) == 0 {
+		addrs = append(addrs, "none")
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString(fmt.Sprintf("peer name: %s\n", p2p.PeerName(peerID)))
+	_, _ = sb.WriteString(fmt.Sprintf("peer id: %s\n", peerID))
+	_, _ = sb.WriteString(fmt.Sprintf("secp256k1 pubkey: %#x\n", record.PubKey.SerializeCompressed()))
+	_, _ = sb.WriteString(fmt.Sprintf("addresses: %s\n", strings.Join(addrs, ", ")))
+	_, _ = sb.WriteString(fmt.Sprintf("sequence: %d\n", record.Seq()))
+
+	_, _ = w.Write([]byte(sb.String()))
+
+	return nil
+}
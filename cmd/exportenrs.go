@@ -0,0 +1,141 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+type exportENRsConfig struct {
+	ClusterDir          string
+	PrivKeyPasswordFile string
+	OutFile             string
+	JSON                bool
+}
+
+// nodeENR is the ENR and random peer name of a node in the cluster.
+type nodeENR struct {
+	Index      int    `json:"index"`
+	ENR        string `json:"enr"`
+	RandomName string `json:"random_name"`
+}
+
+func newExportENRsCmd(runFunc func(context.Context, io.Writer, exportENRsConfig) error) *cobra.Command {
+	var conf exportENRsConfig
+
+	cmd := &cobra.Command{
+		Use:   "enrs",
+		Short: "Export the ENRs of all nodes in a cluster directory",
+		Long: "Derives the ENR of each node from the charon-enr-private-key in the node subdirectories of the cluster directory " +
+			"and writes them to a single newline-delimited file in node order. " +
+			"With --json, a JSON list of each node's index, ENR and random peer name is written instead.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindExportENRsFlags(cmd.Flags(), &conf)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &conf.PrivKeyPasswordFile)
+
+	return cmd
+}
+
+func bindExportENRsFlags(flags *pflag.FlagSet, config *exportENRsConfig) {
+	flags.StringVar(&config.ClusterDir, "cluster-dir", ".charon/cluster", "Parent directory containing a node subdirectory with a charon-enr-private-key for each node in the cluster.")
+	flags.StringVar(&config.OutFile, "out", "", "The path to the file to write the ENRs to. The ENRs are written to stdout if not set.")
+	flags.BoolVar(&config.JSON, "json", false, "Write a JSON list of each node's index, ENR and random peer name instead of newline-delimited ENRs.")
+}
+
+// runExportENRs writes the ENRs of all nodes in the configured cluster directory to the output file or w.
+func runExportENRs(ctx context.Context, w io.Writer, conf exportENRsConfig) error {
+	nodes, err := loadNodeENRs(conf.ClusterDir, conf.PrivKeyPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	if conf.JSON {
+		b, err = json.MarshalIndent(nodes, "", " ")
+		if err != nil {
+			return errors.Wrap(err, "marshal enrs")
+		}
+		b = append(b, '\n')
+	} else {
+		var sb strings.Builder
+		for _, node := range nodes {
+			_, _ = sb.WriteString(node.ENR + "\n")
+		}
+		b = []byte(sb.String())
+	}
+
+	if conf.OutFile == "" {
+		_, _ = w.Write(b)
+		return nil
+	}
+
+	if err := os.WriteFile(conf.OutFile, b, 0o644); err != nil { //nolint:gosec // ENRs are public.
+		return errors.Wrap(err, "write enrs file", z.Str("file", conf.OutFile))
+	}
+
+	log.Info(ctx, "Exported node ENRs", z.Int("nodes", len(nodes)), z.Str("file", conf.OutFile))
+
+	return nil
+}
+
+// loadNodeENRs returns the ENRs of the nodes in the cluster directory in node order,
+// derived from the charon-enr-private-key of each consecutive node subdirectory.
+func loadNodeENRs(clusterDir string, passwordFile string) ([]nodeENR, error) {
+	password, err := p2p.LoadPrivKeyPassword(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []nodeENR
+	for i := 0; ; i++ {
+		dir := nodeDir(clusterDir, i)
+		if _, err := os.Stat(p2p.KeyPath(dir)); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+
+		key, err := p2p.LoadPrivKeyWithPassword(dir, password)
+		if err != nil {
+			return nil, errors.Wrap(err, "load node private key", z.Int("node", i))
+		}
+
+		record, err := enr.New(key)
+		if err != nil {
+			return nil, err
+		}
+
+		peerID, err := p2p.PeerIDFromKey(key.PubKey())
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, nodeENR{
+			Index:      i,
+			ENR:        record.String(),
+			RandomName: p2p.PeerName(peerID),
+		})
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.New("no node private keys found in cluster dir", z.Str("cluster_dir", clusterDir))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestExportENRs(t *testing.T) {
+	const num = 4
+	clusterDir := t.TempDir()
+
+	err := runCreateEnrCmd(io.Discard, createEnrConfig{Num: num, OutDir: clusterDir})
+	require.NoError(t, err)
+
+	// Expected ENRs in node order.
+	var expected []string
+	for i := 0; i < num; i++ {
+		key, err := p2p.LoadPrivKey(nodeDir(clusterDir, i))
+		require.NoError(t, err)
+
+		record, err := enr.New(key)
+		require.NoError(t, err)
+
+		expected = append(expected, record.String())
+	}
+
+	t.Run("file", func(t *testing.T) {
+		outFile := path.Join(t.TempDir(), "enrs.txt")
+
+		err := runExportENRs(context.Background(), io.Discard, exportENRsConfig{
+			ClusterDir: clusterDir,
+			OutFile:    outFile,
+		})
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		require.Equal(t, expected, strings.Split(strings.TrimSpace(string(b)), "\n"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runExportENRs(context.Background(), &buf, exportENRsConfig{
+			ClusterDir: clusterDir,
+			JSON:       true,
+		})
+		require.NoError(t, err)
+
+		var nodes []nodeENR
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &nodes))
+		require.Len(t, nodes, num)
+
+		for i, node := range nodes {
+			require.Equal(t, i, node.Index)
+			require.Equal(t, expected[i], node.ENR)
+
+			record, err := enr.Parse(node.ENR)
+			require.NoError(t, err)
+			peer, err := p2p.NewPeerFromENR(record, i)
+			require.NoError(t, err)
+			require.Equal(t, peer.Name, node.RandomName)
+		}
+	})
+
+	t.Run("empty cluster dir", func(t *testing.T) {
+		err := runExportENRs(context.Background(), io.Discard, exportENRsConfig{ClusterDir: t.TempDir()})
+		require.ErrorContains(t, err, "no node private keys found in cluster dir")
+	})
+}
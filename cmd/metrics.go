@@ -0,0 +1,24 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+// Note these metrics are only exposed when a promauto registry is created, they are no-ops otherwise.
+var (
+	keymanagerImportCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "keymanager",
+		Name:      "import_total",
+		Help:      "Total number of key share import attempts by node index and keymanager address",
+	}, []string{"node", "addr"})
+
+	keymanagerImportFailedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "keymanager",
+		Name:      "import_failed_total",
+		Help:      "Total number of failed key share imports by node index and keymanager address",
+	}, []string{"node", "addr"})
+)
@@ -0,0 +1,246 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+)
+
+type regenerateDepositConfig struct {
+	ClusterDirs         []string
+	WithdrawalAddrs     []string
+	WithdrawalCredTypes []string
+	LockPasswordFile    string
+}
+
+func newRegenerateDepositCmd(runFunc func(context.Context, io.Writer, regenerateDepositConfig) error) *cobra.Command {
+	var conf regenerateDepositConfig
+
+	cmd := &cobra.Command{
+		Use:   "deposit-data",
+		Short: "Regenerate the deposit data of an existing cluster for a new withdrawal address",
+		Long: "Re-signs the deposit data of all validators of an existing cluster for new withdrawal addresses " +
+			"using the validator key shares of at least threshold nodes, without changing the validator keys. " +
+			"This is only safe before depositing, since the withdrawal credentials of deposited validators cannot be changed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindRegenerateDepositFlags(cmd.Flags(), &conf)
+	mustMarkFlagRequired(cmd, "cluster-dir")
+	mustMarkFlagRequired(cmd, "withdrawal-address")
+
+	return cmd
+}
+
+func bindRegenerateDepositFlags(flags *pflag.FlagSet, config *regenerateDepositConfig) {
+	flags.StringSliceVar(&config.ClusterDirs, "cluster-dir", nil, "Comma separated list of the node directories of at least threshold operators, each containing a cluster-lock.json and validator_keys directory. The regenerated deposit-data.json is written to each directory.")
+	flags.StringSliceVar(&config.WithdrawalAddrs, "withdrawal-address", nil, "Comma separated list of the new Ethereum withdrawal addresses. Either provide a single withdrawal address or withdrawal addresses for each validator.")
+	flags.StringSliceVar(&config.WithdrawalCredTypes, "withdrawal-credential-types", nil, "Comma separated list of withdrawal credential types for each validator. Options: 0x01 (execution), 0x02 (compounding). Either provide a single type or types for each validator. Defaults to 0x01.")
+	bindLockPasswordFlag(flags, &config.LockPasswordFile)
+}
+
+// runRegenerateDeposit re-signs the deposit data of the cluster in the configured node directories for
+// the new withdrawal addresses and writes it to each node directory.
+func runRegenerateDeposit(ctx context.Context, w io.Writer, conf regenerateDepositConfig) error {
+	if len(conf.ClusterDirs) == 0 {
+		return errors.New("no node directories provided, see --cluster-dir")
+	}
+
+	log.Warn(ctx, "Regenerating deposit data is only safe before depositing, "+
+		"ensure none of the validators have been deposited with the previous deposit data", nil)
+
+	lock, err := readLockFile(path.Join(conf.ClusterDirs[0], "cluster-lock.json"), conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	var shareSets [][]tblsv2.PrivateKey
+	for _, dir := range conf.ClusterDirs {
+		other, err := readLockFile(path.Join(dir, "cluster-lock.json"), conf.LockPasswordFile)
+		if err != nil {
+			return err
+		} else if !bytes.Equal(other.LockHash, lock.LockHash) {
+			return errors.New("mismatching cluster lock hash", z.Str("dir", dir))
+		}
+
+		shares, err := keystore.LoadKeys(path.Join(dir, "validator_keys"))
+		if err != nil {
+			return errors.Wrap(err, "load key shares", z.Str("dir", dir))
+		}
+
+		shareSets = append(shareSets, shares)
+	}
+
+	signers, err := thresholdSigners(lock, shareSets)
+	if err != nil {
+		return err
+	}
+
+	withdrawalAddrs, err := validateWithdrawalAddrsCount(conf.WithdrawalAddrs, len(lock.Validators))
+	if err != nil {
+		return err
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := validateWithdrawalAddrs(withdrawalAddrs, network); err != nil {
+		return err
+	}
+
+	prefixes, err := withdrawalPrefixes(conf.WithdrawalCredTypes, len(lock.Validators))
+	if err != nil {
+		return err
+	}
+
+	depositDatas, err := signDepositDatas(signers, withdrawalAddrs, prefixes, network)
+	if err != nil {
+		return err
+	}
+
+	b, err := deposit.MarshalDepositData(depositDatas, network)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range conf.ClusterDirs {
+		file := path.Join(dir, "deposit-data.json")
+
+		// Replace the existing read-only deposit data file.
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "remove deposit data", z.Str("file", file))
+		}
+
+		if err := os.WriteFile(file, b, 0o400); err != nil { // read-only
+			return errors.Wrap(err, "write deposit data", z.Str("file", file))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Regenerated deposit data of %d validators in %d node directories.\n",
+		len(depositDatas), len(conf.ClusterDirs))
+	_, _ = fmt.Fprintln(w, "Note the cluster lock still contains the previous withdrawal addresses and deposit data.")
+
+	return nil
+}
+
+// validateWithdrawalAddrsCount returns the withdrawal address of each validator given either a single address
+// or an address per validator.
+func validateWithdrawalAddrsCount(addrs []string, numVals int) ([]string, error) {
+	if len(addrs) != numVals && len(addrs) != 1 {
+		return nil, errors.New("invalid number of withdrawal addresses, expected a single address or exactly one per validator",
+			z.Int("addresses", len(addrs)), z.Int("validators", numVals))
+	}
+
+	return expandAddresses(numVals, addrs), nil
+}
+
+// thresholdSigners returns a signer per lock validator that threshold signs using the provided key shares.
+// It returns an error if less than threshold shares of any validator are provided.
+func thresholdSigners(lock cluster.Lock, shareSets [][]tblsv2.PrivateKey) ([]signer, error) {
+	// Map each public share to its validator and share index.
+	type shareIdx struct {
+		Val   int
+		Share int
+	}
+	idxs := make(map[tblsv2.PublicKey]shareIdx)
+	for i, val := range lock.Validators {
+		for j, share := range val.PubShares {
+			pubshare, err := tblsconv2.PubkeyFromBytes(share)
+			if err != nil {
+				return nil, err
+			}
+			idxs[pubshare] = shareIdx{Val: i, Share: j + 1} // Share indexes are 1-indexed.
+		}
+	}
+
+	signers := make([]thresholdSigner, len(lock.Validators))
+	for i, val := range lock.Validators {
+		pubkey, err := val.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = thresholdSigner{pubkey: pubkey, shares: make(map[int]tblsv2.PrivateKey)}
+	}
+
+	for i, shares := range shareSets {
+		for _, share := range shares {
+			pubshare, err := tblsv2.SecretToPublicKey(share)
+			if err != nil {
+				return nil, errors.Wrap(err, "secret to pubkey")
+			}
+
+			idx, ok := idxs[pubshare]
+			if !ok {
+				return nil, errors.New("key share not in cluster lock", z.Int("node_dir", i))
+			}
+			signers[idx.Val].shares[idx.Share] = share
+		}
+	}
+
+	var resp []signer
+	for i, s := range signers {
+		if len(s.shares) < lock.Threshold {
+			return nil, errors.New("insufficient key shares, the node directories of at least threshold operators are required",
+				z.Int("validator", i), z.Int("shares", len(s.shares)), z.Int("threshold", lock.Threshold))
+		}
+		resp = append(resp, s)
+	}
+
+	return resp, nil
+}
+
+// thresholdSigner is a signer that threshold aggregates partial signatures of key shares.
+type thresholdSigner struct {
+	pubkey tblsv2.PublicKey
+	shares map[int]tblsv2.PrivateKey // Key shares by share index.
+}
+
+func (s thresholdSigner) PublicKey() (tblsv2.PublicKey, error) {
+	return s.pubkey, nil
+}
+
+func (s thresholdSigner) Sign(msg []byte) (tblsv2.Signature, error) {
+	partials := make(map[int]tblsv2.Signature)
+	for idx, share := range s.shares {
+		sig, err := tblsv2.Sign(share, msg)
+		if err != nil {
+			return tblsv2.Signature{}, errors.Wrap(err, "sign")
+		}
+		partials[idx] = sig
+	}
+
+	sig, err := tblsv2.ThresholdAggregate(partials)
+	if err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "threshold aggregate")
+	}
+
+	// Verify the aggregate, since inconsistent key shares result in an invalid signature.
+	if err := tblsv2.Verify(s.pubkey, msg, sig); err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "verify threshold signature")
+	}
+
+	return sig, nil
+}
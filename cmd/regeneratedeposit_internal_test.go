@@ -0,0 +1,120 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+)
+
+func TestRegenerateDeposit(t *testing.T) {
+	const (
+		numVals   = 2
+		threshold = 3
+		numNodes  = 4
+		newAddr   = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+	)
+
+	lock, _, dvShares := cluster.NewForT(t, numVals, threshold, numNodes, 0)
+
+	// writeNodeDirs writes the lock and the key shares of the first n nodes to separate node directories.
+	writeNodeDirs := func(t *testing.T, n int) []string {
+		t.Helper()
+
+		var dirs []string
+		for i := 0; i < n; i++ {
+			dir := path.Join(t.TempDir(), "node")
+			require.NoError(t, os.MkdirAll(path.Join(dir, "validator_keys"), 0o755))
+			writeLockFile(t, path.Join(dir, "cluster-lock.json"), lock)
+
+			var shares []tblsv2.PrivateKey
+			for _, valShares := range dvShares {
+				shares = append(shares, valShares[i])
+			}
+			require.NoError(t, keystore.StoreKeysInsecure(shares, path.Join(dir, "validator_keys"), keystore.ConfirmInsecureKeys))
+
+			dirs = append(dirs, dir)
+		}
+
+		return dirs
+	}
+
+	t.Run("threshold nodes", func(t *testing.T) {
+		dirs := writeNodeDirs(t, threshold)
+
+		var buf bytes.Buffer
+		err := runRegenerateDeposit(context.Background(), &buf, regenerateDepositConfig{
+			ClusterDirs:     dirs,
+			WithdrawalAddrs: []string{newAddr},
+		})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "Regenerated deposit data of 2 validators in 3 node directories")
+
+		network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+		require.NoError(t, err)
+
+		for _, dir := range dirs {
+			b, err := os.ReadFile(path.Join(dir, "deposit-data.json"))
+			require.NoError(t, err)
+
+			var depositDatas []struct {
+				PubKey                string `json:"pubkey"`
+				WithdrawalCredentials string `json:"withdrawal_credentials"`
+				Signature             string `json:"signature"`
+			}
+			require.NoError(t, json.Unmarshal(b, &depositDatas))
+			require.Len(t, depositDatas, numVals)
+
+			// Deposit data is sorted by pubkey, so map pubkeys to lock validators.
+			vals := make(map[string]cluster.DistValidator)
+			for _, val := range lock.Validators {
+				vals[hex.EncodeToString(val.PubKey)] = val
+			}
+
+			for _, dd := range depositDatas {
+				val, ok := vals[dd.PubKey]
+				require.True(t, ok)
+
+				pubkey, err := val.PublicKey()
+				require.NoError(t, err)
+
+				msg, err := deposit.NewMessage(eth2p0.BLSPubKey(pubkey), newAddr)
+				require.NoError(t, err)
+				require.Equal(t, hex.EncodeToString(msg.WithdrawalCredentials), dd.WithdrawalCredentials)
+
+				sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+				require.NoError(t, err)
+
+				sigBytes, err := hex.DecodeString(dd.Signature)
+				require.NoError(t, err)
+				sig, err := tblsconv2.SignatureFromBytes(sigBytes)
+				require.NoError(t, err)
+				require.NoError(t, tblsv2.Verify(pubkey, sigRoot[:], sig))
+			}
+		}
+	})
+
+	t.Run("insufficient nodes", func(t *testing.T) {
+		err := runRegenerateDeposit(context.Background(), io.Discard, regenerateDepositConfig{
+			ClusterDirs:     writeNodeDirs(t, threshold-1),
+			WithdrawalAddrs: []string{newAddr},
+		})
+		require.ErrorContains(t, err, "insufficient key shares")
+	})
+}
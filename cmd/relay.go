@@ -34,6 +34,7 @@ func newRelayCmd(runFunc func(context.Context, relay.Config) error) *cobra.Comma
 	}
 
 	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &config.PrivKeyPasswordFile)
 	bindRelayFlag(cmd.Flags(), &config)
 	bindP2PFlags(cmd, &config.P2PConfig)
 	bindLogFlags(cmd.Flags(), &config.LogConfig)
@@ -31,15 +31,16 @@ import (
 
 // Config defines the config of the relay.
 type Config struct {
-	DataDir        string
-	HTTPAddr       string
-	MonitoringAddr string
-	P2PConfig      p2p.Config
-	LogConfig      log.Config
-	AutoP2PKey     bool
-	MaxResPerPeer  int
-	MaxConns       int
-	RelayLogLevel  string // TODO(corver): Rename to LibP2PLogLevel.
+	DataDir             string
+	PrivKeyPasswordFile string
+	HTTPAddr            string
+	MonitoringAddr      string
+	P2PConfig           p2p.Config
+	LogConfig           log.Config
+	AutoP2PKey          bool
+	MaxResPerPeer       int
+	MaxConns            int
+	RelayLogLevel       string // TODO(corver): Rename to LibP2PLogLevel.
 }
 
 // Run starts an Obol libp2p-tcp-relay and udp-discv5 bootnode.
@@ -51,7 +52,12 @@ func Run(ctx context.Context, config Config) error {
 
 	version.LogInfo(ctx, "Charon relay starting")
 
-	key, err := p2p.LoadPrivKey(config.DataDir)
+	password, err := p2p.LoadPrivKeyPassword(config.PrivKeyPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := p2p.LoadPrivKeyWithPassword(config.DataDir, password)
 	if errors.Is(err, os.ErrNotExist) {
 		if !config.AutoP2PKey {
 			return errors.New("charon-enr-private-key not found in data dir (run with --auto-p2pkey to auto generate)")
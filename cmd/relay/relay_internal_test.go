@@ -8,15 +8,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/p2p"
@@ -44,6 +48,37 @@ func TestRunBootnode(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunBootnodeEncryptedKey(t *testing.T) {
+	temp := t.TempDir()
+
+	key, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	require.NoError(t, k1util.SaveEncrypted(key, p2p.KeyPath(temp), "secret"))
+
+	passwordFile := filepath.Join(temp, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret"), 0o600))
+
+	config := Config{
+		DataDir:   temp,
+		LogConfig: log.DefaultConfig(),
+		P2PConfig: p2p.Config{TCPAddrs: []string{testutil.AvailableAddr(t).String()}},
+		HTTPAddr:  testutil.AvailableAddr(t).String(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Encrypted keys require the password.
+	t.Setenv(p2p.PrivKeyPasswordEnv, "")
+	err = Run(ctx, config)
+	require.ErrorContains(t, err, "decrypt private key")
+
+	config.PrivKeyPasswordFile = passwordFile
+	err = Run(ctx, config)
+	testutil.SkipIfBindErr(t, err)
+	require.NoError(t, err)
+}
+
 func TestRunBootnodeAutoP2P(t *testing.T) {
 	temp := t.TempDir()
 
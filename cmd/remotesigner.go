@@ -0,0 +1,118 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+// remoteSignerTimeout is the timeout of remote signer requests.
+const remoteSignerTimeout = 10 * time.Second
+
+// remoteSigner is a signer delegating signing to a Web3Signer style remote signer, e.g. backed by an HSM,
+// holding the private key of the public key.
+type remoteSigner struct {
+	baseURL string
+	pubkey  tblsv2.PublicKey
+}
+
+// remoteSignReq is the remote signer sign request body.
+type remoteSignReq struct {
+	Type        string `json:"type"`
+	SigningRoot string `json:"signingRoot"`
+}
+
+// remoteSignResp is the remote signer sign response body.
+type remoteSignResp struct {
+	Signature string `json:"signature"`
+}
+
+func (s remoteSigner) PublicKey() (tblsv2.PublicKey, error) {
+	return s.pubkey, nil
+}
+
+// Sign requests the signature of the signing root from the remote signer via POST /api/v1/eth2/sign/{pubkey}
+// and returns it after verifying it against the public key.
+func (s remoteSigner) Sign(msg []byte) (tblsv2.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer cancel()
+
+	addr, err := url.JoinPath(s.baseURL, "/api/v1/eth2/sign", fmt.Sprintf("%#x", s.pubkey))
+	if err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "invalid remote signer url", z.Str("url", s.baseURL))
+	}
+
+	reqBody, err := json.Marshal(remoteSignReq{Type: "DEPOSIT", SigningRoot: fmt.Sprintf("%#x", msg)})
+	if err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "marshal remote sign request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, bytes.NewReader(reqBody))
+	if err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "new remote sign request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "remote sign request")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "read remote sign response")
+	} else if resp.StatusCode/100 != 2 {
+		return tblsv2.Signature{}, errors.New("remote sign request failed",
+			z.Int("status", resp.StatusCode), z.Str("body", string(respBody)))
+	}
+
+	var signResp remoteSignResp
+	if err := json.Unmarshal(respBody, &signResp); err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "unmarshal remote sign response")
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(signResp.Signature, "0x"))
+	if err != nil || len(b) != len(tblsv2.Signature{}) {
+		return tblsv2.Signature{}, errors.New("invalid remote signature length")
+	}
+
+	var sig tblsv2.Signature
+	copy(sig[:], b)
+
+	// Verify the signature, since an invalid deposit signature is only detected once depositing.
+	if err := tblsv2.Verify(s.pubkey, msg, sig); err != nil {
+		return tblsv2.Signature{}, errors.Wrap(err, "invalid remote signature", z.Str("pubkey", fmt.Sprintf("%#x", s.pubkey)))
+	}
+
+	return sig, nil
+}
+
+// remoteSigners returns remote signers of the secrets' public keys. The remote signer must hold the secrets.
+func remoteSigners(baseURL string, secrets []tblsv2.PrivateKey) ([]signer, error) {
+	var resp []signer
+	for _, secret := range secrets {
+		pubkey, err := tblsv2.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, remoteSigner{baseURL: baseURL, pubkey: pubkey})
+	}
+
+	return resp, nil
+}
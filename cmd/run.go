@@ -16,6 +16,7 @@ import (
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core/consensus"
 	"github.com/obolnetwork/charon/p2p"
 )
 
@@ -39,7 +40,9 @@ func newRunCmd(runFunc func(context.Context, app.Config) error) *cobra.Command {
 	}
 
 	bindPrivKeyFlag(cmd, &conf.PrivKeyFile)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &conf.PrivKeyPasswordFile)
 	bindRunFlags(cmd, &conf)
+	bindLockPasswordFlag(cmd.Flags(), &conf.LockPasswordFile)
 	bindNoVerifyFlag(cmd.Flags(), &conf.NoVerify)
 	bindP2PFlags(cmd, &conf.P2P)
 	bindLogFlags(cmd.Flags(), &conf.Log)
@@ -64,8 +67,16 @@ func bindNoVerifyFlag(flags *pflag.FlagSet, config *bool) {
 func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster.")
 	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
+	cmd.Flags().StringSliceVar(&config.BeaconNodeAPIAllowlist, "beacon-node-api-allowlist", nil, "Optional comma separated list of beacon node API methods (e.g. attestation_data,submit_attestations) that may be called, all other calls are rejected. Defaults to allowing all methods.")
 	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API.")
 	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "127.0.0.1:3620", "Listening address (ip and port) for the monitoring API (prometheus, pprof).")
+	cmd.Flags().StringVar(&config.MonitoringNamespace, "monitoring-namespace", "", "Optional prefix of all prometheus metric names, useful when integrating into an existing metrics taxonomy.")
+	cmd.Flags().Float64SliceVar(&config.ConsensusBuckets, "monitoring-consensus-buckets", nil, "Optional comma-separated list of consensus duration histogram buckets in seconds, in increasing order. Defaults to buckets between 50ms and 60s.")
+	bindRoundTimeoutFlags(cmd.Flags(), &config.ConsensusRoundTimeout)
+	cmd.Flags().Float64Var(&config.ConsensusBroadcastLimit, "consensus-broadcast-limit", consensus.DefaultBroadcastLimit, "Maximum sustained rate of consensus messages per second broadcast to each peer. Messages exceeding the limit are dropped.")
+	cmd.Flags().IntVar(&config.ConsensusBroadcastBurst, "consensus-broadcast-burst", consensus.DefaultBroadcastBurst, "Maximum burst of consensus messages broadcast to each peer.")
+	cmd.Flags().IntVar(&config.ConsensusRecvBuffer, "consensus-recv-buffer", 0, "Size of the consensus message receive buffer of each instance. Defaults to scaling with the number of nodes.")
+	cmd.Flags().IntVar(&config.ConsensusSilentPeers, "consensus-silent-peer-instances", consensus.DefaultSilentPeerInstances, "Number of consecutive consensus instances a peer must send no messages in, until the instance deadline, before it is reported as silent.")
 	cmd.Flags().StringVar(&config.JaegerAddr, "jaeger-address", "", "Listening address for jaeger tracing.")
 	cmd.Flags().StringVar(&config.JaegerService, "jaeger-service", "charon", "Service name used for jaeger tracing.")
 	cmd.Flags().BoolVar(&config.SimnetBMock, "simnet-beacon-mock", false, "Enables an internal mock beacon node for running a simnet.")
@@ -74,6 +85,10 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().BoolVar(&config.BuilderAPI, "builder-api", false, "Enables the builder api. Will only produce builder blocks. Builder API must also be enabled on the validator client. Beacon node must be connected to a builder-relay to access the builder network.")
 	cmd.Flags().BoolVar(&config.SyntheticBlockProposals, "synthetic-block-proposals", false, "Enables additional synthetic block proposal duties. Used for testing of rare duties.")
 	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
+	cmd.Flags().Uint64Var(&config.MinFreeDiskMB, "min-free-disk-mb", 0, "Optional minimum free disk space in MB of the directory containing the cluster lock file, below which the node is reported as not ready. Zero disables the check.")
+	cmd.Flags().StringSliceVar(&config.DisabledValidators, "disabled-validators", nil, "Comma separated list of validator public keys whose duties are not scheduled, without removing their keys. Validators can also be enabled or disabled at runtime via the monitoring API /admin/validators endpoint if admin-api is enabled.")
+	cmd.Flags().BoolVar(&config.AdminAPI, "admin-api", false, "Enables enabling or disabling validators at runtime via POST requests to the monitoring API /admin/validators endpoint. Only enable if the monitoring address isn't publicly accessible.")
+	cmd.Flags().BoolVar(&config.Observer, "observer", false, "Enables observer mode. The node never produces or broadcasts partial signatures and doesn't require key shares or a validator client. It participates in the cluster network and consensus as a non-voting peer. The operator must be marked as observer in the cluster lock, see --num-observers.")
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, args []string) error {
 		if len(config.BeaconNodeAddrs) == 0 && !config.SimnetBMock {
@@ -84,10 +99,26 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	})
 }
 
+func bindRoundTimeoutFlags(flags *pflag.FlagSet, timeout *consensus.RoundTimeout) {
+	def := consensus.DefaultRoundTimeout()
+	flags.DurationVar(&timeout.Base, "consensus-round-timeout-base", def.Base, "Consensus round timeout of round zero. Round timeouts grow as timeout(r) = min(max, timeout(r-1)*multiplier + increase).")
+	flags.DurationVar(&timeout.Increase, "consensus-round-timeout-increase", def.Increase, "Linear increase of the consensus round timeout per round.")
+	flags.Float64Var(&timeout.Multiplier, "consensus-round-timeout-multiplier", def.Multiplier, "Exponential growth factor of the consensus round timeout per round, 1 for linear growth.")
+	flags.DurationVar(&timeout.Max, "consensus-round-timeout-max", def.Max, "Maximum consensus round timeout, zero for no cap.")
+}
+
 func bindPrivKeyFlag(cmd *cobra.Command, privKeyFile *string) {
 	cmd.Flags().StringVar(privKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
 }
 
+func bindPrivKeyPasswordFlag(flags *pflag.FlagSet, passwordFile *string) {
+	flags.StringVar(passwordFile, "private-key-password-file", "", "The path to a file containing the password of an encrypted charon enr private key. Defaults to the "+p2p.PrivKeyPasswordEnv+" environment variable if not set.")
+}
+
+func bindLockPasswordFlag(flags *pflag.FlagSet, passwordFile *string) {
+	flags.StringVar(passwordFile, "lock-password-file", "", "Optional path to a file containing the password of the cluster lock encrypted at rest. The cluster lock is not encrypted if not set.")
+}
+
 func bindLogFlags(flags *pflag.FlagSet, config *log.Config) {
 	flags.StringVar(&config.Format, "log-format", "console", "Log format; console, logfmt or json")
 	flags.StringVar(&config.Level, "log-level", "info", "Log level; debug, info, warn or error")
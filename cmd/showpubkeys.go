@@ -0,0 +1,123 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+)
+
+type showPubkeysConfig struct {
+	LockFile         string
+	LockPasswordFile string
+	Aggregate        bool
+	Verify           bool
+}
+
+func newShowPubkeysCmd(runFunc func(context.Context, io.Writer, showPubkeysConfig) error) *cobra.Command {
+	var conf showPubkeysConfig
+
+	cmd := &cobra.Command{
+		Use:   "pubkeys",
+		Short: "Show the validator public keys",
+		Long: "Prints the public shares of each distributed validator in the cluster lock file, one validator per line. " +
+			"With --aggregate, the aggregate group public key of each validator is printed instead.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindShowPubkeysFlags(cmd.Flags(), &conf)
+
+	return cmd
+}
+
+func bindShowPubkeysFlags(flags *pflag.FlagSet, config *showPubkeysConfig) {
+	flags.StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster.")
+	bindLockPasswordFlag(flags, &config.LockPasswordFile)
+	flags.BoolVar(&config.Aggregate, "aggregate", false, "Print the aggregate group public key of each validator instead of its public shares.")
+	flags.BoolVar(&config.Verify, "verify", false, "Verify that the aggregate group public key of each validator is reconstructed from its public shares.")
+}
+
+// runShowPubkeys writes the public shares or aggregate public keys of the validators in the configured lock file.
+func runShowPubkeys(_ context.Context, w io.Writer, conf showPubkeysConfig) error {
+	lock, err := readLockFile(conf.LockFile, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	for i, val := range lock.Validators {
+		if conf.Verify {
+			if err := verifyAggregatePubkey(val, lock.Threshold); err != nil {
+				return errors.Wrap(err, "verify aggregate public key", z.Int("validator_index", i))
+			}
+		}
+
+		if conf.Aggregate {
+			_, _ = fmt.Fprintln(w, val.PublicKeyHex())
+			continue
+		}
+
+		var pubshares []string
+		for _, pubshare := range val.PubShares {
+			pubshares = append(pubshares, fmt.Sprintf("%#x", pubshare))
+		}
+
+		_, _ = fmt.Fprintln(w, strings.Join(pubshares, " "))
+	}
+
+	return nil
+}
+
+// verifyAggregatePubkey returns an error if the validator group public key isn't reconstructed from
+// both a threshold and the full set of its public shares.
+func verifyAggregatePubkey(val cluster.DistValidator, threshold int) error {
+	pubkey, err := val.PublicKey()
+	if err != nil {
+		return err
+	}
+
+	if len(val.PubShares) < threshold {
+		return errors.New("insufficient public shares", z.Int("public_shares", len(val.PubShares)), z.Int("threshold", threshold))
+	}
+
+	pubshares := make(map[int]tblsv2.PublicKey)
+	for peerIdx, b := range val.PubShares {
+		pubshare, err := tblsconv2.PubkeyFromBytes(b)
+		if err != nil {
+			return err
+		}
+		pubshares[peerIdx+1] = pubshare // Share indexes are 1-indexed.
+	}
+
+	thresholdShares := make(map[int]tblsv2.PublicKey)
+	for shareIdx := 1; shareIdx <= threshold; shareIdx++ {
+		thresholdShares[shareIdx] = pubshares[shareIdx]
+	}
+
+	for _, shares := range []map[int]tblsv2.PublicKey{thresholdShares, pubshares} {
+		recovered, err := tblsv2.RecoverPublicKey(shares)
+		if err != nil {
+			return err
+		}
+
+		if recovered != pubkey {
+			return errors.New("public shares inconsistent with group public key",
+				z.Str("expected", val.PublicKeyHex()), z.Str("recovered", fmt.Sprintf("%#x", recovered)))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestShowPubkeys(t *testing.T) {
+	const (
+		numVals  = 3
+		numNodes = 4
+	)
+
+	lock, _, _ := cluster.NewForT(t, numVals, 3, numNodes, 0)
+
+	file := path.Join(t.TempDir(), "cluster-lock.json")
+	writeLockFile(t, file, lock)
+
+	t.Run("aggregate", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runShowPubkeys(context.Background(), &buf, showPubkeysConfig{LockFile: file, Aggregate: true, Verify: true})
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, numVals)
+		for i, val := range lock.Validators {
+			require.Equal(t, val.PublicKeyHex(), lines[i])
+		}
+	})
+
+	t.Run("pubshares", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runShowPubkeys(context.Background(), &buf, showPubkeysConfig{LockFile: file})
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, numVals)
+		for i, val := range lock.Validators {
+			pubshares := strings.Fields(lines[i])
+			require.Len(t, pubshares, numNodes)
+			for j, pubshare := range val.PubShares {
+				require.Equal(t, fmt.Sprintf("%#x", pubshare), pubshares[j])
+			}
+		}
+	})
+
+	t.Run("inconsistent pubshares", func(t *testing.T) {
+		lock := lock
+		lock.Validators = append([]cluster.DistValidator(nil), lock.Validators...)
+		lock.Validators[1].PubShares = append([][]byte(nil), lock.Validators[1].PubShares...)
+		shares := lock.Validators[1].PubShares
+		shares[0], shares[1] = shares[1], shares[0]
+
+		file := path.Join(t.TempDir(), "cluster-lock.json")
+		writeLockFile(t, file, lock)
+
+		var buf bytes.Buffer
+		err := runShowPubkeys(context.Background(), &buf, showPubkeysConfig{LockFile: file, Aggregate: true, Verify: true})
+		require.ErrorContains(t, err, "public shares inconsistent with group public key")
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+)
+
+type submitDepositsConfig struct {
+	DepositDataFile   string
+	ExecutionEndpoint string
+	KeystoreFile      string
+	PasswordFile      string
+	FromBlock         uint64
+	DryRun            bool
+	ConfirmTimeout    time.Duration
+}
+
+func newSubmitDepositsCmd(runFunc func(context.Context, io.Writer, submitDepositsConfig) error) *cobra.Command {
+	var conf submitDepositsConfig
+
+	cmd := &cobra.Command{
+		Use:   "deposits",
+		Short: "Submit deposit data to the deposit contract",
+		Long: "Submits a transaction to the deposit contract for each validator in the deposit data file, skipping already deposited validators. " +
+			"WARNING: Each deposit irreversibly transfers the deposit amount (32 ETH) from the funding account. Use --dry-run first.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindSubmitDepositsFlags(cmd, &conf)
+
+	return cmd
+}
+
+func bindSubmitDepositsFlags(cmd *cobra.Command, config *submitDepositsConfig) {
+	bindSubmitDepositsFileFlags(cmd.Flags(), config)
+	mustMarkFlagRequired(cmd, "deposit-data-file")
+	mustMarkFlagRequired(cmd, "execution-endpoint")
+	mustMarkFlagRequired(cmd, "funding-keystore-file")
+	mustMarkFlagRequired(cmd, "funding-password-file")
+}
+
+func bindSubmitDepositsFileFlags(flags *pflag.FlagSet, config *submitDepositsConfig) {
+	flags.StringVar(&config.DepositDataFile, "deposit-data-file", "", "The path to the deposit data file to submit.")
+	flags.StringVar(&config.ExecutionEndpoint, "execution-endpoint", "", "The execution layer JSON-RPC endpoint URL used to submit deposit transactions.")
+	flags.StringVar(&config.KeystoreFile, "funding-keystore-file", "", "The path to the encrypted Ethereum (web3 secret storage) keystore file of the account funding the deposits.")
+	flags.StringVar(&config.PasswordFile, "funding-password-file", "", "The path to the file containing the password of the funding account keystore.")
+	flags.Uint64Var(&config.FromBlock, "from-block", 0, "The block from which to search for existing deposits of the validators. Defaults to the deposit contract deployment block of known networks.")
+	flags.BoolVar(&config.DryRun, "dry-run", false, "Verifies the deposit data and logs the deposits that would be submitted without submitting any transactions.")
+	flags.DurationVar(&config.ConfirmTimeout, "confirm-timeout", 5*time.Minute, "The maximum time to wait for each deposit transaction to be included.")
+}
+
+// runSubmitDeposits submits the deposits in the configured deposit data file to the deposit contract.
+func runSubmitDeposits(ctx context.Context, w io.Writer, conf submitDepositsConfig) error {
+	depositData, err := os.ReadFile(conf.DepositDataFile)
+	if err != nil {
+		return errors.Wrap(err, "read deposit data file")
+	}
+
+	key, err := loadFundingKey(conf.KeystoreFile, conf.PasswordFile)
+	if err != nil {
+		return err
+	}
+
+	if !conf.DryRun {
+		log.Warn(ctx, "Submitting deposits irreversibly transfers funds from the funding account, "+
+			"ensure the deposit data withdrawal credentials are correct", nil)
+	}
+
+	results, err := deposit.Submit(ctx, depositData, deposit.SubmitConfig{
+		Endpoint:       conf.ExecutionEndpoint,
+		PrivKey:        key.PrivateKey,
+		FromBlock:      conf.FromBlock,
+		DryRun:         conf.DryRun,
+		ConfirmTimeout: conf.ConfirmTimeout,
+		PollInterval:   time.Second * 5,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		switch {
+		case res.Skipped:
+			_, _ = fmt.Fprintf(w, "%s: already deposited\n", res.PubKey)
+		case res.TxHash == "":
+			_, _ = fmt.Fprintf(w, "%s: dry-run\n", res.PubKey)
+		default:
+			_, _ = fmt.Fprintf(w, "%s: deposited in %s\n", res.PubKey, res.TxHash)
+		}
+	}
+
+	return nil
+}
+
+// loadFundingKey returns the funding account key decrypted from the keystore file with the password file contents.
+func loadFundingKey(keystoreFile, passwordFile string) (*keystore.Key, error) {
+	keyJSON, err := os.ReadFile(keystoreFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "read funding keystore file")
+	}
+
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "read funding password file")
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, strings.TrimSpace(string(password)))
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt funding keystore")
+	}
+
+	return key, nil
+}
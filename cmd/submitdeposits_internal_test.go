@@ -0,0 +1,43 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFundingKey(t *testing.T) {
+	privkey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privkey.PublicKey),
+		PrivateKey: privkey,
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, "password", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keystoreFile := filepath.Join(dir, "keystore.json")
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(keystoreFile, keyJSON, 0o600))
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0o600))
+
+	loaded, err := loadFundingKey(keystoreFile, passwordFile)
+	require.NoError(t, err)
+	require.Equal(t, key.Address, loaded.Address)
+	require.True(t, privkey.Equal(loaded.PrivateKey))
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("wrong"), 0o600))
+	_, err = loadFundingKey(keystoreFile, passwordFile)
+	require.ErrorContains(t, err, "decrypt funding keystore")
+}
@@ -0,0 +1,116 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keymanager"
+)
+
+const (
+	keymanagerReachable = "reachable"
+	keymanagerAuthOK    = "auth-ok"
+	keymanagerFailed    = "failed"
+)
+
+type testKeymanagerConfig struct {
+	Addrs      []string
+	AuthTokens []string
+}
+
+// keymanagerResult is the connectivity test result of a single keymanager.
+type keymanagerResult struct {
+	Addr   string
+	Status string
+	Err    error
+}
+
+func newTestKeymanagerCmd(runFunc func(context.Context, io.Writer, testKeymanagerConfig) error) *cobra.Command {
+	var conf testKeymanagerConfig
+
+	cmd := &cobra.Command{
+		Use:   "keymanager",
+		Short: "Test connectivity to keymanager endpoints",
+		Long: "Verifies that all keymanager endpoints are reachable and, if auth tokens are provided, authenticated, " +
+			"without importing any keys. Useful as a pre-flight check before creating a cluster or running a DKG.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindTestKeymanagerFlags(cmd.Flags(), &conf)
+
+	return cmd
+}
+
+func bindTestKeymanagerFlags(flags *pflag.FlagSet, config *testKeymanagerConfig) {
+	flags.StringSliceVar(&config.Addrs, "keymanager-addresses", nil, "Comma-separated list of keymanager URLs to test.")
+	flags.StringSliceVar(&config.AuthTokens, "auth-tokens", nil, "Optional comma-separated list of keymanager authentication bearer tokens, one per keymanager address.")
+}
+
+// runTestKeymanager tests the connectivity of the configured keymanagers and writes a status table to w.
+// It returns an error if any keymanager failed.
+func runTestKeymanager(ctx context.Context, w io.Writer, conf testKeymanagerConfig) error {
+	if len(conf.Addrs) == 0 {
+		return errors.New("no keymanager addresses provided")
+	} else if len(conf.AuthTokens) > 0 && len(conf.AuthTokens) != len(conf.Addrs) {
+		return errors.New("number of auth tokens doesn't match keymanager addresses",
+			z.Int("auth_tokens", len(conf.AuthTokens)), z.Int("addresses", len(conf.Addrs)))
+	}
+
+	var failed int
+	_, _ = fmt.Fprintf(w, "%-40s %-10s %s\n", "ADDRESS", "STATUS", "ERROR")
+	for _, res := range testKeymanagers(ctx, conf) {
+		var errMsg string
+		if res.Err != nil {
+			errMsg = res.Err.Error()
+			failed++
+		}
+		_, _ = fmt.Fprintf(w, "%-40s %-10s %s\n", res.Addr, res.Status, errMsg)
+	}
+
+	if failed > 0 {
+		return errors.New("keymanager test failed", z.Int("failed", failed))
+	}
+
+	return nil
+}
+
+// testKeymanagers returns the connectivity test result of each configured keymanager.
+func testKeymanagers(ctx context.Context, conf testKeymanagerConfig) []keymanagerResult {
+	var results []keymanagerResult
+	for i, addr := range conf.Addrs {
+		var authToken string
+		if len(conf.AuthTokens) > 0 {
+			authToken = conf.AuthTokens[i]
+		}
+
+		cl := keymanager.New(addr, authToken)
+
+		res := keymanagerResult{Addr: addr, Status: keymanagerReachable}
+		if err := cl.VerifyConnection(ctx); err != nil {
+			res.Status = keymanagerFailed
+			res.Err = err
+		} else if authToken != "" {
+			if err := cl.VerifyAuth(ctx); err != nil {
+				res.Status = keymanagerFailed
+				res.Err = err
+			} else {
+				res.Status = keymanagerAuthOK
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	return results
+}
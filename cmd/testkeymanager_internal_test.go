@@ -0,0 +1,61 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/keymanager"
+)
+
+func TestTestKeymanager(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	// Closed server is unreachable.
+	closed := httptest.NewServer(nil)
+	closed.Close()
+
+	conf := testKeymanagerConfig{
+		Addrs:      []string{srv.URL, srv.URL, srv.URL, closed.URL},
+		AuthTokens: []string{"token", "wrong", "", "token"},
+	}
+
+	results := testKeymanagers(context.Background(), conf)
+	require.Len(t, results, 4)
+
+	require.Equal(t, keymanagerAuthOK, results[0].Status)
+	require.NoError(t, results[0].Err)
+
+	require.Equal(t, keymanagerFailed, results[1].Status)
+	require.ErrorIs(t, results[1].Err, keymanager.ErrUnauthorized)
+
+	require.Equal(t, keymanagerReachable, results[2].Status)
+	require.NoError(t, results[2].Err)
+
+	require.Equal(t, keymanagerFailed, results[3].Status)
+	require.ErrorContains(t, results[3].Err, "cannot ping address")
+
+	var buf bytes.Buffer
+	err := runTestKeymanager(context.Background(), &buf, conf)
+	require.ErrorContains(t, err, "keymanager test failed")
+	require.Contains(t, buf.String(), keymanagerAuthOK)
+
+	conf = testKeymanagerConfig{Addrs: []string{srv.URL}, AuthTokens: []string{"token"}}
+	require.NoError(t, runTestKeymanager(context.Background(), &buf, conf))
+
+	conf = testKeymanagerConfig{Addrs: []string{srv.URL}, AuthTokens: []string{"token", "token"}}
+	require.ErrorContains(t, runTestKeymanager(context.Background(), &buf, conf), "number of auth tokens")
+}
@@ -0,0 +1,222 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+type testP2PConfig struct {
+	LocalENR            string
+	PrivKeyPasswordFile string
+	PeerENR             string
+	Timeout             time.Duration
+}
+
+// p2pResult is the connectivity test result of a single peer.
+type p2pResult struct {
+	Peer       p2p.Peer
+	Latency    time.Duration
+	ConnState  network.ConnectionState
+	RemoteAddr ma.Multiaddr
+	Protocols  []protocol.ID
+	Err        error
+}
+
+func newTestP2PCmd(runFunc func(context.Context, io.Writer, testP2PConfig) error) *cobra.Command {
+	var conf testP2PConfig
+
+	cmd := &cobra.Command{
+		Use:   "p2p",
+		Short: "Test libp2p connectivity to a peer ENR",
+		Long: "Attempts a libp2p connection from this node to the peer ENR and reports the latency and negotiated protocols, " +
+			"or a detailed failure. Useful to isolate NAT or firewall issues from consensus issues. " +
+			"The local charon enr private key is used, since charon nodes only accept connections from cluster peers.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindTestP2PFlags(cmd.Flags(), &conf)
+	bindPrivKeyPasswordFlag(cmd.Flags(), &conf.PrivKeyPasswordFile)
+	mustMarkFlagRequired(cmd, "peer-enr")
+
+	return cmd
+}
+
+func bindTestP2PFlags(flags *pflag.FlagSet, config *testP2PConfig) {
+	flags.StringVar(&config.LocalENR, "local-enr", ".charon/charon-enr-private-key", "The path to the charon enr private key file of this node.")
+	flags.StringVar(&config.PeerENR, "peer-enr", "", "The ENR of the peer to connect to. It must contain an ip and tcp port.")
+	flags.DurationVar(&config.Timeout, "timeout", 10*time.Second, "Timeout of the connection attempt.")
+}
+
+// runTestP2P tests the libp2p connectivity to the configured peer ENR and writes a report to w.
+// It returns an error if the peer is unreachable.
+func runTestP2P(ctx context.Context, w io.Writer, conf testP2PConfig) error {
+	password, err := p2p.LoadPrivKeyPassword(conf.PrivKeyPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := k1util.LoadWithPassword(conf.LocalENR, password)
+	if err != nil {
+		return errors.Wrap(err, "load local enr private key", z.Str("file", conf.LocalENR))
+	}
+
+	record, err := enr.Parse(conf.PeerENR)
+	if err != nil {
+		return errors.Wrap(err, "decode peer enr")
+	}
+
+	peer, err := p2p.NewPeerFromENR(record, 0)
+	if err != nil {
+		return err
+	}
+
+	peer.Addrs, err = p2p.AddrsFromENR(record)
+	if err != nil {
+		return err
+	} else if len(peer.Addrs) == 0 {
+		return errors.New("peer enr doesn't contain an ip and tcp port")
+	}
+
+	tcpNode, err := newTestP2PNode(key)
+	if err != nil {
+		return err
+	}
+	defer tcpNode.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, conf.Timeout)
+	defer cancel()
+
+	res := testP2PConnection(ctx, tcpNode, peer)
+	writeP2PResult(w, tcpNode, res)
+
+	if res.Err != nil {
+		return errors.New("p2p test failed")
+	}
+
+	return nil
+}
+
+// newTestP2PNode returns a new libp2p host with the provided identity that doesn't listen on any address.
+func newTestP2PNode(key *k1.PrivateKey) (host.Host, error) {
+	tcpNode, err := libp2p.New(
+		libp2p.Identity((*crypto.Secp256k1PrivateKey)(key)),
+		libp2p.NoListenAddrs,
+		libp2p.UserAgent("obolnetwork-charon/"+version.Version),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "new libp2p node")
+	}
+
+	return tcpNode, nil
+}
+
+// testP2PConnection connects to the peer and returns the connection details, latency and supported protocols of the peer.
+func testP2PConnection(ctx context.Context, tcpNode host.Host, peer p2p.Peer) p2pResult {
+	res := p2pResult{Peer: peer}
+
+	if err := tcpNode.Connect(ctx, peer.AddrInfo()); err != nil {
+		res.Err = errors.Wrap(err, "dial peer")
+		return res
+	}
+
+	conns := tcpNode.Network().ConnsToPeer(peer.ID)
+	if len(conns) == 0 {
+		res.Err = errors.New("connection closed by peer")
+		return res
+	}
+
+	conn := conns[0]
+	res.ConnState = conn.ConnState()
+	res.RemoteAddr = conn.RemoteMultiaddr()
+
+	// Wait for identify to complete, so the peer's supported protocols are known.
+	if ids, ok := tcpNode.(interface{ IDService() identify.IDService }); ok {
+		select {
+		case <-ctx.Done():
+			res.Err = errors.Wrap(ctx.Err(), "identify peer")
+			return res
+		case <-ids.IDService().IdentifyWait(conn):
+		}
+	}
+
+	protocols, err := tcpNode.Peerstore().GetProtocols(peer.ID)
+	if err != nil {
+		res.Err = errors.Wrap(err, "get peer protocols")
+		return res
+	}
+	sort.Slice(protocols, func(i, j int) bool {
+		return protocols[i] < protocols[j]
+	})
+	res.Protocols = protocols
+
+	select {
+	case <-ctx.Done():
+		res.Err = errors.Wrap(ctx.Err(), "ping peer")
+	case result := <-ping.Ping(ctx, tcpNode, peer.ID):
+		if result.Error != nil {
+			res.Err = errors.Wrap(result.Error, "ping peer")
+		}
+		res.Latency = result.RTT
+	}
+
+	return res
+}
+
+// writeP2PResult writes a human-readable report of the p2p test result to w.
+func writeP2PResult(w io.Writer, tcpNode host.Host, res p2pResult) {
+	line := func(key string, val any) {
+		_, _ = fmt.Fprintf(w, "%-20s %v\n", key, val)
+	}
+
+	line("LOCAL PEER", fmt.Sprintf("%s (%s)", p2p.PeerName(tcpNode.ID()), tcpNode.ID()))
+	line("PEER", fmt.Sprintf("%s (%s)", res.Peer.Name, res.Peer.ID))
+	line("PEER ADDRESSES", res.Peer.Addrs)
+
+	if res.RemoteAddr != nil {
+		line("REMOTE ADDRESS", res.RemoteAddr)
+		line("TRANSPORT", res.ConnState.Transport)
+		line("SECURITY", res.ConnState.Security)
+		line("MUXER", res.ConnState.StreamMultiplexer)
+	}
+
+	if len(res.Protocols) > 0 {
+		line("PROTOCOLS", res.Protocols)
+	}
+
+	if res.Err != nil {
+		line("STATUS", "failed")
+		line("ERROR", res.Err.Error())
+
+		return
+	}
+
+	line("LATENCY", res.Latency)
+	line("STATUS", "success")
+}
@@ -0,0 +1,112 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestTestP2P(t *testing.T) {
+	localKey := testutil.GenerateInsecureK1Key(t, 1)
+	localKeyFile := path.Join(t.TempDir(), "charon-enr-private-key")
+	require.NoError(t, k1util.Save(localKey, localKeyFile))
+
+	peerKey := testutil.GenerateInsecureK1Key(t, 2)
+
+	t.Run("success", func(t *testing.T) {
+		stub := newStubP2PPeer(t, peerKey)
+		defer stub.Close()
+
+		var buf bytes.Buffer
+		err := runTestP2P(context.Background(), &buf, testP2PConfig{
+			LocalENR: localKeyFile,
+			PeerENR:  stubPeerENR(t, peerKey, stub),
+			Timeout:  10 * time.Second,
+		})
+		require.NoError(t, err)
+
+		out := buf.String()
+		require.Contains(t, out, "success")
+		require.Contains(t, out, stub.ID().String())
+		require.Contains(t, out, "LATENCY")
+		require.Contains(t, out, "/ipfs/ping/1.0.0")
+		require.Contains(t, out, "tcp")
+		require.NotContains(t, out, "ERROR")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		stub := newStubP2PPeer(t, peerKey)
+		peerENR := stubPeerENR(t, peerKey, stub)
+		require.NoError(t, stub.Close())
+
+		var buf bytes.Buffer
+		err := runTestP2P(context.Background(), &buf, testP2PConfig{
+			LocalENR: localKeyFile,
+			PeerENR:  peerENR,
+			Timeout:  10 * time.Second,
+		})
+		require.ErrorContains(t, err, "p2p test failed")
+
+		out := buf.String()
+		require.Contains(t, out, "failed")
+		require.Contains(t, out, "dial peer")
+		require.NotContains(t, out, "LATENCY")
+	})
+
+	t.Run("no addresses", func(t *testing.T) {
+		record, err := enr.New(peerKey)
+		require.NoError(t, err)
+
+		err = runTestP2P(context.Background(), new(bytes.Buffer), testP2PConfig{
+			LocalENR: localKeyFile,
+			PeerENR:  record.String(),
+			Timeout:  time.Second,
+		})
+		require.ErrorContains(t, err, "peer enr doesn't contain an ip and tcp port")
+	})
+}
+
+// newStubP2PPeer returns a libp2p host listening on a random local tcp port.
+func newStubP2PPeer(t *testing.T, key *k1.PrivateKey) host.Host {
+	t.Helper()
+
+	h, err := libp2p.New(
+		libp2p.Identity((*crypto.Secp256k1PrivateKey)(key)),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+	)
+	require.NoError(t, err)
+
+	return h
+}
+
+// stubPeerENR returns the ENR of the stub peer advertising its local tcp address.
+func stubPeerENR(t *testing.T, key *k1.PrivateKey, h host.Host) string {
+	t.Helper()
+
+	port, err := h.Addrs()[0].ValueForProtocol(ma.P_TCP)
+	require.NoError(t, err)
+	tcpPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	record, err := enr.New(key, enr.WithIP(net.IPv4(127, 0, 0, 1)), enr.WithTCP(tcpPort))
+	require.NoError(t, err)
+
+	return record.String()
+}
@@ -0,0 +1,211 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+type testPerformanceConfig struct {
+	Duration     time.Duration
+	SlotDuration time.Duration
+	Nodes        int
+	Threshold    int
+}
+
+// perfResult is the benchmark result of a single tbls operation.
+type perfResult struct {
+	Name      string
+	OpsPerSec float64
+}
+
+func newTestPerformanceCmd(runFunc func(context.Context, io.Writer, testPerformanceConfig) error) *cobra.Command {
+	var conf testPerformanceConfig
+
+	cmd := &cobra.Command{
+		Use:   "performance",
+		Short: "Benchmark the threshold BLS operations on this machine",
+		Long:  "Benchmarks the threshold BLS signing operations used by charon and estimates the maximum number of validators this machine can serve per slot.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindTestPerformanceFlags(cmd.Flags(), &conf)
+
+	return cmd
+}
+
+func bindTestPerformanceFlags(flags *pflag.FlagSet, config *testPerformanceConfig) {
+	flags.DurationVar(&config.Duration, "duration", time.Second*2, "Duration to benchmark each operation.")
+	flags.DurationVar(&config.SlotDuration, "slot-duration", time.Second*12, "Slot duration used to estimate the maximum number of validators.")
+	flags.IntVar(&config.Nodes, "nodes", 4, "The number of charon nodes in the cluster.")
+	flags.IntVar(&config.Threshold, "threshold", 3, "The threshold of the cluster.")
+}
+
+// runTestPerformance benchmarks the tbls operations and writes the results to w.
+func runTestPerformance(ctx context.Context, w io.Writer, conf testPerformanceConfig) error {
+	results, err := benchmarkTBLS(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "%-20s %15s\n", "OPERATION", "OPS/SEC")
+	for _, res := range results {
+		_, _ = fmt.Fprintf(w, "%-20s %15.2f\n", res.Name, res.OpsPerSec)
+	}
+
+	_, _ = fmt.Fprintf(w, "\nEstimated max validators per slot (%v): %d\n", conf.SlotDuration, maxValidators(results, conf.SlotDuration))
+
+	return nil
+}
+
+// maxValidators returns the estimated number of validators for which a partial signature
+// can be created and a threshold of partial signatures aggregated within a slot.
+func maxValidators(results []perfResult, slotDuration time.Duration) int {
+	var perDuty float64 // Seconds required per validator duty.
+	for _, res := range results {
+		if res.Name != opSign && res.Name != opThresholdAggregate {
+			continue
+		}
+		if res.OpsPerSec == 0 {
+			return 0
+		}
+
+		perDuty += 1 / res.OpsPerSec
+	}
+
+	if perDuty == 0 {
+		return 0
+	}
+
+	return int(slotDuration.Seconds() / perDuty)
+}
+
+const (
+	opSign               = "Sign"
+	opThresholdAggregate = "ThresholdAggregate"
+	opThresholdSplit     = "ThresholdSplit"
+	opSecretToPublicKey  = "SecretToPublicKey"
+)
+
+// benchmarkTBLS returns the ops/sec of each of the benchmarked tbls operations.
+func benchmarkTBLS(ctx context.Context, conf testPerformanceConfig) ([]perfResult, error) {
+	if conf.Threshold <= 0 || conf.Threshold > conf.Nodes {
+		return nil, errors.New("invalid threshold")
+	}
+
+	secret, err := tblsv2.GenerateSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := tblsv2.ThresholdSplit(secret, uint(conf.Nodes), uint(conf.Threshold))
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, 32)
+	_, _ = rand.Read(msg)
+
+	// Partial signatures of a threshold of shares by share index, as aggregated by sigagg.
+	partialSigs := make(map[int]tblsv2.Signature)
+	for shareIdx := 1; shareIdx <= conf.Threshold; shareIdx++ {
+		sig, err := tblsv2.Sign(shares[shareIdx], msg)
+		if err != nil {
+			return nil, err
+		}
+		partialSigs[shareIdx] = sig
+	}
+
+	// Ensure the benchmarked aggregation produces a valid group signature.
+	if err := verifyThresholdAggregate(secret, msg, partialSigs); err != nil {
+		return nil, err
+	}
+
+	ops := []struct {
+		Name string
+		Func func() error
+	}{
+		{
+			Name: opSign,
+			Func: func() error {
+				_, err := tblsv2.Sign(shares[1], msg)
+				return err
+			},
+		},
+		{
+			Name: opThresholdAggregate,
+			Func: func() error {
+				_, err := tblsv2.ThresholdAggregate(partialSigs)
+				return err
+			},
+		},
+		{
+			Name: opThresholdSplit,
+			Func: func() error {
+				_, err := tblsv2.ThresholdSplit(secret, uint(conf.Nodes), uint(conf.Threshold))
+				return err
+			},
+		},
+		{
+			Name: opSecretToPublicKey,
+			Func: func() error {
+				_, err := tblsv2.SecretToPublicKey(secret)
+				return err
+			},
+		},
+	}
+
+	var results []perfResult
+	for _, op := range ops {
+		var (
+			count int
+			t0    = time.Now()
+		)
+		for time.Since(t0) < conf.Duration || count == 0 {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			if err := op.Func(); err != nil {
+				return nil, errors.Wrap(err, "benchmark operation")
+			}
+			count++
+		}
+
+		results = append(results, perfResult{
+			Name:      op.Name,
+			OpsPerSec: float64(count) / time.Since(t0).Seconds(),
+		})
+	}
+
+	return results, nil
+}
+
+// verifyThresholdAggregate returns an error if the threshold aggregate of the partial signatures
+// isn't a valid signature of the message by the secret.
+func verifyThresholdAggregate(secret tblsv2.PrivateKey, msg []byte, partialSigs map[int]tblsv2.Signature) error {
+	sig, err := tblsv2.ThresholdAggregate(partialSigs)
+	if err != nil {
+		return err
+	}
+
+	pubkey, err := tblsv2.SecretToPublicKey(secret)
+	if err != nil {
+		return err
+	}
+
+	return tblsv2.Verify(pubkey, msg, sig)
+}
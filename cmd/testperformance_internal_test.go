@@ -0,0 +1,36 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTestPerformance(t *testing.T) {
+	conf := testPerformanceConfig{
+		Duration:     time.Millisecond * 10,
+		SlotDuration: time.Second * 12,
+		Nodes:        4,
+		Threshold:    3,
+	}
+
+	results, err := benchmarkTBLS(context.Background(), conf)
+	require.NoError(t, err)
+
+	var names []string
+	for _, res := range results {
+		names = append(names, res.Name)
+		require.Greater(t, res.OpsPerSec, 0.0)
+	}
+	require.Equal(t, []string{opSign, opThresholdAggregate, opThresholdSplit, opSecretToPublicKey}, names)
+	require.Positive(t, maxValidators(results, conf.SlotDuration))
+
+	var buf bytes.Buffer
+	require.NoError(t, runTestPerformance(context.Background(), &buf, conf))
+	require.Contains(t, buf.String(), "Estimated max validators per slot")
+}
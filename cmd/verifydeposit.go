@@ -0,0 +1,168 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+)
+
+type verifyDepositConfig struct {
+	DepositDataFile  string
+	LockFile         string
+	LockPasswordFile string
+}
+
+func newVerifyDepositCmd(runFunc func(context.Context, io.Writer, verifyDepositConfig) error) *cobra.Command {
+	var conf verifyDepositConfig
+
+	cmd := &cobra.Command{
+		Use:   "deposit-against-lock",
+		Short: "Verify a deposit data file corresponds to a cluster lock",
+		Long: "Cross-checks the validator public keys, withdrawal credentials and amounts of a deposit data file " +
+			"against the distributed validators of a cluster lock and reports any discrepancy.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindVerifyDepositFlags(cmd.Flags(), &conf)
+	mustMarkFlagRequired(cmd, "deposit-data-file")
+	mustMarkFlagRequired(cmd, "lock-file")
+
+	return cmd
+}
+
+func bindVerifyDepositFlags(flags *pflag.FlagSet, config *verifyDepositConfig) {
+	flags.StringVar(&config.DepositDataFile, "deposit-data-file", "", "The path to the deposit-data.json file to verify.")
+	flags.StringVar(&config.LockFile, "lock-file", "", "The path to the cluster-lock.json file to verify against.")
+	bindLockPasswordFlag(flags, &config.LockPasswordFile)
+}
+
+// runVerifyDeposit verifies the configured deposit data file against the configured cluster lock file.
+func runVerifyDeposit(_ context.Context, w io.Writer, conf verifyDepositConfig) error {
+	lock, err := readLockFile(conf.LockFile, conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(conf.DepositDataFile)
+	if err != nil {
+		return errors.Wrap(err, "read deposit data")
+	}
+
+	var deposits []depositEntry
+	if err := json.Unmarshal(b, &deposits); err != nil {
+		return errors.Wrap(err, "unmarshal deposit data")
+	}
+
+	discrepancies, err := depositLockDiscrepancies(lock, deposits)
+	if err != nil {
+		return err
+	}
+
+	if len(discrepancies) == 0 {
+		_, _ = fmt.Fprintf(w, "Deposit data matches cluster lock for %d validators\n", len(lock.Validators))
+		return nil
+	}
+
+	for _, d := range discrepancies {
+		_, _ = fmt.Fprintln(w, d)
+	}
+
+	return errors.New("deposit data doesn't match cluster lock", z.Int("discrepancies", len(discrepancies)))
+}
+
+// depositEntry is the subset of a deposit data file entry that is verified against a cluster lock.
+type depositEntry struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+}
+
+// depositLockDiscrepancies returns the human-readable discrepancies between the deposits and the lock validators.
+// Validators without deposit data in the lock (older lock versions) are expected to use the default
+// execution withdrawal credentials of their withdrawal address and the default deposit amount.
+func depositLockDiscrepancies(lock cluster.Lock, deposits []depositEntry) ([]string, error) {
+	withdrawalAddrs := lock.WithdrawalAddresses()
+	if len(withdrawalAddrs) != len(lock.Validators) {
+		return nil, errors.New("cluster lock withdrawal addresses don't match validators")
+	}
+
+	byPubkey := make(map[string]depositEntry)
+	var resp []string
+	for _, d := range deposits {
+		pubkey := normaliseHex(d.PubKey)
+		if _, ok := byPubkey[pubkey]; ok {
+			resp = append(resp, fmt.Sprintf("duplicate deposit data for pubkey 0x%s", pubkey))
+			continue
+		}
+		byPubkey[pubkey] = d
+	}
+
+	for i, val := range lock.Validators {
+		pubkey := hex.EncodeToString(val.PubKey)
+
+		d, ok := byPubkey[pubkey]
+		if !ok {
+			resp = append(resp, fmt.Sprintf("validator 0x%s missing from deposit data", pubkey))
+			continue
+		}
+		delete(byPubkey, pubkey)
+
+		wantCreds, wantAmount := val.DepositData.WithdrawalCredentials, uint64(val.DepositData.Amount)
+		if len(val.DepositData.PubKey) == 0 {
+			var pk eth2p0.BLSPubKey
+			copy(pk[:], val.PubKey)
+
+			msg, err := deposit.NewMessage(pk, withdrawalAddrs[i])
+			if err != nil {
+				return nil, err
+			}
+			wantCreds, wantAmount = msg.WithdrawalCredentials, uint64(msg.Amount)
+		}
+
+		creds, err := hex.DecodeString(normaliseHex(d.WithdrawalCredentials))
+		if err != nil || !bytes.Equal(creds, wantCreds) {
+			resp = append(resp, fmt.Sprintf("validator 0x%s withdrawal credentials mismatch: deposit %s, lock %#x",
+				pubkey, d.WithdrawalCredentials, wantCreds))
+		}
+
+		if d.Amount != wantAmount {
+			resp = append(resp, fmt.Sprintf("validator 0x%s amount mismatch: deposit %d, lock %d",
+				pubkey, d.Amount, wantAmount))
+		}
+	}
+
+	// Remaining deposits are not part of the lock, report them in file order.
+	for _, d := range deposits {
+		pubkey := normaliseHex(d.PubKey)
+		if _, ok := byPubkey[pubkey]; ok {
+			resp = append(resp, fmt.Sprintf("deposit data pubkey 0x%s not in cluster lock", pubkey))
+			delete(byPubkey, pubkey)
+		}
+	}
+
+	return resp, nil
+}
+
+// normaliseHex returns the lower case hex string without 0x prefix.
+func normaliseHex(s string) string {
+	return strings.ToLower(strings.TrimPrefix(s, "0x"))
+}
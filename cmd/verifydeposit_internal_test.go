@@ -0,0 +1,94 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+)
+
+func TestVerifyDeposit(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+	otherLock, _, _ := cluster.NewForT(t, 1, 3, 4, 1)
+
+	// newDeposits returns the deposit data entries of the validators using the lock withdrawal addresses.
+	newDeposits := func(t *testing.T, vals []cluster.DistValidator) []depositEntry {
+		t.Helper()
+
+		var resp []depositEntry
+		for i, val := range vals {
+			var pk eth2p0.BLSPubKey
+			copy(pk[:], val.PubKey)
+
+			msg, err := deposit.NewMessage(pk, lock.WithdrawalAddresses()[i])
+			require.NoError(t, err)
+
+			resp = append(resp, depositEntry{
+				PubKey:                hex.EncodeToString(msg.PublicKey[:]),
+				WithdrawalCredentials: hex.EncodeToString(msg.WithdrawalCredentials),
+				Amount:                uint64(msg.Amount),
+			})
+		}
+
+		return resp
+	}
+
+	run := func(t *testing.T, deposits []depositEntry) (string, error) {
+		t.Helper()
+
+		dir := t.TempDir()
+		lockFile := path.Join(dir, "cluster-lock.json")
+		writeLockFile(t, lockFile, lock)
+
+		b, err := json.Marshal(deposits)
+		require.NoError(t, err)
+		depositFile := path.Join(dir, "deposit-data.json")
+		require.NoError(t, os.WriteFile(depositFile, b, 0o644))
+
+		var buf bytes.Buffer
+		err = runVerifyDeposit(context.Background(), &buf, verifyDepositConfig{
+			DepositDataFile: depositFile,
+			LockFile:        lockFile,
+		})
+
+		return buf.String(), err
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		out, err := run(t, newDeposits(t, lock.Validators))
+		require.NoError(t, err)
+		require.Contains(t, out, "Deposit data matches cluster lock for 2 validators")
+	})
+
+	t.Run("mismatching pubkeys", func(t *testing.T) {
+		deposits := newDeposits(t, lock.Validators)
+		deposits[1] = newDeposits(t, otherLock.Validators)[0]
+
+		out, err := run(t, deposits)
+		require.ErrorContains(t, err, "deposit data doesn't match cluster lock")
+		require.Contains(t, out, "validator "+lock.Validators[1].PublicKeyHex()+" missing from deposit data")
+		require.Contains(t, out, "deposit data pubkey "+otherLock.Validators[0].PublicKeyHex()+" not in cluster lock")
+	})
+
+	t.Run("mismatching credentials and amount", func(t *testing.T) {
+		deposits := newDeposits(t, lock.Validators)
+		deposits[0].WithdrawalCredentials = hex.EncodeToString(make([]byte, 32))
+		deposits[0].Amount = 1
+
+		out, err := run(t, deposits)
+		require.ErrorContains(t, err, "deposit data doesn't match cluster lock")
+		require.Contains(t, out, "withdrawal credentials mismatch")
+		require.Contains(t, out, "amount mismatch")
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+)
+
+type verifyLockSigConfig struct {
+	ClusterDirs      []string
+	LockPasswordFile string
+}
+
+func newVerifyLockSigCmd(runFunc func(context.Context, io.Writer, verifyLockSigConfig) error) *cobra.Command {
+	var conf verifyLockSigConfig
+
+	cmd := &cobra.Command{
+		Use:   "lock-signature",
+		Short: "Verify the cluster lock aggregate signature reconstructs from the key shares",
+		Long: "Gathers the validator key shares of all nodes, signs the cluster lock hash with each share and verifies " +
+			"that the aggregate of the partial signatures matches the signature aggregate in the cluster lock.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
+		},
+	}
+
+	bindVerifyLockSigFlags(cmd.Flags(), &conf)
+
+	return cmd
+}
+
+func bindVerifyLockSigFlags(flags *pflag.FlagSet, config *verifyLockSigConfig) {
+	flags.StringSliceVar(&config.ClusterDirs, "cluster-dir", nil, "Comma separated list of the node directories of all operators, each containing a cluster-lock.json and validator_keys directory.")
+	bindLockPasswordFlag(flags, &config.LockPasswordFile)
+}
+
+// runVerifyLockSig verifies the aggregate signature of the cluster lock in the configured node directories.
+func runVerifyLockSig(_ context.Context, w io.Writer, conf verifyLockSigConfig) error {
+	if len(conf.ClusterDirs) == 0 {
+		return errors.New("no node directories provided, see --cluster-dir")
+	}
+
+	lock, err := readLockFile(path.Join(conf.ClusterDirs[0], "cluster-lock.json"), conf.LockPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	var shareSets [][]tblsv2.PrivateKey
+	for _, dir := range conf.ClusterDirs {
+		other, err := readLockFile(path.Join(dir, "cluster-lock.json"), conf.LockPasswordFile)
+		if err != nil {
+			return err
+		} else if !bytes.Equal(other.LockHash, lock.LockHash) {
+			return errors.New("mismatching cluster lock hash", z.Str("dir", dir))
+		}
+
+		shares, err := keystore.LoadKeys(path.Join(dir, "validator_keys"))
+		if err != nil {
+			return errors.Wrap(err, "load key shares", z.Str("dir", dir))
+		}
+
+		shareSets = append(shareSets, shares)
+	}
+
+	if err := verifyLockSig(lock, shareSets); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Cluster lock signature aggregate verified from %d key shares of %d nodes\n",
+		len(lock.Validators)*len(shareSets), len(shareSets))
+
+	return nil
+}
+
+// verifyLockSig returns an error if the aggregate of the partial signatures of the lock hash by the key shares
+// doesn't match the lock signature aggregate. This mirrors aggSign, but as a verification.
+func verifyLockSig(lock cluster.Lock, shareSets [][]tblsv2.PrivateKey) error {
+	// Ensure all public shares of all validators are provided, since the aggregate signs over all of them.
+	expected := make(map[tblsv2.PublicKey]bool)
+	for _, val := range lock.Validators {
+		for _, share := range val.PubShares {
+			pubshare, err := tblsconv2.PubkeyFromBytes(share)
+			if err != nil {
+				return err
+			}
+			expected[pubshare] = true
+		}
+	}
+
+	var signers []signer
+	for i, shares := range shareSets {
+		for _, share := range shares {
+			pubshare, err := tblsv2.SecretToPublicKey(share)
+			if err != nil {
+				return errors.Wrap(err, "secret to pubkey")
+			}
+
+			if !expected[pubshare] {
+				return errors.New("unexpected or duplicate key share", z.Int("node_dir", i))
+			}
+			delete(expected, pubshare)
+		}
+
+		signers = append(signers, localSigners(shares)...)
+	}
+
+	if len(expected) > 0 {
+		return errors.New("missing key shares, the node directories of all operators are required",
+			z.Int("missing", len(expected)))
+	}
+
+	aggSig, err := aggSign([][]signer{signers}, lock.LockHash)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(aggSig, lock.SignatureAggregate) {
+		return errors.New("cluster lock signature aggregate mismatch")
+	}
+
+	return nil
+}
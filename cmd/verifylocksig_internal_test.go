@@ -0,0 +1,71 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+func TestVerifyLockSig(t *testing.T) {
+	const (
+		numVals  = 2
+		numNodes = 4
+	)
+
+	lock, _, dvShares := cluster.NewForT(t, numVals, 3, numNodes, 0)
+
+	// writeNodeDirs writes the lock and the key shares of each node to separate node directories.
+	writeNodeDirs := func(t *testing.T, lock cluster.Lock) []string {
+		t.Helper()
+
+		var dirs []string
+		for i := 0; i < numNodes; i++ {
+			dir := path.Join(t.TempDir(), "node")
+			require.NoError(t, os.MkdirAll(path.Join(dir, "validator_keys"), 0o755))
+			writeLockFile(t, path.Join(dir, "cluster-lock.json"), lock)
+
+			var shares []tblsv2.PrivateKey
+			for _, valShares := range dvShares {
+				shares = append(shares, valShares[i])
+			}
+			require.NoError(t, keystore.StoreKeysInsecure(shares, path.Join(dir, "validator_keys"), keystore.ConfirmInsecureKeys))
+
+			dirs = append(dirs, dir)
+		}
+
+		return dirs
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runVerifyLockSig(context.Background(), &buf, verifyLockSigConfig{ClusterDirs: writeNodeDirs(t, lock)})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "verified from 8 key shares of 4 nodes")
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		tampered := lock
+		tampered.SignatureAggregate = append([]byte(nil), lock.SignatureAggregate...)
+		tampered.SignatureAggregate[len(tampered.SignatureAggregate)-1] ^= 0xff
+
+		err := runVerifyLockSig(context.Background(), new(bytes.Buffer), verifyLockSigConfig{ClusterDirs: writeNodeDirs(t, tampered)})
+		require.ErrorContains(t, err, "signature aggregate mismatch")
+	})
+
+	t.Run("missing node", func(t *testing.T) {
+		dirs := writeNodeDirs(t, lock)
+
+		err := runVerifyLockSig(context.Background(), new(bytes.Buffer), verifyLockSigConfig{ClusterDirs: dirs[1:]})
+		require.ErrorContains(t, err, "missing key shares")
+	})
+}
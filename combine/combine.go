@@ -19,6 +19,18 @@ import (
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 )
 
+// WithLockPassword returns an option to decrypt the cluster lock files with the password if encrypted at rest.
+func WithLockPassword(password string) func(*options) {
+	return func(o *options) {
+		o.lockPassword = password
+	}
+}
+
+// options defines the optional Combine configuration.
+type options struct {
+	lockPassword string
+}
+
 // Combine combines validator keys contained in inputDir, and writes the original BLS12-381 private keys.
 // Combine is validator-aware: it'll recombine all the validator keys listed in the "Validator" field of the lock file.
 // To do so, the user must prepare inputDir as follows:
@@ -26,16 +38,26 @@ import (
 //
 // Combine will create a new directory named after the public key of each validator key reconstructed, containing each
 // keystore under the "validator_keys" subdirectory.
-func Combine(ctx context.Context, inputDir string, force bool) error {
+func Combine(ctx context.Context, inputDir string, force bool, opts ...func(*options)) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	log.Info(ctx, "Recombining key shares",
 		z.Str("input_dir", inputDir),
 	)
 
-	lock, possibleKeyPaths, err := loadLockfile(inputDir)
+	lock, possibleKeyPaths, err := loadLockfile(inputDir, o.lockPassword)
 	if err != nil {
 		return errors.Wrap(err, "cannot open lock file")
 	}
 
+	scheme, err := tblsv2.GetTSSScheme(lock.Definition.TSSScheme)
+	if err != nil {
+		return err
+	}
+
 	privkeys := make(map[int][]tblsv2.PrivateKey)
 
 	for _, pkp := range possibleKeyPaths {
@@ -60,7 +82,7 @@ func Combine(ctx context.Context, inputDir string, force bool) error {
 			return errors.New("insufficient number of keys", z.Int("validator_number", idx))
 		}
 
-		secret, err := tblsv2.RecoverSecret(shares, uint(len(lock.Operators)), uint(lock.Threshold))
+		secret, err := scheme.Recover(shares, uint(len(lock.Operators)), uint(lock.Threshold))
 		if err != nil {
 			return errors.Wrap(err, "cannot recover shares", z.Int("validator_number", idx))
 		}
@@ -143,9 +165,10 @@ func secretsToShares(lock cluster.Lock, secrets []tblsv2.PrivateKey) (map[int]tb
 }
 
 // loadLockfile loads a lockfile from one of the charon directories contained in dir.
-// It checks that all the directories containing a validator_keys subdirectory contain the same cluster_lock.json file.
+// It checks that all the directories containing a validator_keys subdirectory contain the same cluster_lock.json file,
+// decrypting them with the password if encrypted.
 // It returns the cluster.Lock read from the lock file, and a list of directories that possibly contains keys.
-func loadLockfile(dir string) (cluster.Lock, []string, error) {
+func loadLockfile(dir string, password string) (cluster.Lock, []string, error) {
 	root, err := os.ReadDir(dir)
 	if err != nil {
 		return cluster.Lock{}, nil, errors.Wrap(err, "can't read directory")
@@ -154,7 +177,7 @@ func loadLockfile(dir string) (cluster.Lock, []string, error) {
 	var (
 		lfFound            bool
 		lastLockfileHash   [32]byte
-		lfContent          []byte
+		lock               cluster.Lock
 		possibleValKeysDir []string
 	)
 
@@ -184,14 +207,25 @@ func loadLockfile(dir string) (cluster.Lock, []string, error) {
 			continue
 		}
 
-		lfHash := sha256.Sum256(lfc)
+		l, err := cluster.DecryptLock(lfc, password)
+		if err != nil {
+			return cluster.Lock{}, nil, errors.Wrap(err, "load lock file", z.Str("name", sd.Name()))
+		}
+
+		// Compare the decrypted locks, since encrypted lock files differ by their random salt.
+		lb, err := json.Marshal(l)
+		if err != nil {
+			return cluster.Lock{}, nil, errors.Wrap(err, "marshal lock file")
+		}
+
+		lfHash := sha256.Sum256(lb)
 
 		if lastLockfileHash != [32]byte{} && lfHash != lastLockfileHash {
 			return cluster.Lock{}, nil, errors.New("found different lockfile in node directory", z.Str("name", sd.Name()))
 		}
 
 		lastLockfileHash = lfHash
-		lfContent = lfc
+		lock = l
 		lfFound = true
 	}
 
@@ -199,11 +233,6 @@ func loadLockfile(dir string) (cluster.Lock, []string, error) {
 		return cluster.Lock{}, nil, errors.New("lock file not found")
 	}
 
-	var lock cluster.Lock
-	if err := json.Unmarshal(lfContent, &lock); err != nil {
-		return cluster.Lock{}, nil, errors.Wrap(err, "unmarshal lock file")
-	}
-
 	if err := lock.VerifyHashes(); err != nil {
 		return cluster.Lock{}, nil, errors.Wrap(err, "cluster lock hash verification failed")
 	}
@@ -242,3 +242,39 @@ func TestCombineTwiceWithoutForceFails(t *testing.T) {
 		require.Equal(t, exp.secret, fmt.Sprintf("%#x", keys[0]))
 	}
 }
+
+func TestCombineEncryptedLock(t *testing.T) {
+	lock, _, shares := cluster.NewForT(t, 2, 3, 4, 0)
+
+	dir := t.TempDir()
+
+	for enrIdx := 0; enrIdx < len(lock.Definition.Operators); enrIdx++ {
+		var keys []tblsv2.PrivateKey
+		for dvIdx := 0; dvIdx < lock.NumValidators; dvIdx++ {
+			keys = append(keys, shares[dvIdx][enrIdx])
+		}
+
+		ep := filepath.Join(dir, fmt.Sprintf("node%d", enrIdx))
+		vk := filepath.Join(ep, "validator_keys")
+
+		require.NoError(t, os.MkdirAll(vk, 0o755))
+		require.NoError(t, keystore.StoreKeys(keys, vk))
+
+		// Each node encrypts its lock with a different random salt.
+		b, err := cluster.EncryptLock(lock, "secret")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(ep, "cluster-lock.json"), b, 0o644))
+	}
+
+	err := combine.Combine(context.Background(), dir, false)
+	require.ErrorContains(t, err, "cluster lock is encrypted, but no password provided")
+
+	err = combine.Combine(context.Background(), dir, false, combine.WithLockPassword("secret"))
+	require.NoError(t, err)
+
+	for _, val := range lock.Validators {
+		keys, err := keystore.LoadKeys(filepath.Join(dir, val.PublicKeyHex(), "validator_keys"))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+	}
+}
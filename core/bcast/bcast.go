@@ -5,11 +5,16 @@
 package bcast
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"strings"
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
@@ -20,22 +25,25 @@ import (
 	"github.com/obolnetwork/charon/core"
 )
 
-// New returns a new broadcaster instance.
-func New(ctx context.Context, eth2Cl eth2wrap.Client) (Broadcaster, error) {
+// New returns a new broadcaster instance. The optional builderRelaysFunc returns the builder relays preferred
+// by a validator, its builder registrations are also submitted to these relays.
+func New(ctx context.Context, eth2Cl eth2wrap.Client, builderRelaysFunc func(core.PubKey) []string) (Broadcaster, error) {
 	delayFunc, err := newDelayFunc(ctx, eth2Cl)
 	if err != nil {
 		return Broadcaster{}, err
 	}
 
 	return Broadcaster{
-		eth2Cl:    eth2Cl,
-		delayFunc: delayFunc,
+		eth2Cl:            eth2Cl,
+		delayFunc:         delayFunc,
+		builderRelaysFunc: builderRelaysFunc,
 	}, nil
 }
 
 type Broadcaster struct {
-	eth2Cl    eth2wrap.Client
-	delayFunc func(slot int64) time.Duration
+	eth2Cl            eth2wrap.Client
+	delayFunc         func(slot int64) time.Duration
+	builderRelaysFunc func(core.PubKey) []string
 }
 
 // Broadcast broadcasts the aggregated signed duty data object to the beacon-node.
@@ -109,6 +117,8 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, pubkey core.
 			log.Info(ctx, "Successfully submitted validator registration to beacon node",
 				z.Any("delay", b.delayFunc(duty.Slot)),
 			)
+
+			b.submitRelayRegistrations(ctx, pubkey, registration)
 		}
 
 		return err
@@ -180,6 +190,64 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, pubkey core.
 	}
 }
 
+// submitRelayRegistrations submits the validator registration to the builder relays preferred by the validator.
+// Failures are only logged since the registration was already submitted to the beacon node.
+func (b Broadcaster) submitRelayRegistrations(ctx context.Context, pubkey core.PubKey, registration core.VersionedSignedValidatorRegistration) {
+	if b.builderRelaysFunc == nil {
+		return
+	}
+
+	for _, relay := range b.builderRelaysFunc(pubkey) {
+		err := submitRelayRegistration(ctx, relay, registration)
+		if err != nil {
+			log.Warn(ctx, "Failed submitting validator registration to builder relay", err, z.Str("relay", relay))
+			continue
+		}
+
+		log.Debug(ctx, "Successfully submitted validator registration to builder relay", z.Str("relay", relay))
+	}
+}
+
+// submitRelayRegistration submits the validator registration to the builder relay's
+// register validator endpoint. The HTTP request times out after 5s.
+func submitRelayRegistration(ctx context.Context, relay string, registration core.VersionedSignedValidatorRegistration) error {
+	if registration.V1 == nil {
+		return errors.New("unsupported validator registration version", z.Any("version", registration.Version))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	b, err := json.Marshal([]*eth2v1.SignedValidatorRegistration{registration.V1})
+	if err != nil {
+		return errors.Wrap(err, "marshal validator registration")
+	}
+
+	addr := strings.TrimSuffix(relay, "/") + "/eth/v1/builder/validators"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "new post request", z.Str("url", addr))
+	}
+	req.Header.Add("Content-Type", `application/json`)
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post validator registration")
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.New("failed posting validator registration", z.Int("status", resp.StatusCode), z.Str("body", string(data)))
+	}
+
+	return nil
+}
+
 // newDelayFunc returns a function that calculates the delay since the start of a slot.
 func newDelayFunc(ctx context.Context, eth2Cl eth2wrap.Client) (func(slot int64) time.Duration, error) {
 	genesis, err := eth2Cl.GenesisTime(ctx)
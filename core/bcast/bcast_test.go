@@ -4,9 +4,13 @@ package bcast_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -50,7 +54,7 @@ func TestBroadcast(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			bcaster, err := bcast.New(ctx, mock)
+			bcaster, err := bcast.New(ctx, mock, nil)
 			require.NoError(t, err)
 
 			for i := 0; i < test.bcastCnt; i++ {
@@ -72,6 +76,57 @@ func TestBroadcast(t *testing.T) {
 	}
 }
 
+func TestBroadcastBuilderRelays(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registration := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	received := make(chan []*eth2v1.SignedValidatorRegistration, 2)
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/builder/validators", r.URL.Path)
+
+		var regs []*eth2v1.SignedValidatorRegistration
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&regs))
+		received <- regs
+	}))
+	defer relay.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	mock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var submitted bool
+	mock.SubmitValidatorRegistrationsFunc = func(context.Context, []*eth2api.VersionedSignedValidatorRegistration) error {
+		submitted = true
+		return nil
+	}
+
+	bcaster, err := bcast.New(ctx, mock, func(key core.PubKey) []string {
+		require.Equal(t, pubkey, key)
+		return []string{failing.URL, relay.URL + "/"}
+	})
+	require.NoError(t, err)
+
+	// Failing relays don't fail the broadcast.
+	err = bcaster.Broadcast(ctx, core.Duty{Type: core.DutyBuilderRegistration}, pubkey, registration)
+	require.NoError(t, err)
+	require.True(t, submitted)
+
+	select {
+	case regs := <-received:
+		require.Equal(t, []*eth2v1.SignedValidatorRegistration{registration.V1}, regs)
+	default:
+		require.Fail(t, "registration not submitted to builder relay")
+	}
+}
+
 func attData(t *testing.T, mock *beaconmock.Mock) test {
 	t.Helper()
 
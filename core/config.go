@@ -4,3 +4,6 @@ package core
 
 // BuilderEnabled determines whether the builderAPI is enabled for the provided slot.
 type BuilderEnabled func(slot int64) bool
+
+// ValidatorEnabled determines whether duties of the validator are scheduled.
+type ValidatorEnabled func(pubkey PubKey) bool
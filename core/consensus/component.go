@@ -4,9 +4,14 @@ package consensus
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -14,6 +19,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -28,9 +34,18 @@ import (
 
 const (
 	recvBuffer    = 100 // Allow buffering some initial messages when this node is late to start an instance.
+	recvPerNode   = 20  // Default receive buffer size per node, allowing a few rounds of messages per peer.
 	roundStart    = time.Millisecond * 750
 	roundIncrease = time.Millisecond * 250
+	roundMaxLabel = 10 // Rounds above this are aggregated in the round timeout gauge to limit cardinality.
 	protocolID    = "/charon/consensus/qbft/1.0.0"
+
+	// defaultDecisionSLA is the default maximum duration to decide a consensus instance, one mainnet slot.
+	defaultDecisionSLA = 12 * time.Second
+
+	// defaultMaxActiveInstances is the default expected bound of concurrently active consensus instances.
+	// Instances are per duty, so this allows for all duty types of a few concurrent slots.
+	defaultMaxActiveInstances = 64
 )
 
 // Protocols returns the supported protocols of this package in order of precedence.
@@ -40,10 +55,11 @@ func Protocols() []protocol.ID {
 
 type subscriber func(ctx context.Context, duty core.Duty, value proto.Message) error
 
+// ValueValidator validates a decoded proposed value of a duty, returning an error to reject it.
+type ValueValidator func(ctx context.Context, duty core.Duty, value proto.Message) error
+
 // newDefinition returns a qbft definition (this is constant across all consensus instances).
 func newDefinition(nodes int, subs func() []subscriber) qbft.Definition[core.Duty, [32]byte] {
-	quorum := qbft.Definition[int, int]{Nodes: nodes}.Quorum()
-
 	return qbft.Definition[core.Duty, [32]byte]{
 		// IsLeader is a deterministic leader election function.
 		IsLeader: func(duty core.Duty, round, process int64) bool {
@@ -78,7 +94,7 @@ func newDefinition(nodes int, subs func() []subscriber) qbft.Definition[core.Dut
 			}
 		},
 
-		NewTimer: newRoundTimer, // newRoundTimer returns a 750ms+(round*250ms) period timer.
+		NewTimer: newRoundTimer(DefaultRoundTimeout()), // Default returns a 750ms+(round*250ms) period timer.
 
 		// LogUponRule logs upon rules at debug level.
 		LogUponRule: func(ctx context.Context, _ core.Duty, _, round int64,
@@ -87,26 +103,7 @@ func newDefinition(nodes int, subs func() []subscriber) qbft.Definition[core.Dut
 			log.Debug(ctx, "QBFT upon rule triggered", z.Any("rule", uponRule), z.I64("round", round))
 		},
 
-		// LogRoundChange logs round changes at debug level.
-		LogRoundChange: func(ctx context.Context, duty core.Duty, process,
-			round, newRound int64, uponRule qbft.UponRule, msgs []qbft.Msg[core.Duty, [32]byte],
-		) {
-			fields := []z.Field{
-				z.Any("rule", uponRule),
-				z.I64("round", round),
-				z.I64("new_round", newRound),
-			}
-
-			steps := groupRoundMessages(msgs, nodes, round, int(leader(duty, round, nodes)))
-			for _, step := range steps {
-				fields = append(fields, z.Str(step.Type.String(), fmtStepPeers(step)))
-			}
-			if uponRule == qbft.UponRoundTimeout {
-				fields = append(fields, z.Str("timeout_reason", timeoutReason(steps, round, quorum)))
-			}
-
-			log.Debug(ctx, "QBFT round changed", fields...)
-		},
+		LogRoundChange: newRoundChangeLogger(nodes, nil),
 
 		LogUnjust: func(ctx context.Context, _ core.Duty, _ int64, msg qbft.Msg[core.Duty, [32]byte]) {
 			log.Warn(ctx, "Unjustified consensus message from peer", nil,
@@ -141,20 +138,33 @@ func New(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.Pri
 		keys[int64(i)] = pk
 	}
 
-	c := &Component{
-		tcpNode:           tcpNode,
-		sender:            sender,
-		peers:             peers,
-		peerLabels:        labels,
-		privkey:           p2pKey,
-		pubkeys:           keys,
-		deadliner:         deadliner,
-		recvBuffers:       make(map[core.Duty]chan msg),
-		snifferFunc:       snifferFunc,
-		dropFilter:        log.Filter(),
-		legacyProbability: legacyProbability,
+	// Derive the instance nonce from the ordered cluster peers, so it is identical for all peers.
+	nonce := sha256.New()
+	for _, p := range peers {
+		_, _ = nonce.Write([]byte(p.ID))
 	}
 
+	c := &Component{
+		tcpNode:            tcpNode,
+		sender:             sender,
+		peers:              peers,
+		peerLabels:         labels,
+		privkey:            p2pKey,
+		pubkeys:            keys,
+		deadliner:          deadliner,
+		recvBuffers:        make(map[core.Duty]chan msg),
+		snifferFunc:        snifferFunc,
+		dropFilter:         log.Filter(),
+		throttleFilter:     log.Filter(),
+		legacyProbability:  legacyProbability,
+		limiter:            newBroadcastLimiter(DefaultBroadcastLimit, DefaultBroadcastBurst),
+		decisionSLA:        defaultDecisionSLA,
+		recvBufferSize:     defaultRecvBufferSize(len(peers)),
+		maxActiveInstances: defaultMaxActiveInstances,
+		silentPeers:        newSilentPeers(peers, DefaultSilentPeerInstances),
+	}
+	copy(c.instanceNonce[:], nonce.Sum(nil))
+
 	c.def = newDefinition(len(peers), c.subscribers)
 
 	return c, nil
@@ -163,22 +173,118 @@ func New(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.Pri
 // Component implements core.Consensus.
 type Component struct {
 	// Immutable state
-	tcpNode           host.Host
-	sender            *p2p.Sender
-	peerLabels        []string
-	peers             []p2p.Peer
-	pubkeys           map[int64]*k1.PublicKey
-	privkey           *k1.PrivateKey
-	def               qbft.Definition[core.Duty, [32]byte]
-	subs              []subscriber
-	deadliner         core.Deadliner
-	snifferFunc       func(*pbv1.SniffedConsensusInstance)
-	dropFilter        z.Field // Filter buffer overflow errors (possible DDoS)
-	legacyProbability float64 // Probability of using legacy duplicated values inside QBFTMsg vs new pointer values.
+	tcpNode            host.Host
+	sender             *p2p.Sender
+	peerLabels         []string
+	peers              []p2p.Peer
+	pubkeys            map[int64]*k1.PublicKey
+	privkey            *k1.PrivateKey
+	def                qbft.Definition[core.Duty, [32]byte]
+	subs               []subscriber
+	deadliner          core.Deadliner
+	snifferFunc        func(*pbv1.SniffedConsensusInstance)
+	dropFilter         z.Field // Filter buffer overflow errors (possible DDoS)
+	throttleFilter     z.Field // Filter broadcast rate limiting warnings.
+	legacyProbability  float64 // Probability of using legacy duplicated values inside QBFTMsg vs new pointer values.
+	limiter            *broadcastLimiter
+	instanceNonce      [32]byte // Cluster specific nonce used to derive consensus instance IDs.
+	validator          ValueValidator
+	decisionSLA        time.Duration // Instances deciding slower than this breach the SLA.
+	recvBufferSize     int           // Size of instance receive buffers.
+	maxActiveInstances int           // Exceeding this number of active instances indicates a leak.
+	silentPeers        *silentPeers
 
 	// Mutable state
-	recvMu      sync.Mutex
-	recvBuffers map[core.Duty]chan msg // Instance outer receive buffers.
+	recvMu          sync.Mutex
+	recvBuffers     map[core.Duty]chan msg // Instance outer receive buffers.
+	activeInstances atomic.Int64
+}
+
+// SetBroadcastLimit overrides the default per peer broadcast rate limit (messages per second) and burst.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetBroadcastLimit(limit float64, burst int) {
+	c.limiter = newBroadcastLimiter(rate.Limit(limit), burst)
+}
+
+// SetDecisionSLA overrides the default maximum duration to decide a consensus instance.
+// Slower instances increment the SLA breach counter.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetDecisionSLA(sla time.Duration) {
+	c.decisionSLA = sla
+}
+
+// SetRecvBufferSize overrides the default size of the instance receive buffers.
+// Larger buffers prevent blocking under bursty load at the cost of memory.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetRecvBufferSize(size int) {
+	c.recvBufferSize = size
+}
+
+// SetMaxActiveInstances overrides the default expected bound of concurrently active consensus instances.
+// A warning is logged when it is exceeded, since it indicates instances are leaking.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetMaxActiveInstances(limit int) {
+	c.maxActiveInstances = limit
+}
+
+// SetSilentPeerInstances overrides the default number of consecutive consensus instances a peer
+// must be absent from before it is flagged as silent.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetSilentPeerInstances(instances int) {
+	c.silentPeers = newSilentPeers(c.peers, instances)
+}
+
+// SetPeerWeights overrides the default equal voting weight (1) of peers by index.
+// Quorum is then reached by the sum of the weights of the peers rather than by their count.
+// It returns an error if the weights allow deciding with fewer peers than the signing threshold,
+// since the decided duty data couldn't be signed then. Unweighted definitions are not validated,
+// since small clusters may have quorums below the threshold by design.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetPeerWeights(weights map[int64]int, threshold int) error {
+	def := c.def
+	def.Weights = weights
+
+	if deciders := minDeciders(def); len(weights) > 0 && deciders < threshold {
+		return errors.New("peer weights allow deciding below signing threshold",
+			z.Int("min_deciders", deciders), z.Int("threshold", threshold))
+	}
+
+	c.def.Weights = weights
+	c.def.LogRoundChange = newRoundChangeLogger(c.def.Nodes, weights)
+
+	return nil
+}
+
+// minDeciders returns the minimum number of peers whose summed weight reaches the quorum of the weighted definition.
+func minDeciders(def qbft.Definition[core.Duty, [32]byte]) int {
+	var weights []int
+	for i := 0; i < def.Nodes; i++ {
+		weights = append(weights, def.Weight(int64(i)))
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(weights)))
+
+	var total int
+	for i, w := range weights {
+		total += w
+		if total >= def.Quorum() {
+			return i + 1
+		}
+	}
+
+	return def.Nodes + 1 // Quorum not reachable.
+}
+
+// SetRoundTimeout overrides the default round timeout curve.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetRoundTimeout(timeout RoundTimeout) {
+	c.def.NewTimer = newRoundTimer(timeout)
+}
+
+// SetValueValidator registers a validator of proposed values received in pre-prepare messages.
+// Rejected proposals are dropped before they are processed by QBFT.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetValueValidator(fn ValueValidator) {
+	c.validator = fn
 }
 
 // Subscribe registers a callback for unsigned duty data proposals from leaders.
@@ -230,6 +336,7 @@ func (c *Component) Start(ctx context.Context) {
 				return
 			case duty := <-c.deadliner.C():
 				c.deleteRecvChan(duty)
+				c.silentPeers.Expire(log.WithTopic(ctx, "qbft"), duty)
 			}
 		}
 	}()
@@ -282,19 +389,32 @@ func (c *Component) propose(ctx context.Context, duty core.Duty, value proto.Mes
 		return err
 	}
 
+	instanceID := newInstanceID(c.instanceNonce, duty)
+	ctx = log.WithCtx(ctx, z.Hex("instance", instanceID))
+
 	// Create a transport handles sending and receiving for this instance.
 	t := transport{
 		component:  c,
-		values:     map[[32]byte]*anypb.Any{hash: anyValue},
-		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte]),
+		values:     make(map[[32]byte]*anypb.Any),
+		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte], c.recvBufferSize),
 		sniffer:    newSniffer(int64(c.def.Nodes), peerIdx),
+		peerIdx:    peerIdx,
+		instanceID: instanceID,
+		validator:  c.validator,
 	}
 
+	t.cacheValues(map[[32]byte]*anypb.Any{hash: anyValue})
+	defer t.evictValues()
+	defer c.trackInstance(ctx)()
+
 	// Provide sniffed buffer to snifferFunc at the end.
 	defer func() {
 		c.snifferFunc(t.sniffer.Instance())
 	}()
 
+	// Detect silent peers by the messages received until the instance deadline.
+	c.silentPeers.Start(duty, peerIdx)
+
 	// Start a receiving goroutine.
 	go t.ProcessReceives(ctx, c.getRecvBuffer(duty))
 
@@ -313,7 +433,7 @@ func (c *Component) propose(ctx context.Context, duty core.Duty, value proto.Mes
 	// Wrap Decide function of c.def to instrument consensus instance with provided start time (t0) and decided round.
 	def.Decide = func(ctx context.Context, duty core.Duty, val [32]byte, qcommit []qbft.Msg[core.Duty, [32]byte]) {
 		decided = true
-		instrumentConsensus(duty, qcommit[0].Round(), t0)
+		instrumentConsensus(duty, qcommit, t0, c.decisionSLA)
 		c.def.Decide(ctx, duty, val, qcommit)
 	}
 
@@ -333,6 +453,32 @@ func (c *Component) propose(ctx context.Context, duty core.Duty, value proto.Mes
 	return nil
 }
 
+// trackInstance instruments a new active consensus instance, logging a warning once the expected bound is exceeded
+// since it indicates instances are leaking. It returns a function that must be called when the instance completes.
+func (c *Component) trackInstance(ctx context.Context) func() {
+	activeInstancesGauge.Inc()
+	if active := c.activeInstances.Add(1); active == int64(c.maxActiveInstances)+1 {
+		log.Warn(ctx, "Active consensus instances exceed expected bound, possible leak", nil,
+			z.I64("active", active), z.Int("bound", c.maxActiveInstances))
+	}
+
+	return func() {
+		activeInstancesGauge.Dec()
+		c.activeInstances.Add(-1)
+	}
+}
+
+// newInstanceID returns the ID of the duty's consensus instance derived from the duty and the cluster nonce.
+// It is identical for all peers, correlating all messages of a consensus instance.
+func newInstanceID(nonce [32]byte, duty core.Duty) []byte {
+	h := sha256.New()
+	_, _ = h.Write(nonce[:])
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(duty.Slot)))
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(duty.Type)))
+
+	return h.Sum(nil)[:16]
+}
+
 // handle processes an incoming consensus wire message.
 func (c *Component) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
 	t0 := time.Now()
@@ -385,8 +531,12 @@ func (c *Component) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 			z.Any("after", time.Since(t0)))
 	}
 
+	c.silentPeers.Received(duty, pbMsg.Msg.PeerIdx)
+
+	recvBuffer := c.getRecvBuffer(duty)
 	select {
-	case c.getRecvBuffer(duty) <- msg:
+	case recvBuffer <- msg:
+		recvBufferGauge.WithLabelValues(duty.Type.String()).Set(float64(len(recvBuffer)))
 		return nil, false, nil
 	case <-ctx.Done():
 		return nil, false, errors.Wrap(ctx.Err(), "timeout enqueuing receive buffer",
@@ -401,7 +551,7 @@ func (c *Component) getRecvBuffer(duty core.Duty) chan msg {
 
 	ch, ok := c.recvBuffers[duty]
 	if !ok {
-		ch = make(chan msg, recvBuffer)
+		ch = make(chan msg, c.recvBufferSize)
 		c.recvBuffers[duty] = ch
 	}
 
@@ -409,13 +559,27 @@ func (c *Component) getRecvBuffer(duty core.Duty) chan msg {
 }
 
 // deleteRecvChan deletes the receive channel and recvDropped map entry for the duty.
+// The receive buffer usage is reset since undelivered messages of the expired duty are dropped.
 func (c *Component) deleteRecvChan(duty core.Duty) {
 	c.recvMu.Lock()
 	defer c.recvMu.Unlock()
 
+	if _, ok := c.recvBuffers[duty]; ok {
+		recvBufferGauge.WithLabelValues(duty.Type.String()).Set(0)
+	}
+
 	delete(c.recvBuffers, duty)
 }
 
+// defaultRecvBufferSize returns the default receive buffer size scaled by the number of nodes.
+func defaultRecvBufferSize(nodes int) int {
+	if size := nodes * recvPerNode; size > recvBuffer {
+		return size
+	}
+
+	return recvBuffer
+}
+
 // getPeerIdx returns the local peer index.
 func (c *Component) getPeerIdx() (int64, error) {
 	peerIdx := int64(-1)
@@ -496,14 +660,52 @@ func groupRoundMessages(msgs []qbft.Msg[core.Duty, [32]byte], peers int, round i
 	return resp
 }
 
-func timeoutReason(steps []roundStep, round int64, quorum int) string {
+// newRoundChangeLogger returns a qbft round change logger logging at debug level, including the
+// timeout reason based on the weighted quorum of the peers.
+func newRoundChangeLogger(nodes int, weights map[int64]int) func(context.Context, core.Duty, int64, int64, int64,
+	qbft.UponRule, []qbft.Msg[core.Duty, [32]byte],
+) {
+	def := qbft.Definition[core.Duty, [32]byte]{Nodes: nodes, Weights: weights}
+
+	return func(ctx context.Context, duty core.Duty, process,
+		round, newRound int64, uponRule qbft.UponRule, msgs []qbft.Msg[core.Duty, [32]byte],
+	) {
+		fields := []z.Field{
+			z.Any("rule", uponRule),
+			z.I64("round", round),
+			z.I64("new_round", newRound),
+		}
+
+		steps := groupRoundMessages(msgs, nodes, round, int(leader(duty, round, nodes)))
+		for _, step := range steps {
+			fields = append(fields, z.Str(step.Type.String(), fmtStepPeers(step)))
+		}
+		if uponRule == qbft.UponRoundTimeout {
+			fields = append(fields, z.Str("timeout_reason", timeoutReason(steps, round, def)))
+		}
+
+		log.Debug(ctx, "QBFT round changed", fields...)
+	}
+}
+
+// timeoutReason returns the reason of a round timeout, comparing the weight of the present peers to the quorum.
+func timeoutReason(steps []roundStep, round int64, def qbft.Definition[core.Duty, [32]byte]) string {
 	byType := make(map[qbft.MsgType]roundStep)
 	for _, step := range steps {
 		byType[step.Type] = step
 	}
 
+	belowQuorum := func(step roundStep) bool {
+		var weight int
+		for _, peer := range step.Present {
+			weight += def.Weight(int64(peer))
+		}
+
+		return weight < def.Quorum()
+	}
+
 	if round > 1 { // Quorum round changes are required for leader to propose for rounds > 1.
-		if step := byType[qbft.MsgRoundChange]; len(step.Present) < quorum {
+		if step := byType[qbft.MsgRoundChange]; belowQuorum(step) {
 			return "insufficient round-changes, missing peers=" + fmt.Sprint(step.Missing)
 		}
 	}
@@ -512,11 +714,11 @@ func timeoutReason(steps []roundStep, round int64, quorum int) string {
 		return "no pre-prepare, missing leader=" + fmt.Sprint(step.Missing)
 	}
 
-	if step := byType[qbft.MsgPrepare]; len(step.Present) < quorum {
+	if step := byType[qbft.MsgPrepare]; belowQuorum(step) {
 		return "insufficient prepares, missing peers=" + fmt.Sprint(step.Missing)
 	}
 
-	if step := byType[qbft.MsgCommit]; len(step.Present) < quorum {
+	if step := byType[qbft.MsgCommit]; belowQuorum(step) {
 		return "insufficient commits, missing peers=" + fmt.Sprint(step.Missing)
 	}
 
@@ -546,14 +748,78 @@ func leader(duty core.Duty, round int64, nodes int) int64 {
 	return ((duty.Slot) + int64(duty.Type) + round) % int64(nodes)
 }
 
-// newRoundTimer returns a 750ms+(round*250ms) period timer.
+// RoundTimeout defines the curve of QBFT round timeouts by round:
+//
+//	timeout(0) = Base
+//	timeout(r) = min(Max, timeout(r-1)*Multiplier + Increase)
 //
 // TODO(corver): Round timeout is a tradeoff between fast rounds to skip unavailable nodes
 // and slow rounds to allow consensus in high latency clusters. Dynamic timeout based on
 // recent network conditions could be an option.
-func newRoundTimer(round int64) (<-chan time.Time, func()) {
-	timer := time.NewTimer(roundStart + (time.Duration(round) * roundIncrease))
-	return timer.C, func() { timer.Stop() }
+type RoundTimeout struct {
+	Base       time.Duration // Timeout of round zero.
+	Increase   time.Duration // Linear increase per round.
+	Multiplier float64       // Exponential growth factor per round, 1 for linear growth.
+	Max        time.Duration // Maximum timeout, zero for no cap.
+}
+
+// DefaultRoundTimeout returns the default linear 750ms+(round*250ms) round timeout curve.
+func DefaultRoundTimeout() RoundTimeout {
+	return RoundTimeout{
+		Base:       roundStart,
+		Increase:   roundIncrease,
+		Multiplier: 1,
+	}
+}
+
+// Validate returns an error if the round timeout curve is invalid.
+func (t RoundTimeout) Validate() error {
+	if t.Base <= 0 {
+		return errors.New("round timeout base must be positive", z.Any("base", t.Base))
+	} else if t.Increase < 0 {
+		return errors.New("round timeout increase must not be negative", z.Any("increase", t.Increase))
+	} else if t.Multiplier < 1 {
+		return errors.New("round timeout multiplier must be at least 1", z.Any("multiplier", t.Multiplier))
+	} else if t.Max < 0 {
+		return errors.New("round timeout max must not be negative", z.Any("max", t.Max))
+	}
+
+	return nil
+}
+
+// Timeout returns the timeout of the round.
+func (t RoundTimeout) Timeout(round int64) time.Duration {
+	timeout := t.Base
+	for i := int64(0); i < round; i++ {
+		timeout = time.Duration(float64(timeout)*t.Multiplier) + t.Increase
+		if t.Max > 0 && timeout >= t.Max {
+			return t.Max
+		}
+	}
+
+	if t.Max > 0 && timeout > t.Max {
+		return t.Max
+	}
+
+	return timeout
+}
+
+// newRoundTimer returns a function returning a timer of the round's timeout.
+// The effective timeout is instrumented by round.
+func newRoundTimer(timeout RoundTimeout) func(round int64) (<-chan time.Time, func()) {
+	return func(round int64) (<-chan time.Time, func()) {
+		d := timeout.Timeout(round)
+
+		label := strconv.FormatInt(round, 10)
+		if round > roundMaxLabel {
+			label = fmt.Sprintf("%d+", roundMaxLabel+1)
+		}
+		roundTimeoutGauge.WithLabelValues(label).Set(d.Seconds())
+
+		timer := time.NewTimer(d)
+
+		return timer.C, func() { timer.Stop() }
+	}
 }
 
 func valuesByHash(values []*anypb.Any) (map[[32]byte]*anypb.Any, error) {
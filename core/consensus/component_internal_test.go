@@ -3,8 +3,17 @@
 package consensus
 
 import (
+	"context"
+	"strconv"
 	"testing"
+	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/promauto"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/qbft"
 	"github.com/obolnetwork/charon/testutil"
@@ -60,7 +69,7 @@ func TestDebugRoundChange(t *testing.T) {
 				Reason string
 			}{
 				Steps:  fmtSteps,
-				Reason: timeoutReason(steps, test.round, 3),
+				Reason: timeoutReason(steps, test.round, qbft.Definition[core.Duty, [32]byte]{Nodes: n}),
 			})
 		})
 	}
@@ -76,6 +85,8 @@ func m(source int64, typ qbft.MsgType) testMsg {
 type testMsg struct {
 	source int64
 	typ    qbft.MsgType
+	round  int64
+	value  [32]byte
 }
 
 func (t testMsg) Type() qbft.MsgType {
@@ -91,21 +102,322 @@ func (t testMsg) Source() int64 {
 }
 
 func (t testMsg) Round() int64 {
-	panic("implement me")
+	return t.round
 }
 
 func (t testMsg) Value() [32]byte {
-	panic("implement me")
+	return t.value
 }
 
 func (t testMsg) PreparedRound() int64 {
-	panic("implement me")
+	return 0
 }
 
 func (t testMsg) PreparedValue() [32]byte {
-	panic("implement me")
+	return [32]byte{}
 }
 
 func (t testMsg) Justification() []qbft.Msg[core.Duty, [32]byte] {
-	panic("implement me")
+	return nil
+}
+
+func TestSetPeerWeights(t *testing.T) {
+	newComp := func(nodes int) *Component {
+		return &Component{def: newDefinition(nodes, nil)}
+	}
+
+	// Unweighted quorum of 4 nodes is 3, matching the threshold.
+	c := newComp(4)
+	require.NoError(t, c.SetPeerWeights(nil, 3))
+	require.Equal(t, 3, minDeciders(c.def))
+
+	// A heavy peer allows deciding with 2 peers below the threshold.
+	c = newComp(4)
+	err := c.SetPeerWeights(map[int64]int{0: 3}, 3)
+	require.ErrorContains(t, err, "peer weights allow deciding below signing threshold")
+	require.Nil(t, c.def.Weights)
+
+	// Weighted quorum of 7 nodes with total weight 8 is 6, requiring 5 peers.
+	c = newComp(7)
+	require.NoError(t, c.SetPeerWeights(map[int64]int{0: 2}, 5))
+	require.Equal(t, 6, c.def.Quorum())
+	require.Equal(t, 5, minDeciders(c.def))
+
+	// Observers don't count towards the quorum.
+	c = newComp(5)
+	require.NoError(t, c.SetPeerWeights(map[int64]int{4: 0}, 3))
+	require.Equal(t, 3, c.def.Quorum())
+
+	// Timeout reasons are based on the weighted quorum, 5 commits of weight 6 reach the quorum.
+	steps := []roundStep{
+		{Type: qbft.MsgPrePrepare, Present: []int{0}},
+		{Type: qbft.MsgPrepare, Present: []int{0, 1, 2, 3, 4}, Missing: []int{5, 6}},
+		{Type: qbft.MsgCommit, Present: []int{1, 2, 3, 4, 5}, Missing: []int{0, 6}},
+	}
+	weighted := qbft.Definition[core.Duty, [32]byte]{Nodes: 7, Weights: map[int64]int{0: 2}}
+	require.Equal(t, "insufficient commits, missing peers=[0 6]", timeoutReason(steps, 1, weighted))
+}
+
+func TestInstrumentConsensusSLA(t *testing.T) {
+	const sla = time.Second
+
+	fast := core.NewAttesterDuty(1)
+	slow := core.NewProposerDuty(1)
+
+	fastBefore := promtestutil.ToFloat64(slaBreachCounter.WithLabelValues(fast.Type.String()))
+	slowBefore := promtestutil.ToFloat64(slaBreachCounter.WithLabelValues(slow.Type.String()))
+
+	instrumentConsensus(fast, commits(1, 3), time.Now(), sla)
+	instrumentConsensus(slow, commits(2, 3), time.Now().Add(-2*sla), sla)
+
+	require.Equal(t, fastBefore, promtestutil.ToFloat64(slaBreachCounter.WithLabelValues(fast.Type.String())))
+	require.Equal(t, slowBefore+1, promtestutil.ToFloat64(slaBreachCounter.WithLabelValues(slow.Type.String())))
+}
+
+// commits returns quorum commits of the same value for the round.
+func commits(round int64, quorum int) []qbft.Msg[core.Duty, [32]byte] {
+	var resp []qbft.Msg[core.Duty, [32]byte]
+	for i := 0; i < quorum; i++ {
+		resp = append(resp, testMsg{source: int64(i), typ: qbft.MsgCommit, round: round, value: [32]byte{1}})
+	}
+
+	return resp
+}
+
+func TestIsFastPath(t *testing.T) {
+	require.True(t, isFastPath(commits(1, 3)))
+	require.False(t, isFastPath(commits(2, 3)))
+	require.False(t, isFastPath(nil))
+
+	mixed := commits(1, 3)
+	mixed[2] = testMsg{source: 2, typ: qbft.MsgCommit, round: 1, value: [32]byte{2}}
+	require.False(t, isFastPath(mixed))
+}
+
+func TestFastPath(t *testing.T) {
+	const n = 4
+
+	duty := core.NewAttesterDuty(1)
+	before := promtestutil.ToFloat64(fastPathCounter.WithLabelValues(duty.Type.String()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	def := newDefinition(n, func() []subscriber { return nil })
+	def.NewTimer = func(int64) (<-chan time.Time, func()) {
+		return nil, func() {} // Never timeout, so deciding requires no round changes.
+	}
+
+	decided := make(chan []qbft.Msg[core.Duty, [32]byte], n)
+	def.Decide = func(_ context.Context, duty core.Duty, _ [32]byte, qcommit []qbft.Msg[core.Duty, [32]byte]) {
+		instrumentConsensus(duty, qcommit, time.Now(), time.Minute)
+		decided <- qcommit
+	}
+
+	var recvs []chan qbft.Msg[core.Duty, [32]byte]
+	for i := 0; i < n; i++ {
+		recvs = append(recvs, make(chan qbft.Msg[core.Duty, [32]byte], 100))
+	}
+
+	broadcast := func(_ context.Context, typ qbft.MsgType, _ core.Duty, source int64, round int64, value [32]byte,
+		_ int64, _ [32]byte, _ []qbft.Msg[core.Duty, [32]byte],
+	) error {
+		for _, recv := range recvs {
+			recv <- testMsg{source: source, typ: typ, round: round, value: value}
+		}
+
+		return nil
+	}
+
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		qt := qbft.Transport[core.Duty, [32]byte]{Broadcast: broadcast, Receive: recvs[i]}
+		go func(i int) {
+			// All peers propose the same value.
+			errs <- qbft.Run[core.Duty, [32]byte](ctx, def, qt, duty, int64(i), [32]byte{1})
+		}(i)
+	}
+
+	for i := 0; i < n; i++ {
+		qcommit := <-decided
+		require.True(t, isFastPath(qcommit))
+	}
+
+	cancel()
+	for i := 0; i < n; i++ {
+		require.ErrorIs(t, <-errs, context.Canceled)
+	}
+
+	require.Equal(t, before+n, promtestutil.ToFloat64(fastPathCounter.WithLabelValues(duty.Type.String())))
+}
+
+func TestMetricsNamespace(t *testing.T) {
+	instrumentConsensus(core.NewAttesterDuty(1), commits(1, 3), time.Now(), time.Minute)
+
+	registry, err := promauto.NewRegistry(nil, promauto.WithNamespace("myorg"))
+	require.NoError(t, err)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var found bool
+	for _, fam := range families {
+		if fam.GetName() == "myorg_core_consensus_decided_rounds" {
+			found = true
+		}
+		require.NotEqual(t, "core_consensus_decided_rounds", fam.GetName())
+	}
+	require.True(t, found)
+}
+
+func TestDurationBuckets(t *testing.T) {
+	err := InitDurationMetric([]float64{1, 0.5})
+	require.ErrorContains(t, err, "consensus duration buckets not in increasing order")
+
+	consensusDuration() // Ensure the histogram is created with default buckets.
+
+	require.NoError(t, InitDurationMetric(nil))
+	require.NoError(t, InitDurationMetric(defaultDurationBuckets))
+
+	err = InitDurationMetric([]float64{1, 2, 5})
+	require.ErrorContains(t, err, "consensus duration histogram already created with different buckets")
+
+	registry, err := promauto.NewRegistry(nil)
+	require.NoError(t, err)
+
+	duty := core.NewProposerDuty(1)
+	instrumentConsensus(duty, commits(1, 3), time.Now().Add(-1500*time.Millisecond), time.Minute)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var found bool
+	for _, fam := range families {
+		if fam.GetName() != "core_consensus_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range fam.GetMetric() {
+			if metric.GetLabel()[0].GetValue() != duty.Type.String() {
+				continue
+			}
+			found = true
+
+			hist := metric.GetHistogram()
+			require.Len(t, hist.GetBucket(), len(defaultDurationBuckets))
+			for i, bucket := range hist.GetBucket() {
+				require.Equal(t, defaultDurationBuckets[i], bucket.GetUpperBound())
+			}
+		}
+	}
+	require.True(t, found)
+}
+
+func TestRecvBufferSize(t *testing.T) {
+	require.Equal(t, recvBuffer, defaultRecvBufferSize(4))
+	require.Equal(t, 10*recvPerNode, defaultRecvBufferSize(10))
+
+	// enqueue returns the number of messages enqueued without blocking.
+	enqueue := func(c *Component, n int) int {
+		ch := c.getRecvBuffer(core.NewAttesterDuty(1))
+		for i := 0; i < n; i++ {
+			select {
+			case ch <- msg{}:
+			default:
+				return i
+			}
+		}
+
+		return n
+	}
+
+	const burst = 500
+
+	c := &Component{recvBuffers: make(map[core.Duty]chan msg), recvBufferSize: defaultRecvBufferSize(4)}
+	require.Equal(t, recvBuffer, enqueue(c, burst)) // Default blocks.
+
+	c = &Component{recvBuffers: make(map[core.Duty]chan msg), recvBufferSize: defaultRecvBufferSize(4)}
+	c.SetRecvBufferSize(burst)
+	require.Equal(t, burst, enqueue(c, burst))
+}
+
+func TestRoundTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout RoundTimeout
+		want    []time.Duration // Rounds 1, 2, 3.
+	}{
+		{
+			name:    "default",
+			timeout: DefaultRoundTimeout(),
+			want:    []time.Duration{time.Second, 1250 * time.Millisecond, 1500 * time.Millisecond},
+		},
+		{
+			name:    "exponential",
+			timeout: RoundTimeout{Base: time.Second, Multiplier: 2},
+			want:    []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			name:    "capped",
+			timeout: RoundTimeout{Base: time.Second, Increase: time.Second, Multiplier: 1.5, Max: 4 * time.Second},
+			want:    []time.Duration{2500 * time.Millisecond, 4 * time.Second, 4 * time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, test.timeout.Validate())
+
+			c := &Component{}
+			c.SetRoundTimeout(test.timeout)
+
+			for i, want := range test.want {
+				round := int64(i + 1)
+				require.Equal(t, want, test.timeout.Timeout(round))
+
+				_, stop := c.def.NewTimer(round)
+				stop()
+				require.Equal(t, want.Seconds(), promtestutil.ToFloat64(roundTimeoutGauge.WithLabelValues(strconv.FormatInt(round, 10))))
+			}
+		})
+	}
+
+	require.ErrorContains(t, RoundTimeout{Base: time.Second}.Validate(), "multiplier")
+	require.ErrorContains(t, RoundTimeout{Multiplier: 1}.Validate(), "base")
+}
+
+func TestTrackInstance(t *testing.T) {
+	var buf zaptest.Buffer
+	log.InitLogfmtForT(t, &buf)
+
+	const bound = 3
+
+	c := &Component{maxActiveInstances: bound}
+	ctx := context.Background()
+	before := promtestutil.ToFloat64(activeInstancesGauge)
+
+	// Completed instances keep the gauge bounded.
+	for i := 0; i < 10; i++ {
+		done := c.trackInstance(ctx)
+		require.Equal(t, before+1, promtestutil.ToFloat64(activeInstancesGauge))
+		done()
+	}
+	require.Equal(t, before, promtestutil.ToFloat64(activeInstancesGauge))
+	require.Empty(t, buf.String())
+
+	// Leaked instances trip the warning once the bound is exceeded.
+	var leaked []func()
+	for i := 0; i < bound; i++ {
+		leaked = append(leaked, c.trackInstance(ctx))
+	}
+	require.Empty(t, buf.String())
+
+	leaked = append(leaked, c.trackInstance(ctx))
+	require.Equal(t, before+bound+1, promtestutil.ToFloat64(activeInstancesGauge))
+	require.Contains(t, buf.String(), "possible leak")
+
+	for _, done := range leaked {
+		done()
+	}
+	require.Equal(t, before, promtestutil.ToFloat64(activeInstancesGauge))
 }
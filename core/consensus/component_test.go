@@ -47,7 +47,7 @@ func TestComponent(t *testing.T) {
 		components  []*consensus.Component
 		results     = make(chan core.UnsignedDataSet, nodes)
 		runErrs     = make(chan error, nodes)
-		sniffed     = make(chan int, nodes)
+		sniffed     = make(chan *pbv1.SniffedConsensusInstance, nodes)
 		ctx, cancel = context.WithCancel(context.Background())
 	)
 	defer cancel()
@@ -82,8 +82,8 @@ func TestComponent(t *testing.T) {
 			hosts[i].Peerstore().AddAddrs(hostsInfo[j].ID, hostsInfo[j].Addrs, peerstore.PermanentAddrTTL)
 		}
 
-		sniffer := func(msgs *pbv1.SniffedConsensusInstance) {
-			sniffed <- len(msgs.Msgs)
+		sniffer := func(instance *pbv1.SniffedConsensusInstance) {
+			sniffed <- instance
 		}
 
 		c, err := consensus.New(hosts[i], new(p2p.Sender), peers, p2pkeys[i], testDeadliner{}, sniffer, 0.5)
@@ -135,8 +135,19 @@ func TestComponent(t *testing.T) {
 
 	cancel()
 
+	// All messages of the instance share the same instance ID across all nodes.
+	var instanceID []byte
 	for i := 0; i < nodes; i++ {
-		require.NotZero(t, <-sniffed)
+		instance := <-sniffed
+		require.NotZero(t, len(instance.Msgs))
+
+		for _, msg := range instance.Msgs {
+			if instanceID == nil {
+				instanceID = msg.Msg.Msg.InstanceId
+			}
+			require.NotEmpty(t, msg.Msg.Msg.InstanceId)
+			require.Equal(t, instanceID, msg.Msg.Msg.InstanceId)
+		}
 	}
 }
 
@@ -3,14 +3,96 @@
 package consensus
 
 import (
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/promauto"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/qbft"
 )
 
+// defaultDurationBuckets are the default consensus duration histogram buckets in seconds.
+var defaultDurationBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20, 30, 60}
+
+var (
+	durationMu      sync.Mutex
+	durationBuckets []float64
+	durationVec     *prometheus.HistogramVec // Created on first use since buckets are configurable.
+)
+
+// InitDurationMetric creates the consensus duration histogram with the provided buckets in seconds,
+// or the default buckets if empty. It must be called before promauto.NewRegistry.
+func InitDurationMetric(buckets []float64) error {
+	if len(buckets) == 0 {
+		buckets = defaultDurationBuckets
+	}
+
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return errors.New("consensus duration buckets not in increasing order", z.Any("buckets", buckets))
+		}
+	}
+
+	durationMu.Lock()
+	defer durationMu.Unlock()
+
+	if durationVec != nil {
+		if !equalBuckets(durationBuckets, buckets) {
+			return errors.New("consensus duration histogram already created with different buckets",
+				z.Any("existing", durationBuckets), z.Any("buckets", buckets))
+		}
+
+		return nil
+	}
+
+	durationBuckets = buckets
+	durationVec = newDurationHistogram(buckets)
+
+	return nil
+}
+
+// consensusDuration returns the consensus duration histogram, creating it with the default buckets if required.
+func consensusDuration() *prometheus.HistogramVec {
+	durationMu.Lock()
+	defer durationMu.Unlock()
+
+	if durationVec == nil {
+		durationBuckets = defaultDurationBuckets
+		durationVec = newDurationHistogram(defaultDurationBuckets)
+	}
+
+	return durationVec
+}
+
+func newDurationHistogram(buckets []float64) *prometheus.HistogramVec {
+	return promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "duration_seconds",
+		Help:      "Duration of a consensus instance in seconds by duty",
+		Buckets:   buckets,
+	}, []string{"duty"})
+}
+
+// equalBuckets returns true if the buckets are identical.
+func equalBuckets(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 var (
 	decidedRoundsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "core",
@@ -19,12 +101,25 @@ var (
 		Help:      "Number of rounds it took to decide consensus instances by duty type.",
 	}, []string{"duty"}) // Using gauge since the value changes slowly, once per slot.
 
-	consensusDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	fastPathCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "consensus",
-		Name:      "duration_seconds",
-		Help:      "Duration of a consensus instance in seconds by duty",
-		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20, 30, 60},
+		Name:      "fastpath_total",
+		Help:      "Total count of consensus instances decided via the fast path by unanimous first round commits by duty",
+	}, []string{"duty"})
+
+	roundTimeoutGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "round_timeout_seconds",
+		Help:      "Effective consensus round timeout in seconds by round",
+	}, []string{"round"})
+
+	slaBreachCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "sla_breach_total",
+		Help:      "Total count of consensus instances that exceeded the decision latency SLA by duty",
 	}, []string{"duty"})
 
 	consensusTimeout = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -34,6 +129,55 @@ var (
 		Help:      "Total count of consensus timeouts by duty",
 	}, []string{"duty"})
 
+	duplicateCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "duplicate_msg_total",
+		Help:      "Total count of dropped duplicate or self-originated consensus messages by duty",
+	}, []string{"duty"})
+
+	invalidValueCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "invalid_value_total",
+		Help:      "Total count of dropped pre-prepare messages with proposed values rejected by the value validator by duty",
+	}, []string{"duty"})
+
+	broadcastThrottledCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "broadcast_throttled_total",
+		Help:      "Total count of consensus messages not broadcast to a peer due to rate limiting by peer",
+	}, []string{"peer"})
+
+	recvBufferGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "recv_buffer_usage",
+		Help:      "Number of messages in the consensus instance receive buffer by duty",
+	}, []string{"duty"})
+
+	activeInstancesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "active_instances",
+		Help:      "Number of currently active consensus instances",
+	})
+
+	cachedValuesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "cached_values",
+		Help:      "Number of proposed values cached by active consensus instances",
+	})
+
+	silentPeerGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "silent_peer",
+		Help:      "Set to 1 if no consensus messages were received from the peer in recent consensus instances, else 0, by peer",
+	}, []string{"peer"})
+
 	consensusError = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "consensus",
@@ -42,7 +186,35 @@ var (
 	})
 )
 
-func instrumentConsensus(duty core.Duty, round int64, startTime time.Time) {
-	decidedRoundsGauge.WithLabelValues(duty.Type.String()).Set(float64(round))
-	consensusDuration.WithLabelValues(duty.Type.String()).Observe(time.Since(startTime).Seconds())
+func instrumentConsensus(duty core.Duty, qcommit []qbft.Msg[core.Duty, [32]byte], startTime time.Time, sla time.Duration) {
+	duration := time.Since(startTime)
+
+	decidedRoundsGauge.WithLabelValues(duty.Type.String()).Set(float64(qcommit[0].Round()))
+	if isFastPath(qcommit) {
+		fastPathCounter.WithLabelValues(duty.Type.String()).Inc()
+	}
+	consensusDuration().WithLabelValues(duty.Type.String()).Observe(duration.Seconds())
+
+	if duration > sla {
+		slaBreachCounter.WithLabelValues(duty.Type.String()).Inc()
+	}
+}
+
+// isFastPath returns true if the quorum commits that decided the instance are all first round commits
+// of the same value. This is the fast path of healthy clusters: the first round leader's proposal is
+// prepared and committed by a quorum of peers and the instance decides immediately on receipt of the
+// quorum commits, without any round change or waiting for the round timeout. Deciding any earlier, e.g. on
+// unanimous prepares, isn't safe, since a subsequent round change may then justify a different value.
+func isFastPath(qcommit []qbft.Msg[core.Duty, [32]byte]) bool {
+	if len(qcommit) == 0 {
+		return false
+	}
+
+	for _, commit := range qcommit {
+		if commit.Type() != qbft.MsgCommit || commit.Round() != 1 || commit.Value() != qcommit[0].Value() {
+			return false
+		}
+	}
+
+	return true
 }
@@ -0,0 +1,56 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultBroadcastLimit is the default maximum sustained rate of messages per second broadcast to each peer.
+	// It is well above normal duty rates, only throttling buggy or malicious duty schedulers.
+	DefaultBroadcastLimit = 100.0
+	// DefaultBroadcastBurst is the default maximum burst of messages broadcast to each peer.
+	DefaultBroadcastBurst = 200
+)
+
+// newBroadcastLimiter returns a new broadcastLimiter with the provided per peer limit and burst.
+func newBroadcastLimiter(limit rate.Limit, burst int) *broadcastLimiter {
+	return &broadcastLimiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: make(map[peer.ID]*rate.Limiter),
+	}
+}
+
+// broadcastLimiter limits the rate of messages broadcast to each peer.
+type broadcastLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[peer.ID]*rate.Limiter
+}
+
+// Allow returns true if a message may be broadcast to the peer now.
+func (l *broadcastLimiter) Allow(p peer.ID) bool {
+	return l.allowAt(p, time.Now())
+}
+
+// allowAt returns true if a message may be broadcast to the peer at the provided time.
+func (l *broadcastLimiter) allowAt(p peer.ID, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters[p]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[p] = limiter
+	}
+
+	return limiter.AllowN(now, 1)
+}
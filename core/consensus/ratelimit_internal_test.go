@@ -0,0 +1,47 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastLimiter(t *testing.T) {
+	const (
+		limit = 10 // Messages per second
+		burst = 2
+	)
+
+	var (
+		peerA = peer.ID("a")
+		peerB = peer.ID("b")
+		t0    = time.Now()
+	)
+
+	t.Run("exceeding rate", func(t *testing.T) {
+		limiter := newBroadcastLimiter(limit, burst)
+
+		require.True(t, limiter.allowAt(peerA, t0))
+		require.True(t, limiter.allowAt(peerA, t0))
+		require.False(t, limiter.allowAt(peerA, t0))
+
+		// Other peers are not affected.
+		require.True(t, limiter.allowAt(peerB, t0))
+
+		// Tokens are replenished over time.
+		require.True(t, limiter.allowAt(peerA, t0.Add(time.Second/limit)))
+	})
+
+	t.Run("under rate", func(t *testing.T) {
+		limiter := newBroadcastLimiter(limit, burst)
+
+		for i := 0; i < 100; i++ {
+			now := t0.Add(time.Duration(i) * time.Second / limit)
+			require.True(t, limiter.allowAt(peerA, now))
+		}
+	})
+}
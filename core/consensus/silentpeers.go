@@ -0,0 +1,113 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// DefaultSilentPeerInstances is the default number of consecutive consensus instances a peer
+// must be absent from before it is flagged as silent.
+const DefaultSilentPeerInstances = 10
+
+// newSilentPeers returns a new silentPeers detector of the cluster peers flagging peers absent
+// from the provided number of consecutive instances.
+func newSilentPeers(peers []p2p.Peer, instances int) *silentPeers {
+	return &silentPeers{
+		peers:     peers,
+		instances: instances,
+		absent:    make(map[int64]int),
+		active:    make(map[core.Duty]*instancePeers),
+	}
+}
+
+// instancePeers tracks the peers that sent messages for a consensus instance.
+type instancePeers struct {
+	started bool  // True if this node participates in the instance.
+	self    int64 // Peer index of this node.
+	present map[int64]bool
+}
+
+// silentPeers detects peers that stopped contributing messages to consensus instances.
+// A peer is present in an instance if any of its messages is received before the instance deadline.
+type silentPeers struct {
+	peers     []p2p.Peer
+	instances int
+
+	mu     sync.Mutex
+	absent map[int64]int // Number of consecutive instances each peer was absent from by peer index.
+	active map[core.Duty]*instancePeers
+}
+
+// getOrCreate returns the instance peers of the duty, creating it if absent. It must be called with the lock held.
+func (s *silentPeers) getOrCreate(duty core.Duty) *instancePeers {
+	inst, ok := s.active[duty]
+	if !ok {
+		inst = &instancePeers{present: make(map[int64]bool)}
+		s.active[duty] = inst
+	}
+
+	return inst
+}
+
+// Start records that this node participates in the duty's consensus instance.
+func (s *silentPeers) Start(duty core.Duty, self int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inst := s.getOrCreate(duty)
+	inst.started = true
+	inst.self = self
+}
+
+// Received records a message received from the peer for the duty's consensus instance.
+func (s *silentPeers) Received(duty core.Duty, peerIdx int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getOrCreate(duty).present[peerIdx] = true
+}
+
+// Expire completes the duty's consensus instance once its deadline is reached,
+// logging a warning and setting the silent peer gauge once a peer is absent from the last N instances.
+// Instances this node didn't participate in are ignored.
+func (s *silentPeers) Expire(ctx context.Context, duty core.Duty) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.active[duty]
+	delete(s.active, duty)
+	if !ok || !inst.started {
+		return
+	}
+
+	for i, p := range s.peers {
+		peerIdx := int64(i)
+		if peerIdx == inst.self {
+			continue // Own messages are not received.
+		}
+
+		if inst.present[peerIdx] {
+			if s.absent[peerIdx] >= s.instances {
+				log.Info(ctx, "Silent consensus peer contributing again", z.Str("peer", p.Name))
+			}
+			s.absent[peerIdx] = 0
+			silentPeerGauge.WithLabelValues(p.Name).Set(0)
+
+			continue
+		}
+
+		s.absent[peerIdx]++
+		if s.absent[peerIdx] == s.instances {
+			log.Warn(ctx, "Silent consensus peer, no messages received in recent consensus instances", nil,
+				z.Str("peer", p.Name), z.Int("instances", s.instances))
+			silentPeerGauge.WithLabelValues(p.Name).Set(1)
+		}
+	}
+}
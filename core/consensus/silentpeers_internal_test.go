@@ -0,0 +1,93 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestSilentPeers(t *testing.T) {
+	var buf zaptest.Buffer
+	log.InitLogfmtForT(t, &buf)
+
+	var (
+		ctx  = context.Background()
+		slot int64
+	)
+
+	const (
+		instances = 5
+		self      = 0
+		silent    = 3
+	)
+
+	peers := []p2p.Peer{
+		{Name: "silent-test-peer-0"},
+		{Name: "silent-test-peer-1"},
+		{Name: "silent-test-peer-2"},
+		{Name: "silent-test-peer-3"},
+	}
+
+	// observe runs a consensus instance receiving a message from each of the provided peers before the deadline.
+	observe := func(s *silentPeers, senders ...int64) {
+		duty := core.NewAttesterDuty(slot)
+		slot++
+
+		s.Start(duty, self)
+		for _, sender := range senders {
+			s.Received(duty, sender)
+		}
+		s.Expire(ctx, duty)
+	}
+
+	gauge := func(peerIdx int) float64 {
+		return promtestutil.ToFloat64(silentPeerGauge.WithLabelValues(peers[peerIdx].Name))
+	}
+
+	s := newSilentPeers(peers, instances)
+
+	// Peer 3 never sends any messages.
+	for i := 0; i < instances-1; i++ {
+		observe(s, 1, 2)
+		require.Zero(t, gauge(silent))
+	}
+	require.NotContains(t, buf.String(), "Silent consensus peer")
+
+	observe(s, 1, 2)
+	require.Equal(t, 1.0, gauge(silent))
+	require.Zero(t, gauge(1))
+	require.Zero(t, gauge(2))
+	require.Contains(t, buf.String(), "Silent consensus peer")
+	require.Contains(t, buf.String(), peers[silent].Name)
+	require.NotContains(t, buf.String(), peers[self].Name, "own messages are never received")
+
+	// The peer is no longer flagged once it contributes again.
+	observe(s, 1, 2, silent)
+	require.Zero(t, gauge(silent))
+	require.Contains(t, buf.String(), "contributing again")
+
+	// Messages are counted until the instance deadline, independent of when this node starts or decides the instance.
+	late := newSilentPeers(peers, 1)
+	duty := core.NewAttesterDuty(slot)
+	late.Received(duty, silent)
+	late.Start(duty, self)
+	late.Received(duty, 1)
+	late.Received(duty, 2)
+	late.Expire(ctx, duty)
+	require.Zero(t, gauge(silent))
+
+	// Instances this node didn't participate in are ignored.
+	late.Received(core.NewAttesterDuty(slot+1), 1)
+	late.Expire(ctx, core.NewAttesterDuty(slot+1))
+	require.Zero(t, gauge(silent))
+	require.Empty(t, late.active)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	"github.com/obolnetwork/charon/core/qbft"
@@ -25,22 +26,86 @@ type transport struct {
 	component  *Component
 	recvBuffer chan qbft.Msg[core.Duty, [32]byte] // Instance inner receive buffer.
 	sniffer    *sniffer
+	peerIdx    int64  // Local peer index, messages from this index are never received via the outer buffer.
+	instanceID []byte // Correlates all messages of this consensus instance.
+	validator  ValueValidator
 
 	// Mutable state
 	valueMu sync.Mutex
 	values  map[[32]byte]*anypb.Any // maps any-wrapped proposed values to their hashes
+	evicted bool                    // True once values are evicted at the end of the instance.
+	seen    map[dedupKey]bool       // Only accessed by ProcessReceives goroutine.
+}
+
+// dedupKey uniquely identifies a received message for deduplication.
+type dedupKey struct {
+	Peer      int64
+	Duty      core.Duty
+	Round     int64
+	Type      qbft.MsgType
+	ValueHash [32]byte
+}
+
+// isDuplicate returns true if the message was originated by this node or if it was already received.
+// It records the message as seen otherwise.
+func (t *transport) isDuplicate(msg msg) bool {
+	if msg.Source() == t.peerIdx {
+		return true // Own messages are sent directly to the inner buffer in Broadcast.
+	}
+
+	key := dedupKey{
+		Peer:      msg.Source(),
+		Duty:      msg.Instance(),
+		Round:     msg.Round(),
+		Type:      msg.Type(),
+		ValueHash: msg.Value(),
+	}
+
+	if t.seen == nil {
+		t.seen = make(map[dedupKey]bool)
+	}
+
+	if t.seen[key] {
+		return true
+	}
+
+	t.seen[key] = true
+
+	return false
 }
 
 // setValues caches the values and their hashes.
 func (t *transport) setValues(msg msg) {
+	t.cacheValues(msg.values)
+}
+
+// cacheValues caches the values by their hashes, it is a noop after the values are evicted.
+func (t *transport) cacheValues(values map[[32]byte]*anypb.Any) {
 	t.valueMu.Lock()
 	defer t.valueMu.Unlock()
 
-	for k, v := range msg.values {
+	if t.evicted {
+		return
+	}
+
+	for k, v := range values {
+		if _, ok := t.values[k]; !ok {
+			cachedValuesGauge.Inc()
+		}
 		t.values[k] = v
 	}
 }
 
+// evictValues drops all cached values, it should be called when the instance completes.
+func (t *transport) evictValues() {
+	t.valueMu.Lock()
+	defer t.valueMu.Unlock()
+
+	cachedValuesGauge.Sub(float64(len(t.values)))
+	t.values = make(map[[32]byte]*anypb.Any)
+	t.evicted = true
+}
+
 // getValue returns the value by its hash.
 func (t *transport) getValue(hash [32]byte) (*anypb.Any, error) {
 	t.valueMu.Lock()
@@ -92,7 +157,8 @@ func (t *transport) Broadcast(ctx context.Context, typ qbft.MsgType, duty core.D
 	}
 
 	// Make the message
-	msg, err := createMsg(typ, duty, peerIdx, round, valueHash, value, pr, pvHash, pv, justification, t.component.privkey, t.usePointerValues())
+	msg, err := createMsg(typ, duty, peerIdx, round, valueHash, value, pr, pvHash, pv, justification,
+		t.component.privkey, t.usePointerValues(), t.instanceID)
 	if err != nil {
 		return err
 	}
@@ -112,6 +178,14 @@ func (t *transport) Broadcast(ctx context.Context, typ qbft.MsgType, duty core.D
 			continue
 		}
 
+		if !t.component.limiter.Allow(p.ID) {
+			broadcastThrottledCounter.WithLabelValues(p.Name).Inc()
+			log.Warn(ctx, "Dropping consensus message to peer due to broadcast rate limit", nil,
+				z.Str("peer", p.Name), t.component.throttleFilter)
+
+			continue
+		}
+
 		err = t.component.sender.SendAsync(ctx, t.component.tcpNode, protocolID, p.ID, msg.ToConsensusMsg())
 		if err != nil {
 			return err
@@ -122,19 +196,32 @@ func (t *transport) Broadcast(ctx context.Context, typ qbft.MsgType, duty core.D
 }
 
 // ProcessReceives processes received messages from the outer buffer until the context is closed.
-//
-
+// Messages originated by this node or already received are dropped.
 func (t *transport) ProcessReceives(ctx context.Context, outerBuffer chan msg) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case msg := <-outerBuffer:
+			recvBufferGauge.WithLabelValues(msg.Instance().Type.String()).Set(float64(len(outerBuffer)))
+
 			if err := validateMsg(msg); err != nil {
 				log.Warn(ctx, "Dropping invalid message", err)
 				continue
 			}
 
+			if err := t.validateValue(ctx, msg); err != nil {
+				invalidValueCounter.WithLabelValues(msg.Instance().Type.String()).Inc()
+				log.Warn(ctx, "Dropping invalid proposed value", err, z.I64("peer", msg.Source()))
+
+				continue
+			}
+
+			if t.isDuplicate(msg) {
+				duplicateCounter.WithLabelValues(msg.Instance().Type.String()).Inc()
+				continue
+			}
+
 			t.setValues(msg)
 
 			select {
@@ -147,6 +234,25 @@ func (t *transport) ProcessReceives(ctx context.Context, outerBuffer chan msg) {
 	}
 }
 
+// validateValue returns an error if the value proposed in a pre-prepare message is rejected by the validator.
+func (t *transport) validateValue(ctx context.Context, msg msg) error {
+	if t.validator == nil || msg.Type() != qbft.MsgPrePrepare {
+		return nil
+	}
+
+	anyValue, ok := msg.values[msg.valueHash]
+	if !ok {
+		return errors.New("pre-prepare value not found")
+	}
+
+	value, err := anyValue.UnmarshalNew()
+	if err != nil {
+		return errors.Wrap(err, "unmarshal proposed value")
+	}
+
+	return t.validator(ctx, msg.Instance(), value)
+}
+
 // createMsg returns a new message by converting the inputs into a protobuf
 // and wrapping that in a msg type.
 func createMsg(typ qbft.MsgType, duty core.Duty,
@@ -155,7 +261,7 @@ func createMsg(typ qbft.MsgType, duty core.Duty,
 	pr int64,
 	pvHash [32]byte, pv *anypb.Any,
 	justification []qbft.Msg[core.Duty, [32]byte], privkey *k1.PrivateKey,
-	pointerValues bool,
+	pointerValues bool, instanceID []byte,
 ) (msg, error) {
 	values := make(map[[32]byte]*anypb.Any)
 	if value != nil {
@@ -182,6 +288,7 @@ func createMsg(typ qbft.MsgType, duty core.Duty,
 		PreparedRound:     pr,
 		PreparedValue:     pv,
 		PreparedValueHash: pvHash[:],
+		InstanceId:        instanceID,
 	}
 
 	pbMsg, err := signMsg(pbMsg, privkey)
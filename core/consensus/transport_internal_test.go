@@ -0,0 +1,209 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+func TestProcessReceivesDuplicates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const selfIdx = 0
+
+	duty := core.NewAttesterDuty(99)
+	tr := &transport{
+		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte]),
+		sniffer:    newSniffer(4, selfIdx),
+		peerIdx:    selfIdx,
+		values:     make(map[[32]byte]*anypb.Any),
+	}
+
+	outer := make(chan msg)
+	go tr.ProcessReceives(ctx, outer)
+
+	newTestMsg := func(peerIdx int64) msg {
+		return msg{msg: &pbv1.QBFTMsg{
+			Type:    int64(qbft.MsgPrepare),
+			Duty:    core.DutyToProto(duty),
+			PeerIdx: peerIdx,
+			Round:   1,
+		}}
+	}
+
+	dupsBefore := testutil.ToFloat64(duplicateCounter.WithLabelValues(duty.Type.String()))
+
+	// Echo of own message is dropped.
+	outer <- newTestMsg(selfIdx)
+	requireNoReceive(t, tr.recvBuffer)
+
+	// First message from a peer is processed.
+	outer <- newTestMsg(1)
+	select {
+	case received := <-tr.recvBuffer:
+		require.EqualValues(t, 1, received.Source())
+	case <-time.After(time.Second):
+		require.Fail(t, "message not received")
+	}
+
+	// Duplicate message from same peer is dropped.
+	outer <- newTestMsg(1)
+	requireNoReceive(t, tr.recvBuffer)
+
+	dupsAfter := testutil.ToFloat64(duplicateCounter.WithLabelValues(duty.Type.String()))
+	require.EqualValues(t, 2, dupsAfter-dupsBefore)
+	require.Len(t, tr.sniffer.Instance().Msgs, 1)
+}
+
+func TestRecvBufferGauge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	duty := core.NewProposerDuty(99)
+	tr := &transport{
+		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte]),
+		sniffer:    newSniffer(4, 0),
+		peerIdx:    0,
+		values:     make(map[[32]byte]*anypb.Any),
+	}
+
+	// Enqueue messages before processing starts.
+	outer := make(chan msg, 2)
+	for peerIdx := int64(1); peerIdx <= 2; peerIdx++ {
+		outer <- msg{msg: &pbv1.QBFTMsg{
+			Type:    int64(qbft.MsgPrepare),
+			Duty:    core.DutyToProto(duty),
+			PeerIdx: peerIdx,
+			Round:   1,
+		}}
+	}
+	gauge := recvBufferGauge.WithLabelValues(duty.Type.String())
+	gauge.Set(float64(len(outer)))
+
+	go tr.ProcessReceives(ctx, outer)
+
+	// The gauge is updated on every dequeue.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-tr.recvBuffer:
+		case <-time.After(time.Second):
+			require.Fail(t, "message not received")
+		}
+	}
+	require.Zero(t, testutil.ToFloat64(gauge))
+}
+
+func TestProcessReceivesInvalidValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	duty := core.NewAttesterDuty(99)
+	tr := &transport{
+		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte]),
+		sniffer:    newSniffer(4, 0),
+		values:     make(map[[32]byte]*anypb.Any),
+		validator: func(_ context.Context, duty core.Duty, value proto.Message) error {
+			if value.(*pbv1.Duty).Slot != duty.Slot {
+				return errors.New("wrong slot")
+			}
+
+			return nil
+		},
+	}
+
+	outer := make(chan msg)
+	go tr.ProcessReceives(ctx, outer)
+
+	newPrePrepare := func(peerIdx int64, slot int64) msg {
+		value := &pbv1.Duty{Slot: slot}
+		hash, err := hashProto(value)
+		require.NoError(t, err)
+
+		anyValue, err := anypb.New(value)
+		require.NoError(t, err)
+
+		return msg{
+			msg: &pbv1.QBFTMsg{
+				Type:    int64(qbft.MsgPrePrepare),
+				Duty:    core.DutyToProto(duty),
+				PeerIdx: peerIdx,
+				Round:   1,
+			},
+			valueHash: hash,
+			values:    map[[32]byte]*anypb.Any{hash: anyValue},
+		}
+	}
+
+	invalidBefore := testutil.ToFloat64(invalidValueCounter.WithLabelValues(duty.Type.String()))
+
+	// Proposal with the wrong slot is dropped.
+	outer <- newPrePrepare(1, duty.Slot+1)
+	requireNoReceive(t, tr.recvBuffer)
+
+	// Valid proposal is processed.
+	outer <- newPrePrepare(2, duty.Slot)
+	select {
+	case received := <-tr.recvBuffer:
+		require.EqualValues(t, 2, received.Source())
+	case <-time.After(time.Second):
+		require.Fail(t, "message not received")
+	}
+
+	invalidAfter := testutil.ToFloat64(invalidValueCounter.WithLabelValues(duty.Type.String()))
+	require.EqualValues(t, 1, invalidAfter-invalidBefore)
+}
+
+func requireNoReceive(t *testing.T, ch chan qbft.Msg[core.Duty, [32]byte]) {
+	t.Helper()
+
+	select {
+	case <-ch:
+		require.Fail(t, "unexpected message received")
+	case <-time.After(time.Millisecond * 50):
+	}
+}
+
+func TestCachedValuesGauge(t *testing.T) {
+	tr := &transport{
+		values: make(map[[32]byte]*anypb.Any),
+	}
+
+	newValue := func(slot int64) ([32]byte, *anypb.Any) {
+		value, err := anypb.New(&pbv1.Duty{Slot: slot})
+		require.NoError(t, err)
+
+		return [32]byte{byte(slot)}, value
+	}
+
+	before := testutil.ToFloat64(cachedValuesGauge)
+
+	h1, v1 := newValue(1)
+	h2, v2 := newValue(2)
+	tr.cacheValues(map[[32]byte]*anypb.Any{h1: v1})
+	require.Equal(t, before+1, testutil.ToFloat64(cachedValuesGauge))
+
+	tr.setValues(msg{values: map[[32]byte]*anypb.Any{h1: v1, h2: v2}}) // Only h2 is new.
+	require.Equal(t, before+2, testutil.ToFloat64(cachedValuesGauge))
+
+	tr.evictValues()
+	require.Equal(t, before, testutil.ToFloat64(cachedValuesGauge))
+
+	tr.cacheValues(map[[32]byte]*anypb.Any{h1: v1}) // Noop after eviction.
+	require.Equal(t, before, testutil.ToFloat64(cachedValuesGauge))
+
+	_, err := tr.getValue(h1)
+	require.ErrorContains(t, err, "unknown value")
+}
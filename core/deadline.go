@@ -209,12 +209,18 @@ func NewDutyDeadlineFunc(ctx context.Context, eth2Cl eth2wrap.Client) (func(Duty
 		}
 
 		start := genesis.Add(duration * time.Duration(duty.Slot))
-		delta := duration * time.Duration(lateFactor)
-		if delta < lateMin {
-			delta = lateMin
-		}
-		end := start.Add(delta)
+		end := start.Add(LateDelta(duration))
 
 		return end, true
 	}, nil
 }
+
+// LateDelta returns the duration after the duty slot start that duties deadline for the slot duration.
+func LateDelta(slotDuration time.Duration) time.Duration {
+	delta := slotDuration * time.Duration(lateFactor)
+	if delta < lateMin {
+		delta = lateMin
+	}
+
+	return delta
+}
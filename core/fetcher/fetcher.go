@@ -20,11 +20,13 @@ import (
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 )
 
-// New returns a new fetcher instance.
-func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string) (*Fetcher, error) {
+// New returns a new fetcher instance. The optional graffitiFunc returns the custom graffiti of a validator,
+// the default charon graffiti is used if it is nil or returns an empty string.
+func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, graffitiFunc func(core.PubKey) string) (*Fetcher, error) {
 	return &Fetcher{
 		eth2Cl:           eth2Cl,
 		feeRecipientFunc: feeRecipientFunc,
+		graffitiFunc:     graffitiFunc,
 	}, nil
 }
 
@@ -32,6 +34,7 @@ func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string) (*Fe
 type Fetcher struct {
 	eth2Cl           eth2wrap.Client
 	feeRecipientFunc func(core.PubKey) string
+	graffitiFunc     func(core.PubKey) string
 	subs             []func(context.Context, core.Duty, core.UnsignedDataSet) error
 	aggSigDBFunc     func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
 	awaitAttDataFunc func(ctx context.Context, slot int64, commIdx int64) (*eth2p0.AttestationData, error)
@@ -225,6 +228,22 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot int64, defSet co
 	return resp, nil
 }
 
+// graffiti returns the custom graffiti of the validator from the cluster lock or the default charon graffiti.
+func (f *Fetcher) graffiti(pubkey core.PubKey) [32]byte {
+	var resp [32]byte
+	if f.graffitiFunc != nil {
+		if graffiti := f.graffitiFunc(pubkey); graffiti != "" {
+			copy(resp[:], graffiti)
+			return resp
+		}
+	}
+
+	commitSHA, _ := version.GitCommit()
+	copy(resp[:], fmt.Sprintf("charon/%s-%s", version.Version, commitSHA))
+
+	return resp
+}
+
 func (f *Fetcher) fetchProposerData(ctx context.Context, slot int64, defSet core.DutyDefinitionSet) (core.UnsignedDataSet, error) {
 	resp := make(core.UnsignedDataSet)
 	for pubkey := range defSet {
@@ -237,10 +256,7 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot int64, defSet core
 
 		randao := randaoData.Signature().ToETH2()
 
-		// TODO(dhruv): replace hardcoded graffiti with the one from cluster-lock.json
-		var graffiti [32]byte
-		commitSHA, _ := version.GitCommit()
-		copy(graffiti[:], fmt.Sprintf("charon/%s-%s", version.Version, commitSHA))
+		graffiti := f.graffiti(pubkey)
 		block, err := f.eth2Cl.BeaconBlockProposal(ctx, eth2p0.Slot(uint64(slot)), randao, graffiti[:])
 		if err != nil {
 			return nil, err
@@ -275,10 +291,7 @@ func (f *Fetcher) fetchBuilderProposerData(ctx context.Context, slot int64, defS
 
 		randao := randaoData.Signature().ToETH2()
 
-		// TODO(dhruv): replace hardcoded graffiti with the one from cluster-lock.json
-		var graffiti [32]byte
-		commitSHA, _ := version.GitCommit()
-		copy(graffiti[:], fmt.Sprintf("charon/%s-%s", version.Version, commitSHA))
+		graffiti := f.graffiti(pubkey)
 		block, err := f.eth2Cl.BlindedBeaconBlockProposal(ctx, eth2p0.Slot(uint64(slot)), randao, graffiti[:])
 		if err != nil {
 			return nil, err
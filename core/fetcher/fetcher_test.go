@@ -3,6 +3,7 @@
 package fetcher_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -62,7 +63,7 @@ func TestFetchAttester(t *testing.T) {
 	duty := core.NewAttesterDuty(slot)
 	bmock, err := beaconmock.New()
 	require.NoError(t, err)
-	fetch, err := fetcher.New(bmock, nil)
+	fetch, err := fetcher.New(bmock, nil, nil)
 	require.NoError(t, err)
 
 	fetch.Subscribe(func(ctx context.Context, resDuty core.Duty, resDataSet core.UnsignedDataSet) error {
@@ -150,7 +151,7 @@ func TestFetchAggregator(t *testing.T) {
 		return nil, errors.New("expected unknown root")
 	}
 
-	fetch, err := fetcher.New(bmock, nil)
+	fetch, err := fetcher.New(bmock, nil, nil)
 	require.NoError(t, err)
 
 	fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -231,9 +232,17 @@ func TestFetchBlocks(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("fetch DutyProposer", func(t *testing.T) {
+		const graffitiA = "custom graffiti"
+
 		duty := core.NewProposerDuty(slot)
 		fetch, err := fetcher.New(bmock, func(core.PubKey) string {
 			return feeRecipientAddr
+		}, func(pubkey core.PubKey) string {
+			if pubkey == pubkeysByIdx[vIdxA] {
+				return graffitiA
+			}
+
+			return "" // Default graffiti
 		})
 		require.NoError(t, err)
 
@@ -251,6 +260,7 @@ func TestFetchBlocks(t *testing.T) {
 			require.EqualValues(t, slot, slotA)
 			require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", dutyDataA.Capella.Body.ExecutionPayload.FeeRecipient))
 			assertRandao(t, randaoByPubKey[pubkeysByIdx[vIdxA]].Signature().ToETH2(), dutyDataA)
+			require.Equal(t, graffitiA, string(bytes.TrimRight(dutyDataA.Capella.Body.Graffiti[:], "\x00")))
 
 			dutyDataB := resDataSet[pubkeysByIdx[vIdxB]].(core.VersionedBeaconBlock)
 			slotB, err := dutyDataB.Slot()
@@ -258,6 +268,7 @@ func TestFetchBlocks(t *testing.T) {
 			require.EqualValues(t, slot, slotB)
 			require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", dutyDataB.Capella.Body.ExecutionPayload.FeeRecipient))
 			assertRandao(t, randaoByPubKey[pubkeysByIdx[vIdxB]].Signature().ToETH2(), dutyDataB)
+			require.True(t, bytes.HasPrefix(dutyDataB.Capella.Body.Graffiti[:], []byte("charon/")))
 
 			return nil
 		})
@@ -270,7 +281,7 @@ func TestFetchBlocks(t *testing.T) {
 		duty := core.NewBuilderProposerDuty(slot)
 		fetch, err := fetcher.New(bmock, func(core.PubKey) string {
 			return feeRecipientAddr
-		})
+		}, nil)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -405,7 +416,7 @@ func TestFetchSyncContribution(t *testing.T) {
 		}
 
 		// Construct fetcher component.
-		fetch, err := fetcher.New(bmock, nil)
+		fetch, err := fetcher.New(bmock, nil, nil)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -466,7 +477,7 @@ func TestFetchSyncContribution(t *testing.T) {
 		require.NoError(t, err)
 
 		// Construct fetcher component.
-		fetch, err := fetcher.New(bmock, nil)
+		fetch, err := fetcher.New(bmock, nil, nil)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -496,7 +507,7 @@ func TestFetchSyncContribution(t *testing.T) {
 		require.NoError(t, err)
 
 		// Construct fetcher component.
-		fetch, err := fetcher.New(bmock, nil)
+		fetch, err := fetcher.New(bmock, nil, nil)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -0,0 +1,23 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+)
+
+// WithObserver wraps component input functions to run the node as an observer that never signs.
+// Partial signatures submitted by the validator client are dropped, so none are stored or broadcast
+// to peers. Partial signatures from peers are still processed and the node still participates in
+// consensus, as a non-voting peer since observers have zero voting weight in the cluster lock.
+// It should be the last option, so that dropped partial signatures are not tracked.
+func WithObserver() WireOption {
+	return func(w *wireFuncs) {
+		w.ParSigDBStoreInternal = func(context.Context, Duty, ParSignedDataSet) error {
+			return nil
+		}
+		w.ParSigExBroadcast = func(context.Context, Duty, ParSignedDataSet) error {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithObserver(t *testing.T) {
+	var stored, broadcast, external int
+	w := wireFuncs{
+		ParSigDBStoreInternal: func(context.Context, Duty, ParSignedDataSet) error {
+			stored++
+			return nil
+		},
+		ParSigExBroadcast: func(context.Context, Duty, ParSignedDataSet) error {
+			broadcast++
+			return nil
+		},
+		ParSigDBStoreExternal: func(context.Context, Duty, ParSignedDataSet) error {
+			external++
+			return nil
+		},
+	}
+
+	tracker := new(observerTracker)
+	WithTracking(tracker)(&w)
+	WithObserver()(&w)
+
+	ctx := context.Background()
+	duty := NewAttesterDuty(1)
+
+	require.NoError(t, w.ParSigDBStoreInternal(ctx, duty, ParSignedDataSet{}))
+	require.NoError(t, w.ParSigExBroadcast(ctx, duty, ParSignedDataSet{}))
+	require.NoError(t, w.ParSigDBStoreExternal(ctx, duty, ParSignedDataSet{}))
+
+	// No partial signatures are produced.
+	require.Zero(t, stored)
+	require.Zero(t, broadcast)
+	require.Zero(t, tracker.internal)
+	require.Zero(t, tracker.broadcast)
+
+	// Peer partial signatures are still processed and tracked.
+	require.Equal(t, 1, external)
+	require.Equal(t, 1, tracker.external)
+}
+
+// observerTracker is a Tracker counting partial signature events, other events panic.
+type observerTracker struct {
+	Tracker
+
+	internal, broadcast, external int
+}
+
+func (t *observerTracker) ParSigDBStoredInternal(Duty, ParSignedDataSet, error) {
+	t.internal++
+}
+
+func (t *observerTracker) ParSigExBroadcasted(Duty, ParSignedDataSet, error) {
+	t.broadcast++
+}
+
+func (t *observerTracker) ParSigDBStoredExternal(Duty, ParSignedDataSet, error) {
+	t.external++
+}
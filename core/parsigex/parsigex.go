@@ -137,8 +137,18 @@ func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedD
 
 // NewEth2Verifier returns a partial signature verification function for core workflow eth2 signatures.
 func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
-	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
+	return NewEth2VerifierFunc(eth2Cl, func(pubkey core.PubKey) (map[int]tblsv2.PublicKey, bool) {
 		pubshares, ok := pubSharesByKey[pubkey]
+		return pubshares, ok
+	})
+}
+
+// NewEth2VerifierFunc returns a partial signature verification function for core workflow eth2 signatures
+// using the provided function to lookup the public shares of a validator by public key.
+// This supports validators being added at runtime.
+func NewEth2VerifierFunc(eth2Cl eth2wrap.Client, pubSharesFunc func(core.PubKey) (map[int]tblsv2.PublicKey, bool)) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
+	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
+		pubshares, ok := pubSharesFunc(pubkey)
 		if !ok {
 			return errors.New("unknown pubkey, not part of cluster lock")
 		}
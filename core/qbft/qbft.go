@@ -46,20 +46,63 @@ type Definition[I any, V comparable] struct {
 
 	// Nodes is the total number of nodes/processes participating in consensus.
 	Nodes int
+	// Weights optionally defines the voting weight of each process by index, defaulting to 1.
+	// Quorum and faulty calculations are based on the sum of weights rather than the number of processes.
+	Weights map[int64]int
 	// FIFOLimit limits the amount of message buffered for each peer.
 	FIFOLimit int
 }
 
-// Quorum returns the quorum count for the system.
+// Quorum returns the quorum weight for the system, which is the quorum count if all processes have equal weight.
 // See IBFT 2.0 paper for correct formula: https://arxiv.org/pdf/1909.10194.pdf
 func (d Definition[I, V]) Quorum() int {
-	return int(math.Ceil(float64(d.Nodes*2) / 3))
+	return int(math.Ceil(float64(d.totalWeight()*2) / 3))
 }
 
-// Faulty returns the maximum number of faulty/byzantium nodes supported in the system.
+// Faulty returns the maximum weight of faulty/byzantium nodes supported in the system,
+// which is the faulty count if all processes have equal weight.
 // See IBFT 2.0 paper for correct formula: https://arxiv.org/pdf/1909.10194.pdf
 func (d Definition[I, V]) Faulty() int {
-	return int(math.Floor(float64(d.Nodes-1) / 3))
+	return int(math.Floor(float64(d.totalWeight()-1) / 3))
+}
+
+// Weight returns the voting weight of the process.
+func (d Definition[I, V]) Weight(process int64) int {
+	if w, ok := d.Weights[process]; ok {
+		return w
+	}
+
+	return 1
+}
+
+// totalWeight returns the sum of the voting weights of all processes.
+func (d Definition[I, V]) totalWeight() int {
+	total := d.Nodes
+	for _, w := range d.Weights {
+		total += w - 1 // Processes without explicit weights default to 1.
+	}
+
+	return total
+}
+
+// sourcesWeight returns the sum of the voting weights of the unique sources of the messages.
+func sourcesWeight[I any, V comparable](d Definition[I, V], msgs []Msg[I, V]) int {
+	var (
+		total int
+		uniq  = uniqSource[I, V]()
+	)
+	for _, msg := range msgs {
+		if uniq(msg) {
+			total += d.Weight(msg.Source())
+		}
+	}
+
+	return total
+}
+
+// isQuorum returns true if the unique sources of the messages have quorum weight.
+func isQuorum[I any, V comparable](d Definition[I, V], msgs []Msg[I, V]) bool {
+	return sourcesWeight(d, msgs) >= d.Quorum()
 }
 
 //go:generate stringer -type=MsgType
@@ -379,7 +422,7 @@ func classify[I any, V comparable](d Definition[I, V], instance I, round, proces
 			return UponNothing, nil
 		}
 		prepares := filterByRoundAndValue(flatten(buffer), MsgPrepare, msg.Round(), msg.Value())
-		if len(prepares) >= d.Quorum() {
+		if isQuorum(d, prepares) {
 			return UponQuorumPrepares, prepares
 		}
 
@@ -389,7 +432,7 @@ func classify[I any, V comparable](d Definition[I, V], instance I, round, proces
 			return UponNothing, nil
 		}
 		commits := filterByRoundAndValue(flatten(buffer), MsgCommit, msg.Round(), msg.Value())
-		if len(commits) >= d.Quorum() {
+		if isQuorum(d, commits) {
 			return UponQuorumCommits, commits
 		}
 
@@ -412,7 +455,7 @@ func classify[I any, V comparable](d Definition[I, V], instance I, round, proces
 
 		/* else msg.Round == round */
 
-		if qrc := filterRoundChange(all, msg.Round()); len(qrc) < d.Quorum() {
+		if qrc := filterRoundChange(all, msg.Round()); !isQuorum(d, qrc) {
 			return UponNothing, nil
 		}
 
@@ -439,7 +482,7 @@ func classify[I any, V comparable](d Definition[I, V], instance I, round, proces
 func nextMinRound[I any, V comparable](d Definition[I, V], frc []Msg[I, V], round int64) int64 {
 	// Get all RoundChange messages with round (rj) higher than current round (ri)
 
-	if len(frc) < d.Faulty()+1 {
+	if sourcesWeight(d, frc) < d.Faulty()+1 {
 		panic("bug: Frc too short")
 	}
 
@@ -497,7 +540,7 @@ func isJustifiedRoundChange[I any, V comparable](d Definition[I, V], msg Msg[I,
 
 	// No need to check for all possible combinations, since justified should only contain a one.
 
-	if len(prepares) < d.Quorum() {
+	if !isQuorum(d, prepares) {
 		return false
 	}
 
@@ -530,7 +573,7 @@ func isJustifiedDecided[I any, V comparable](d Definition[I, V], msg Msg[I, V])
 	v := msg.Value()
 	commits := filterMsgs(msg.Justification(), MsgCommit, msg.Round(), &v, nil, nil)
 
-	return len(commits) >= d.Quorum()
+	return isQuorum(d, commits)
 }
 
 // isJustifiedPrePrepare returns true if the PRE-PREPARE message is justified.
@@ -563,7 +606,7 @@ func isJustifiedPrePrepare[I any, V comparable](d Definition[I, V], instance I,
 // the messages contains a justified quorum ROUND_CHANGEs (Qrc).
 func containsJustifiedQrc[I any, V comparable](d Definition[I, V], justification []Msg[I, V], round int64) (V, bool) {
 	qrc := filterRoundChange(justification, round)
-	if len(qrc) < d.Quorum() {
+	if !isQuorum(d, qrc) {
 		return zeroVal[V](), false
 	}
 
@@ -610,10 +653,11 @@ func containsJustifiedQrc[I any, V comparable](d Definition[I, V], justification
 // PREPARES in list of messages. It expects only one possible combination.
 func getSingleJustifiedPrPv[I any, V comparable](d Definition[I, V], msgs []Msg[I, V]) (int64, V, bool) {
 	var (
-		pr    int64
-		pv    V
-		count int
-		uniq  = uniqSource[I, V]()
+		pr     int64
+		pv     V
+		count  int
+		weight int
+		uniq   = uniqSource[I, V]()
 	)
 	for _, msg := range msgs {
 		if msg.Type() != MsgPrepare {
@@ -629,9 +673,10 @@ func getSingleJustifiedPrPv[I any, V comparable](d Definition[I, V], msgs []Msg[
 			return 0, zeroVal[V](), false
 		}
 		count++
+		weight += d.Weight(msg.Source())
 	}
 
-	return pr, pv, count >= d.Quorum()
+	return pr, pv, weight >= d.Quorum()
 }
 
 // getJustifiedQrc implements algorithm 4:1 and returns a justified quorum ROUND_CHANGEs (Qrc).
@@ -664,7 +709,7 @@ func getJustifiedQrc[I any, V comparable](d Definition[I, V], all []Msg[I, V], r
 			}
 			qrc = append(qrc, rc)
 		}
-		if len(qrc) >= d.Quorum() && hasHighestPrepared {
+		if isQuorum(d, qrc) && hasHighestPrepared {
 			return append(qrc, prepares...), true
 		}
 	}
@@ -676,7 +721,10 @@ func getJustifiedQrc[I any, V comparable](d Definition[I, V], all []Msg[I, V], r
 // the rounds higher than the provided round. It returns the highest round
 // per process in order to jump furthest.
 func getFPlus1RoundChanges[I any, V comparable](d Definition[I, V], all []Msg[I, V], round int64) ([]Msg[I, V], bool) {
-	highestBySource := make(map[int64]Msg[I, V])
+	var (
+		highestBySource = make(map[int64]Msg[I, V])
+		weight          int
+	)
 	for _, msg := range all {
 		if msg.Type() != MsgRoundChange {
 			continue
@@ -688,14 +736,17 @@ func getFPlus1RoundChanges[I any, V comparable](d Definition[I, V], all []Msg[I,
 			continue
 		}
 
+		if _, ok := highestBySource[msg.Source()]; !ok {
+			weight += d.Weight(msg.Source())
+		}
 		highestBySource[msg.Source()] = msg
 
-		if len(highestBySource) == d.Faulty()+1 {
+		if weight >= d.Faulty()+1 {
 			break
 		}
 	}
 
-	if len(highestBySource) < d.Faulty()+1 {
+	if weight < d.Faulty()+1 {
 		return nil, false
 	}
 
@@ -734,13 +785,13 @@ func getPrepareQuorums[I any, V comparable](d Definition[I, V], all []Msg[I, V])
 	// Return all quorums
 	var quorums [][]Msg[I, V]
 	for _, msgs := range sets {
-		if len(msgs) < d.Quorum() {
-			continue
-		}
 		var quorum []Msg[I, V]
 		for _, msg := range msgs {
 			quorum = append(quorum, msg)
 		}
+		if !isQuorum(d, quorum) {
+			continue
+		}
 		quorums = append(quorums, quorum)
 	}
 
@@ -756,7 +807,7 @@ func quorumNullPrepared[I any, V comparable](d Definition[I, V], all []Msg[I, V]
 	)
 	justification := filterMsgs(all, MsgRoundChange, round, nil, &nullPr, &nullPv)
 
-	return justification, len(justification) >= d.Quorum()
+	return justification, isQuorum(d, justification)
 }
 
 // filterByRoundAndValue returns the messages matching the type and value.
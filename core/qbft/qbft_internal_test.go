@@ -585,3 +585,55 @@ var noopDef = Definition[int64, int64]{
 	LogRoundChange: func(context.Context, int64, int64, int64, int64, UponRule, []Msg[int64, int64]) {},
 	LogUnjust:      func(context.Context, int64, int64, Msg[int64, int64]) {},
 }
+
+func TestWeightedFormulas(t *testing.T) {
+	d := Definition[any, int64]{Nodes: 4, Weights: map[int64]int{0: 3}}
+	require.Equal(t, 6, d.totalWeight())
+	require.Equal(t, 4, d.Quorum())
+	require.Equal(t, 1, d.Faulty())
+	require.Equal(t, 3, d.Weight(0))
+	require.Equal(t, 1, d.Weight(1))
+}
+
+func TestWeightedQuorum(t *testing.T) {
+	const n = 4
+
+	def := noopDef
+	def.Nodes = n
+	def.Weights = map[int64]int{0: 3} // Total weight 6, quorum 4.
+
+	prepare := func(source int64) Msg[int64, int64] {
+		return newMsg(MsgPrepare, 0, source, 1, 1, 0, 0, nil)
+	}
+
+	tests := []struct {
+		Name    string
+		Sources []int64
+		Quorum  bool
+	}{
+		{Name: "heavy plus one", Sources: []int64{0, 1}, Quorum: true},
+		{Name: "heavy only", Sources: []int64{0}, Quorum: false},
+		{Name: "all light", Sources: []int64{1, 2, 3}, Quorum: false},
+		{Name: "duplicate sources", Sources: []int64{0, 0}, Quorum: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			buffer := make(map[int64][]Msg[int64, int64])
+			var last Msg[int64, int64]
+			for _, source := range test.Sources {
+				last = prepare(source)
+				buffer[source] = append(buffer[source], last)
+			}
+
+			require.Equal(t, test.Quorum, isQuorum(def, flatten(buffer)))
+
+			rule, _ := classify(def, 0, 1, 0, buffer, last)
+			if test.Quorum {
+				require.Equal(t, UponQuorumPrepares, rule)
+			} else {
+				require.Equal(t, UponNothing, rule)
+			}
+		})
+	}
+}
@@ -76,7 +76,8 @@ func instrumentDuty(duty core.Duty, defSet core.DutyDefinitionSet) {
 }
 
 // newMetricSubmitter returns a function that sets validator balance and status metric.
-func newMetricSubmitter() func(pubkey core.PubKey, totalBal eth2p0.Gwei, status string) {
+// The status series of the previous status is deleted when the status changes, so stale statuses don't linger.
+func newMetricSubmitter() metricSubmitter {
 	prevStatus := make(map[core.PubKey]string)
 
 	return func(pubkey core.PubKey, totalBal eth2p0.Gwei, status string) {
@@ -84,7 +85,7 @@ func newMetricSubmitter() func(pubkey core.PubKey, totalBal eth2p0.Gwei, status
 		statusGauge.WithLabelValues(string(pubkey), pubkey.String(), status).Set(1)
 
 		if prev, ok := prevStatus[pubkey]; ok && prev != status { // Validator status changed
-			statusGauge.WithLabelValues(string(pubkey), pubkey.String(), prev).Set(0)
+			statusGauge.DeleteLabelValues(string(pubkey), pubkey.String(), prev)
 		}
 		prevStatus[pubkey] = status
 	}
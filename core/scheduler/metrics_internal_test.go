@@ -0,0 +1,24 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestMetricSubmitterStatusChange(t *testing.T) {
+	submit := newMetricSubmitter()
+
+	pubkey := testutil.RandomCorePubKey(t)
+	submit(pubkey, 32e9, "pending_queued")
+	submit(pubkey, 32e9, "active_ongoing")
+
+	// DeleteLabelValues returns true only if the series existed.
+	require.False(t, statusGauge.DeleteLabelValues(string(pubkey), pubkey.String(), "pending_queued"))
+	require.True(t, statusGauge.DeleteLabelValues(string(pubkey), pubkey.String(), "active_ongoing"))
+	require.True(t, balanceGauge.DeleteLabelValues(string(pubkey), pubkey.String()))
+}
@@ -27,12 +27,17 @@ const trimEpochOffset = 3 // Trim cached duties after 3 epochs. Note inclusion d
 type delayFunc func(duty core.Duty, deadline time.Time) <-chan time.Time
 
 // NewForT returns a new scheduler for testing using a fake clock.
+// A nil validatorEnabled function enables all validators.
 func NewForT(t *testing.T, clock clockwork.Clock, delayFunc delayFunc, pubkeys []core.PubKey,
-	eth2Cl eth2wrap.Client, builderAPI bool,
+	eth2Cl eth2wrap.Client, builderAPI bool, validatorEnabled core.ValidatorEnabled,
 ) *Scheduler {
 	t.Helper()
 
-	s, err := New(pubkeys, eth2Cl, func(int64) bool { return builderAPI })
+	if validatorEnabled == nil {
+		validatorEnabled = func(core.PubKey) bool { return true }
+	}
+
+	s, err := New(pubkeys, eth2Cl, func(int64) bool { return builderAPI }, validatorEnabled)
 	require.NoError(t, err)
 
 	s.clock = clock
@@ -41,8 +46,10 @@ func NewForT(t *testing.T, clock clockwork.Clock, delayFunc delayFunc, pubkeys [
 	return s
 }
 
-// New returns a new scheduler.
-func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled core.BuilderEnabled) (*Scheduler, error) {
+// New returns a new scheduler. Duties of validators that are not enabled are resolved but not triggered.
+func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled core.BuilderEnabled,
+	validatorEnabled core.ValidatorEnabled,
+) (*Scheduler, error) {
 	return &Scheduler{
 		eth2Cl:        eth2Cl,
 		pubkeys:       pubkeys,
@@ -53,26 +60,53 @@ func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled core.Buil
 		delayFunc: func(_ core.Duty, deadline time.Time) <-chan time.Time {
 			return time.After(time.Until(deadline))
 		},
-		metricSubmitter: newMetricSubmitter(),
-		resolvedEpoch:   math.MaxInt64,
-		builderEnabled:  builderEnabled,
+		metricSubmitter:  newMetricSubmitter(),
+		resolvedEpoch:    math.MaxInt64,
+		builderEnabled:   builderEnabled,
+		validatorEnabled: validatorEnabled,
 	}, nil
 }
 
 type Scheduler struct {
-	eth2Cl          eth2wrap.Client
-	pubkeys         []core.PubKey
-	quit            chan struct{}
-	clock           clockwork.Clock
-	delayFunc       delayFunc
-	metricSubmitter metricSubmitter
-	resolvedEpoch   int64
-	duties          map[core.Duty]core.DutyDefinitionSet
-	dutiesByEpoch   map[int64][]core.Duty
-	dutiesMutex     sync.Mutex
-	dutySubs        []func(context.Context, core.Duty, core.DutyDefinitionSet) error
-	slotSubs        []func(context.Context, core.Slot) error
-	builderEnabled  core.BuilderEnabled
+	eth2Cl           eth2wrap.Client
+	pubkeys          []core.PubKey
+	quit             chan struct{}
+	clock            clockwork.Clock
+	delayFunc        delayFunc
+	metricSubmitter  metricSubmitter
+	resolvedEpoch    int64
+	duties           map[core.Duty]core.DutyDefinitionSet
+	dutiesByEpoch    map[int64][]core.Duty
+	dutiesMutex      sync.Mutex
+	dutySubs         []func(context.Context, core.Duty, core.DutyDefinitionSet) error
+	slotSubs         []func(context.Context, core.Slot) error
+	builderEnabled   core.BuilderEnabled
+	validatorEnabled core.ValidatorEnabled
+	reresolve        bool // Re-resolve the current epoch's duties, since validators were added.
+}
+
+// AddPubkeys adds validators to the scheduler, ignoring existing validators. The current epoch's duties are
+// re-resolved on the next slot, which adds the duties of the new validators without affecting existing duties.
+func (s *Scheduler) AddPubkeys(pubkeys []core.PubKey) {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	existing := make(map[core.PubKey]bool)
+	for _, pubkey := range s.pubkeys {
+		existing[pubkey] = true
+	}
+
+	// Copy on write, since the pubkeys are read without holding the lock.
+	clone := append([]core.PubKey(nil), s.pubkeys...)
+	for _, pubkey := range pubkeys {
+		if existing[pubkey] {
+			continue
+		}
+		existing[pubkey] = true
+		clone = append(clone, pubkey)
+		s.reresolve = true
+	}
+	s.pubkeys = clone
 }
 
 // SubscribeDuties subscribes a callback function for triggered duties.
@@ -166,7 +200,7 @@ func (s *Scheduler) GetDutyDefinition(ctx context.Context, duty core.Duty) (core
 
 // scheduleSlot resolves upcoming duties and triggers resolved duties for the slot.
 func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
-	if s.getResolvedEpoch() != slot.Epoch() {
+	if s.getResolvedEpoch() != slot.Epoch() || s.takeReresolve() {
 		err := s.resolveDuties(ctx, slot)
 		if err != nil {
 			log.Warn(ctx, "Resolving duties error (retrying next slot)", err, z.I64("slot", slot.Slot))
@@ -185,6 +219,12 @@ func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
 			continue
 		}
 
+		defSet = s.filterEnabled(ctx, duty, defSet)
+		if len(defSet) == 0 {
+			// Nothing for this duty since all its validators are disabled.
+			continue
+		}
+
 		// Trigger duty async
 		go func() {
 			if !delaySlotOffset(ctx, slot, duty, s.delayFunc) {
@@ -218,6 +258,20 @@ func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
 	}
 }
 
+// filterEnabled returns a new duty definition set excluding disabled validators.
+func (s *Scheduler) filterEnabled(ctx context.Context, duty core.Duty, defSet core.DutyDefinitionSet) core.DutyDefinitionSet {
+	resp := make(core.DutyDefinitionSet)
+	for pubkey, def := range defSet {
+		if !s.validatorEnabled(pubkey) {
+			log.Debug(ctx, "Skipping duty of disabled validator", z.Any("duty", duty), z.Any("pubkey", pubkey))
+			continue
+		}
+		resp[pubkey] = def
+	}
+
+	return resp
+}
+
 // delaySlotOffset blocks until the slot offset for the duty has been reached and return true.
 // It returns false if the context is cancelled.
 func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty, delayFunc delayFunc) bool {
@@ -240,7 +294,7 @@ func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty, delayF
 
 // resolveDuties resolves the duties for the slot's epoch, caching the results.
 func (s *Scheduler) resolveDuties(ctx context.Context, slot core.Slot) error {
-	vals, err := resolveActiveValidators(ctx, s.eth2Cl, s.pubkeys, s.metricSubmitter)
+	vals, err := resolveActiveValidators(ctx, s.eth2Cl, s.getPubkeys(), s.metricSubmitter)
 	if err != nil {
 		return err
 	}
@@ -424,8 +478,17 @@ func (s *Scheduler) getDutyDefinitionSet(duty core.Duty) (core.DutyDefinitionSet
 	defer s.dutiesMutex.Unlock()
 
 	defSet, ok := s.duties[duty]
+	if !ok {
+		return nil, false
+	}
 
-	return defSet, ok
+	// Return a shallow copy, since sets are extended when duties are re-resolved for added validators.
+	resp := make(core.DutyDefinitionSet, len(defSet))
+	for pubkey, def := range defSet {
+		resp[pubkey] = def
+	}
+
+	return resp, true
 }
 
 // setDutyDefinition returns true if the duty definition for the pubkey was set, false if it was already set.
@@ -448,6 +511,24 @@ func (s *Scheduler) setDutyDefinition(duty core.Duty, epoch int64, pubkey core.P
 	return true
 }
 
+func (s *Scheduler) getPubkeys() []core.PubKey {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	return s.pubkeys
+}
+
+// takeReresolve returns true and resets the flag if the current epoch's duties should be re-resolved.
+func (s *Scheduler) takeReresolve() bool {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	resp := s.reresolve
+	s.reresolve = false
+
+	return resp
+}
+
 func (s *Scheduler) getResolvedEpoch() int64 {
 	s.dutiesMutex.Lock()
 	defer s.dutiesMutex.Unlock()
@@ -641,7 +722,7 @@ func waitBeaconSync(ctx context.Context, eth2Cl eth2wrap.Client, clock clockwork
 
 		if state.IsSyncing {
 			log.Info(ctx, "Waiting for beacon node to sync",
-				z.U64("distance", uint64(state.SyncDistance)))
+				z.U64("distance", uint64(state.SyncDistance)), z.U64("head_slot", uint64(state.HeadSlot)))
 			clock.Sleep(time.Minute) // TODO(corver): Improve backoff
 
 			continue
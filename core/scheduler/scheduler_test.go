@@ -8,6 +8,7 @@ import (
 	"os"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -55,7 +56,8 @@ func TestIntegration(t *testing.T) {
 	}
 
 	builderDisabled := func(int64) bool { return false }
-	s, err := scheduler.New(pubkeys, eth2Cl, builderDisabled)
+	allEnabled := func(core.PubKey) bool { return true }
+	s, err := scheduler.New(pubkeys, eth2Cl, builderDisabled, allEnabled)
 	require.NoError(t, err)
 
 	count := 10
@@ -142,7 +144,7 @@ func TestSchedulerWait(t *testing.T) {
 				}, err
 			}
 
-			sched := scheduler.NewForT(t, clock, new(delayer).delay, nil, eth2Cl, false)
+			sched := scheduler.NewForT(t, clock, new(delayer).delay, nil, eth2Cl, false, nil)
 			sched.Stop() // Just run wait functions, then quit.
 			require.NoError(t, sched.Run())
 			require.EqualValues(t, test.WaitSecs, clock.Since(t0).Seconds())
@@ -150,6 +152,51 @@ func TestSchedulerWait(t *testing.T) {
 	}
 }
 
+// TestSchedulerSyncedDuties tests that no duties are scheduled while the beacon node is syncing.
+func TestSchedulerSyncedDuties(t *testing.T) {
+	const syncedAfter = time.Minute * 3
+
+	var t0 time.Time
+	clock := newTestClock(t0)
+
+	valSet := beaconmock.ValidatorSetA
+	eth2Cl, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithGenesisTime(t0),
+		beaconmock.WithDeterministicAttesterDuties(0),
+	)
+	require.NoError(t, err)
+
+	var syncChecks int
+	eth2Cl.NodeSyncingFunc = func(context.Context) (*eth2v1.SyncState, error) {
+		syncChecks++
+
+		return &eth2v1.SyncState{
+			IsSyncing: clock.Now().Before(t0.Add(syncedAfter)),
+		}, nil
+	}
+
+	pubkeys, err := valSet.CorePubKeys()
+	require.NoError(t, err)
+
+	sched := scheduler.NewForT(t, clock, new(delayer).delay, pubkeys, eth2Cl, false, nil)
+
+	var firstDuty time.Time
+	sched.SubscribeDuties(func(ctx context.Context, duty core.Duty, set core.DutyDefinitionSet) error {
+		if firstDuty.IsZero() {
+			firstDuty = clock.Now()
+			sched.Stop()
+		}
+
+		return nil
+	})
+
+	require.NoError(t, sched.Run())
+	require.Greater(t, syncChecks, 1)
+	require.False(t, firstDuty.IsZero())
+	require.False(t, firstDuty.Before(t0.Add(syncedAfter)), "duty scheduled before beacon node synced")
+}
+
 //go:generate go test . -run=TestSchedulerDuties -update -clean
 
 // TestSchedulerDuties tests the scheduled duties given a deterministic mock beacon node.
@@ -213,7 +260,7 @@ func TestSchedulerDuties(t *testing.T) {
 			// Construct scheduler
 			clock := newTestClock(t0)
 			delayer := new(delayer)
-			sched := scheduler.NewForT(t, clock, delayer.delay, pubkeys, eth2Cl, false)
+			sched := scheduler.NewForT(t, clock, delayer.delay, pubkeys, eth2Cl, false, nil)
 
 			// Only test scheduler output for first N slots, so Stop scheduler (and slotTicker) after that.
 			const stopAfter = 3
@@ -307,7 +354,7 @@ func TestScheduler_GetDuty(t *testing.T) {
 
 	// Construct scheduler.
 	clock := newTestClock(t0)
-	sched := scheduler.NewForT(t, clock, new(delayer).delay, pubkeys, eth2Cl, false)
+	sched := scheduler.NewForT(t, clock, new(delayer).delay, pubkeys, eth2Cl, false, nil)
 
 	_, err = sched.GetDutyDefinition(ctx, core.NewAttesterDuty(slot))
 	require.ErrorContains(t, err, "epoch not resolved yet")
@@ -371,6 +418,168 @@ func TestScheduler_GetDuty(t *testing.T) {
 	require.NoError(t, sched.Run())
 }
 
+func TestSchedulerDisabledValidator(t *testing.T) {
+	var t0 time.Time
+
+	valSet := beaconmock.ValidatorSetA
+	eth2Cl, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithGenesisTime(t0),
+		beaconmock.WithDeterministicAttesterDuties(0),
+		beaconmock.WithSlotsPerEpoch(1),
+	)
+	require.NoError(t, err)
+
+	pubkeys, err := valSet.CorePubKeys()
+	require.NoError(t, err)
+
+	disabled := pubkeys[0]
+	var reenabled atomic.Bool
+	validatorEnabled := func(pubkey core.PubKey) bool {
+		return pubkey != disabled || reenabled.Load()
+	}
+
+	clock := newTestClock(t0)
+	sched := scheduler.NewForT(t, clock, new(delayer).delay, pubkeys, eth2Cl, false, validatorEnabled)
+
+	slotDuration, err := eth2Cl.SlotDuration(context.Background())
+	require.NoError(t, err)
+
+	const (
+		enableSlot = 4
+		stopSlot   = 7
+	)
+
+	// Re-enable the validator before the enable slot ticks.
+	clock.CallbackAfter(t0.Add(enableSlot*slotDuration), func() {
+		reenabled.Store(true)
+	})
+
+	var (
+		mu              sync.Mutex
+		attPubkeys      = make(map[int64][]core.PubKey)
+		stopped         bool
+		maxDisabled     int64
+		reenabledDuties bool
+	)
+	sched.SubscribeDuties(func(ctx context.Context, duty core.Duty, set core.DutyDefinitionSet) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if duty.Type != core.DutyAttester {
+			return nil
+		}
+
+		// Note duties are triggered async, so later slots may be recorded before the stop slot.
+		for pubkey := range set {
+			attPubkeys[duty.Slot] = append(attPubkeys[duty.Slot], pubkey)
+		}
+
+		if duty.Slot >= stopSlot && !stopped {
+			stopped = true
+			sched.Stop()
+		}
+
+		return nil
+	})
+
+	require.NoError(t, sched.Run())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for slot, pks := range attPubkeys {
+		// Note the validator may be re-enabled while scheduling the slot before the enable slot.
+		if slot < enableSlot-1 {
+			require.Len(t, pks, len(pubkeys)-1)
+			require.NotContains(t, pks, disabled, "disabled validator produced duty")
+
+			if slot > maxDisabled {
+				maxDisabled = slot
+			}
+		} else if slot >= enableSlot {
+			require.Len(t, pks, len(pubkeys))
+			require.Contains(t, pks, disabled, "re-enabled validator produced no duty")
+			reenabledDuties = true
+		}
+	}
+
+	require.Positive(t, maxDisabled, "no duties scheduled while disabled")
+	require.True(t, reenabledDuties, "no duties scheduled after re-enabling")
+}
+
+func TestSchedulerAddPubkeys(t *testing.T) {
+	var t0 time.Time
+
+	valSet := beaconmock.ValidatorSetA
+	eth2Cl, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithGenesisTime(t0),
+		beaconmock.WithDeterministicAttesterDuties(1), // Validators attest in consecutive slots.
+		beaconmock.WithSlotsPerEpoch(4),
+	)
+	require.NoError(t, err)
+
+	var pubkeys []core.PubKey
+	for _, idx := range []eth2p0.ValidatorIndex{1, 2, 3} {
+		pubkey, err := core.PubKeyFromBytes(valSet[idx].Validator.PublicKey[:])
+		require.NoError(t, err)
+		pubkeys = append(pubkeys, pubkey)
+	}
+	added := pubkeys[2]
+
+	clock := newTestClock(t0)
+	sched := scheduler.NewForT(t, clock, new(delayer).delay, pubkeys[:2], eth2Cl, false, nil)
+
+	slotDuration, err := eth2Cl.SlotDuration(context.Background())
+	require.NoError(t, err)
+
+	// Add the validator during the first epoch, so its duty in the same epoch is resolved.
+	clock.CallbackAfter(t0.Add(slotDuration), func() {
+		sched.AddPubkeys(pubkeys[1:]) // Existing validators are ignored.
+	})
+
+	const maxSlot = 8
+
+	var (
+		mu         sync.Mutex
+		attPubkeys = make(map[int64][]core.PubKey)
+		stopped    bool
+	)
+	sched.SubscribeDuties(func(ctx context.Context, duty core.Duty, set core.DutyDefinitionSet) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if duty.Type != core.DutyAttester {
+			return nil
+		}
+
+		// Note duties are triggered async, so later slots may be recorded before the stop slot.
+		for pubkey := range set {
+			attPubkeys[duty.Slot] = append(attPubkeys[duty.Slot], pubkey)
+		}
+
+		// Stop once the first epoch's duties are recorded, or after the next epoch if the added validator's duty is missing.
+		done := len(attPubkeys[0]) > 0 && len(attPubkeys[1]) > 0 && len(attPubkeys[2]) > 0
+		if (done || duty.Slot >= maxSlot) && !stopped {
+			stopped = true
+			sched.Stop()
+		}
+
+		return nil
+	})
+
+	require.NoError(t, sched.Run())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Existing duties are neither dropped nor duplicated, and the added validator's duty is triggered.
+	require.Equal(t, []core.PubKey{pubkeys[0]}, attPubkeys[0])
+	require.Equal(t, []core.PubKey{pubkeys[1]}, attPubkeys[1])
+	require.Equal(t, []core.PubKey{added}, attPubkeys[2])
+}
+
 //go:generate go test . -run=TestNoActive -count=100
 
 func TestNoActive(t *testing.T) {
@@ -390,7 +599,7 @@ func TestNoActive(t *testing.T) {
 
 	// Construct scheduler.
 	clock := newTestClock(t0)
-	sched := scheduler.NewForT(t, clock, new(delayer).delay, nil, eth2Cl, false)
+	sched := scheduler.NewForT(t, clock, new(delayer).delay, nil, eth2Cl, false, nil)
 
 	clock.CallbackAfter(t0.Add(slotDuration*2), func() {
 		_, err := sched.GetDutyDefinition(ctx, core.NewAttesterDuty(1))
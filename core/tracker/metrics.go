@@ -30,6 +30,20 @@ var (
 		Help:      "Total number of failed duties by type",
 	}, []string{"duty"})
 
+	successCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "success_duties_total",
+		Help:      "Total number of successful duties by type",
+	}, []string{"duty"})
+
+	successRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "success_ratio",
+		Help:      "Ratio of successful duties to all analysed duties by type since startup, i.e. success / (success + failed)",
+	}, []string{"duty"})
+
 	unexpectedEventsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "tracker",
@@ -44,6 +58,14 @@ var (
 		Help:      "Total number of duties that contained inconsistent partial signed data by duty type",
 	}, []string{"duty"})
 
+	parSigArrival = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "parsig_arrival_seconds",
+		Help:      "Time in seconds between duty start and storing partial signatures by peer",
+		Buckets:   []float64{1, 2, 4, 6, 8, 12, 24},
+	}, []string{"peer"})
+
 	inclusionDelay = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "core",
 		Subsystem: "tracker",
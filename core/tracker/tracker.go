@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	eth2http "github.com/attestantio/go-eth2-client/http"
 
@@ -237,6 +239,16 @@ type Tracker struct {
 
 	// participationReporter instruments duty peer participation.
 	participationReporter func(ctx context.Context, duty core.Duty, failed bool, participatedShares map[int]bool, unexpectedPeers map[int]bool)
+
+	// inconsistencyFunc records partial signed data inconsistencies if set.
+	inconsistencyFunc func(Inconsistency)
+
+	// dutyStartFunc returns the start time of a duty, it enables partial signature arrival instrumentation if set.
+	dutyStartFunc func(core.Duty) (time.Time, bool)
+	// nowFunc returns the current time.
+	nowFunc func() time.Time
+	// peers are the cluster peers.
+	peers []p2p.Peer
 }
 
 // New returns a new Tracker. The deleter deadliner must return well after analyser deadliner since duties of the same slot are often analysed together.
@@ -250,12 +262,53 @@ func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, from
 		fromSlot:              fromSlot,
 		parSigReporter:        reportParSigs,
 		failedDutyReporter:    newFailedDutyReporter(),
+		nowFunc:               time.Now,
 		participationReporter: newParticipationReporter(peers),
+		peers:                 peers,
 	}
 
 	return t
 }
 
+// SetDutyStartFunc enables instrumenting the arrival time of partial signatures by peer
+// relative to the duty start time returned by the provided function.
+// Note this function is not thread safe, it should be called *before* Run.
+func (t *Tracker) SetDutyStartFunc(fn func(core.Duty) (time.Time, bool)) {
+	t.dutyStartFunc = fn
+}
+
+// SetInconsistencyFunc enables recording details of partial signed data inconsistencies via the provided function.
+// Note this function is not thread safe, it should be called *before* Run.
+func (t *Tracker) SetInconsistencyFunc(fn func(Inconsistency)) {
+	t.inconsistencyFunc = fn
+}
+
+// instrumentParSigArrival observes the time since duty start of the successfully stored partial signatures by peer.
+func (t *Tracker) instrumentParSigArrival(duty core.Duty, set core.ParSignedDataSet, stepErr error) {
+	if t.dutyStartFunc == nil || stepErr != nil || len(set) == 0 {
+		return
+	}
+
+	start, ok := t.dutyStartFunc(duty)
+	if !ok {
+		return
+	}
+
+	// All partial signatures in a set are from the same peer.
+	var shareIdx int
+	for _, parSig := range set {
+		shareIdx = parSig.ShareIdx
+		break
+	}
+
+	for _, peer := range t.peers {
+		if peer.ShareIdx() == shareIdx {
+			parSigArrival.WithLabelValues(peer.Name).Observe(t.nowFunc().Sub(start).Seconds())
+			return
+		}
+	}
+}
+
 // Run blocks and registers events from each step in tracker's input channel.
 // It also analyses and reports the duties whose deadline gets crossed.
 func (t *Tracker) Run(ctx context.Context) error {
@@ -280,6 +333,11 @@ func (t *Tracker) Run(ctx context.Context) error {
 
 			parsigs := extractParSigs(ctx, t.events[duty])
 			t.parSigReporter(ctx, duty, parsigs)
+			if t.inconsistencyFunc != nil {
+				for _, inconsistency := range newInconsistencies(duty, parsigs, t.peers, t.nowFunc()) {
+					t.inconsistencyFunc(inconsistency)
+				}
+			}
 
 			// Analyse failed duties
 			failed, failedStep, failedMsg, failedErr := analyseDutyFailed(duty, t.events, parsigs.MsgRootsConsistent())
@@ -566,13 +624,26 @@ func extractParSigs(ctx context.Context, events []event) parsigsByMsg {
 
 // newFailedDutyReporter returns failed duty reporter which instruments failed duties.
 func newFailedDutyReporter() func(ctx context.Context, duty core.Duty, failed bool, step step, reason string, err error) {
-	var loggedNoSelections bool
+	var (
+		loggedNoSelections bool
+		successes          = make(map[core.DutyType]int)
+		failures           = make(map[core.DutyType]int)
+	)
+
+	// instrumentRatio updates the success ratio gauge of the duty type.
+	instrumentRatio := func(typ core.DutyType) {
+		successRatio.WithLabelValues(typ.String()).Set(float64(successes[typ]) / float64(successes[typ]+failures[typ]))
+	}
 
 	return func(ctx context.Context, duty core.Duty, failed bool, step step, reason string, err error) {
 		counter := failedCounter.WithLabelValues(duty.Type.String())
 		counter.Add(0) // Zero the metric so first failure shows in grafana.
 
 		if !failed {
+			successCounter.WithLabelValues(duty.Type.String()).Inc()
+			successes[duty.Type]++
+			instrumentRatio(duty.Type)
+
 			return
 		}
 
@@ -600,6 +671,8 @@ func newFailedDutyReporter() func(ctx context.Context, duty core.Duty, failed bo
 		)
 
 		counter.Inc()
+		failures[duty.Type]++
+		instrumentRatio(duty.Type)
 	}
 }
 
@@ -753,6 +826,8 @@ func (t *Tracker) DutyDBStored(duty core.Duty, set core.UnsignedDataSet, stepErr
 
 // ParSigDBStoredInternal implements core.Tracker interface.
 func (t *Tracker) ParSigDBStoredInternal(duty core.Duty, set core.ParSignedDataSet, stepErr error) {
+	t.instrumentParSigArrival(duty, set, stepErr)
+
 	for pubkey, parSig := range set {
 		parSig := parSig
 		select {
@@ -789,6 +864,8 @@ func (t *Tracker) ParSigExBroadcasted(duty core.Duty, set core.ParSignedDataSet,
 
 // ParSigDBStoredExternal implements core.Tracker interface.
 func (t *Tracker) ParSigDBStoredExternal(duty core.Duty, set core.ParSignedDataSet, stepErr error) {
+	t.instrumentParSigArrival(duty, set, stepErr)
+
 	for pubkey, parSig := range set {
 		parSig := parSig
 		select {
@@ -890,6 +967,70 @@ func reportParSigs(ctx context.Context, duty core.Duty, parsigMsgs parsigsByMsg)
 	}
 }
 
+// Inconsistency details the conflicting partial signed data of a validator for a duty.
+type Inconsistency struct {
+	Time   time.Time           `json:"time"`
+	Duty   string              `json:"duty"`
+	Pubkey string              `json:"pubkey"`
+	Groups []InconsistentGroup `json:"groups"`
+}
+
+// InconsistentGroup is a set of peers that signed the same message root.
+type InconsistentGroup struct {
+	MsgRoot string   `json:"msg_root"`
+	Peers   []string `json:"peers"`
+}
+
+// newInconsistencies returns the inconsistencies of each validator with conflicting partial signed data.
+// Duties expected to sometimes produce inconsistent partial signed data are ignored.
+func newInconsistencies(duty core.Duty, parsigMsgs parsigsByMsg, peers []p2p.Peer, now time.Time) []Inconsistency {
+	if parsigMsgs.MsgRootsConsistent() || expectInconsistentParSigs(duty.Type) {
+		return nil
+	}
+
+	peerNames := make(map[int]string)
+	for _, peer := range peers {
+		peerNames[peer.ShareIdx()] = peer.Name
+	}
+
+	var resp []Inconsistency
+	for pubkey, parsigsByRoot := range parsigMsgs {
+		if len(parsigsByRoot) <= 1 {
+			continue
+		}
+
+		var groups []InconsistentGroup
+		for root, parsigs := range parsigsByRoot {
+			group := InconsistentGroup{MsgRoot: fmt.Sprintf("%#x", root)}
+			for _, parsig := range parsigs {
+				name, ok := peerNames[parsig.ShareIdx]
+				if !ok {
+					name = fmt.Sprintf("share_%d", parsig.ShareIdx)
+				}
+				group.Peers = append(group.Peers, name)
+			}
+			sort.Strings(group.Peers)
+			groups = append(groups, group)
+		}
+		sort.Slice(groups, func(i, j int) bool {
+			return groups[i].MsgRoot < groups[j].MsgRoot
+		})
+
+		resp = append(resp, Inconsistency{
+			Time:   now,
+			Duty:   duty.String(),
+			Pubkey: string(pubkey),
+			Groups: groups,
+		})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Pubkey < resp[j].Pubkey
+	})
+
+	return resp
+}
+
 // expectInconsistentParSigs returns true if the duty type is expected to sometimes
 // produce inconsistent partial signed data.
 func expectInconsistentParSigs(duty core.DutyType) bool {
@@ -7,14 +7,18 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2http "github.com/attestantio/go-eth2-client/http"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/promauto"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/testutil"
@@ -26,6 +30,104 @@ func TestStepString(t *testing.T) {
 	}
 }
 
+func TestMetricsNamespace(t *testing.T) {
+	peers := []p2p.Peer{{Index: 0, Name: t.Name()}}
+	tr := New(nil, nil, peers, 0)
+	tr.participationReporter(context.Background(), core.NewAttesterDuty(1), false, map[int]bool{peers[0].ShareIdx(): true}, nil)
+
+	registry, err := promauto.NewRegistry(nil, promauto.WithNamespace("myorg"))
+	require.NoError(t, err)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var found bool
+	for _, fam := range families {
+		if fam.GetName() == "myorg_core_tracker_participation" {
+			found = true
+		}
+		require.NotEqual(t, "core_tracker_participation", fam.GetName())
+	}
+	require.True(t, found)
+}
+
+func TestParSigArrival(t *testing.T) {
+	var peers []p2p.Peer
+	for i := 0; i < 3; i++ {
+		peers = append(peers, p2p.Peer{Index: i, Name: fmt.Sprintf("%s-%d", t.Name(), i)})
+	}
+
+	tr := New(nil, nil, peers, 0)
+	close(tr.quit) // Drop events since Run isn't called.
+
+	start := time.Unix(1000, 0)
+	tr.SetDutyStartFunc(func(core.Duty) (time.Time, bool) {
+		return start, true
+	})
+
+	duty := core.NewAttesterDuty(1)
+	parSigSet := func(shareIdx int) core.ParSignedDataSet {
+		return core.ParSignedDataSet{
+			testutil.RandomCorePubKey(t): core.NewPartialAttestation(testutil.RandomAttestation(), shareIdx),
+			testutil.RandomCorePubKey(t): core.NewPartialAttestation(testutil.RandomAttestation(), shareIdx),
+		}
+	}
+
+	// Peer 0 arrives after 1.5s (internal), peer 1 after 3s and 5s (external), peer 2 fails after 7s.
+	tr.nowFunc = func() time.Time { return start.Add(1500 * time.Millisecond) }
+	tr.ParSigDBStoredInternal(duty, parSigSet(peers[0].ShareIdx()), nil)
+	tr.nowFunc = func() time.Time { return start.Add(3 * time.Second) }
+	tr.ParSigDBStoredExternal(duty, parSigSet(peers[1].ShareIdx()), nil)
+	tr.nowFunc = func() time.Time { return start.Add(5 * time.Second) }
+	tr.ParSigDBStoredExternal(duty, parSigSet(peers[1].ShareIdx()), nil)
+	tr.nowFunc = func() time.Time { return start.Add(7 * time.Second) }
+	tr.ParSigDBStoredExternal(duty, parSigSet(peers[2].ShareIdx()), errors.New("invalid"))
+
+	expected := fmt.Sprintf(`
+# HELP core_tracker_parsig_arrival_seconds Time in seconds between duty start and storing partial signatures by peer
+# TYPE core_tracker_parsig_arrival_seconds histogram
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="1"} 0
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="2"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="4"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="6"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="8"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="12"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="24"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[1]s",le="+Inf"} 1
+core_tracker_parsig_arrival_seconds_sum{peer="%[1]s"} 1.5
+core_tracker_parsig_arrival_seconds_count{peer="%[1]s"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="1"} 0
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="2"} 0
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="4"} 1
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="6"} 2
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="8"} 2
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="12"} 2
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="24"} 2
+core_tracker_parsig_arrival_seconds_bucket{peer="%[2]s",le="+Inf"} 2
+core_tracker_parsig_arrival_seconds_sum{peer="%[2]s"} 8
+core_tracker_parsig_arrival_seconds_count{peer="%[2]s"} 2
+`, peers[0].Name, peers[1].Name)
+
+	require.NoError(t, promtestutil.CollectAndCompare(parSigArrival, strings.NewReader(expected)))
+}
+
+func TestSuccessRatio(t *testing.T) {
+	reporter := newFailedDutyReporter()
+
+	ctx := context.Background()
+	attester := core.NewAttesterDuty(1)
+	proposer := core.NewProposerDuty(1)
+
+	for i := 0; i < 3; i++ {
+		reporter(ctx, attester, false, zero, "", nil)
+	}
+	reporter(ctx, attester, true, fetcher, "failed", errors.New("failed"))
+	reporter(ctx, proposer, true, fetcher, "failed", errors.New("failed"))
+
+	require.InDelta(t, 0.75, promtestutil.ToFloat64(successRatio.WithLabelValues(attester.Type.String())), 1e-9)
+	require.Zero(t, promtestutil.ToFloat64(successRatio.WithLabelValues(proposer.Type.String())))
+}
+
 func TestTrackerFailedDuty(t *testing.T) {
 	const slot = 1
 	testData, pubkeys := setupData(t, []int{slot})
@@ -1176,3 +1278,42 @@ func TestUnexpectedFailures(t *testing.T) {
 		}
 	}
 }
+
+func TestNewInconsistencies(t *testing.T) {
+	var peers []p2p.Peer
+	for i := 0; i < 4; i++ {
+		peers = append(peers, p2p.Peer{Index: i, Name: fmt.Sprintf("peer-%d", i)})
+	}
+
+	pubkey := testutil.RandomCorePubKey(t)
+	parsig := func(shareIdx int) core.ParSignedData {
+		return core.ParSignedData{ShareIdx: shareIdx}
+	}
+
+	parsigs := parsigsByMsg{
+		pubkey: {
+			{1}: {parsig(1), parsig(2), parsig(3)},
+			{2}: {parsig(4)},
+		},
+		testutil.RandomCorePubKey(t): {
+			{1}: {parsig(1), parsig(2)},
+		},
+	}
+
+	now := time.Now()
+	duty := core.NewAttesterDuty(1)
+
+	resp := newInconsistencies(duty, parsigs, peers, now)
+	require.Equal(t, []Inconsistency{{
+		Time:   now,
+		Duty:   duty.String(),
+		Pubkey: string(pubkey),
+		Groups: []InconsistentGroup{
+			{MsgRoot: fmt.Sprintf("%#x", [32]byte{1}), Peers: []string{"peer-0", "peer-1", "peer-2"}},
+			{MsgRoot: fmt.Sprintf("%#x", [32]byte{2}), Peers: []string{"peer-3"}},
+		},
+	}}, resp)
+
+	// Sync committee inconsistencies are expected and not recorded.
+	require.Empty(t, newInconsistencies(core.NewSyncMessageDuty(1), parsigs, peers, now))
+}
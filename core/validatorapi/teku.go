@@ -55,6 +55,9 @@ func (c Component) TekuProposerConfig(ctx context.Context) (TekuProposerConfigRe
 		return TekuProposerConfigResponse{}, err
 	}
 
+	c.sharesMu.RLock()
+	defer c.sharesMu.RUnlock()
+
 	for pubkey, pubshare := range c.sharesByKey {
 		resp.Proposers[string(pubshare)] = TekuProposerConfig{
 			FeeRecipient: c.feeRecipientFunc(pubkey),
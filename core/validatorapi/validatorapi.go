@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
@@ -26,13 +27,13 @@ import (
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
-	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
 )
 
 // NewComponentInsecure returns a new instance of the validator API core workflow component
 // that does not perform signature verification.
 func NewComponentInsecure(_ *testing.T, eth2Cl eth2wrap.Client, shareIdx int) (*Component, error) {
 	return &Component{
+		sharesMu:       new(sync.RWMutex),
 		eth2Cl:         eth2Cl,
 		shareIdx:       shareIdx,
 		builderEnabled: func(int64) bool { return false },
@@ -44,38 +45,28 @@ func NewComponentInsecure(_ *testing.T, eth2Cl eth2wrap.Client, shareIdx int) (*
 func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey,
 	shareIdx int, feeRecipientFunc func(core.PubKey) string, builderEnabled core.BuilderEnabled, seenPubkeys func(core.PubKey),
 ) (*Component, error) {
-	var (
-		sharesByKey     = make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey)
-		keysByShare     = make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey)
-		sharesByCoreKey = make(map[core.PubKey]tblsv2.PublicKey)
-		coreSharesByKey = make(map[core.PubKey]core.PubKey)
-	)
-	for corePubkey, shares := range allPubSharesByKey {
-		pubshare := shares[shareIdx]
-		coreShare, err := core.PubKeyFromBytes(pubshare[:])
-		if err != nil {
-			return nil, err
-		}
-
-		cpBytes, err := corePubkey.Bytes()
-		if err != nil {
-			return nil, err
-		}
-		pubkey, err := tblsconv2.PubkeyFromBytes(cpBytes)
-		if err != nil {
-			return nil, err
-		}
-		eth2Pubkey := eth2p0.BLSPubKey(pubkey)
-
-		eth2Share := eth2p0.BLSPubKey(pubshare)
-		sharesByCoreKey[corePubkey] = pubshare
-		coreSharesByKey[corePubkey] = coreShare
-		sharesByKey[eth2Pubkey] = eth2Share
-		keysByShare[eth2Share] = eth2Pubkey
+	c := &Component{
+		sharesMu:          new(sync.RWMutex),
+		eth2Cl:            eth2Cl,
+		shareIdx:          shareIdx,
+		feeRecipientFunc:  feeRecipientFunc,
+		builderEnabled:    builderEnabled,
+		allPubSharesByKey: make(map[core.PubKey]map[int]tblsv2.PublicKey),
+		sharesByKey:       make(map[core.PubKey]core.PubKey),
+		eth2SharesByKey:   make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey),
+		eth2KeysByShare:   make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey),
+		sharesByCoreKey:   make(map[core.PubKey]tblsv2.PublicKey),
+	}
+
+	if err := c.AddValidators(allPubSharesByKey); err != nil {
+		return nil, err
 	}
 
-	getVerifyShareFunc := func(pubkey core.PubKey) (tblsv2.PublicKey, error) {
-		pubshare, ok := sharesByCoreKey[pubkey]
+	c.getVerifyShareFunc = func(pubkey core.PubKey) (tblsv2.PublicKey, error) {
+		c.sharesMu.RLock()
+		defer c.sharesMu.RUnlock()
+
+		pubshare, ok := c.sharesByCoreKey[pubkey]
 		if !ok {
 			return tblsv2.PublicKey{}, errors.New("unknown public key")
 		}
@@ -83,8 +74,10 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		return pubshare, nil
 	}
 
-	getPubShareFunc := func(pubkey eth2p0.BLSPubKey) (eth2p0.BLSPubKey, bool) {
-		share, ok := sharesByKey[pubkey]
+	c.getPubShareFunc = func(pubkey eth2p0.BLSPubKey) (eth2p0.BLSPubKey, bool) {
+		c.sharesMu.RLock()
+		share, ok := c.eth2SharesByKey[pubkey]
+		c.sharesMu.RUnlock()
 
 		if seenPubkeys != nil {
 			seenPubkeys(core.PubKeyFrom48Bytes(pubkey))
@@ -93,10 +86,13 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		return share, ok
 	}
 
-	getPubKeyFunc := func(share eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error) {
-		key, ok := keysByShare[share]
+	c.getPubKeyFunc = func(share eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error) {
+		c.sharesMu.RLock()
+		defer c.sharesMu.RUnlock()
+
+		key, ok := c.eth2KeysByShare[share]
 		if !ok {
-			for _, shares := range allPubSharesByKey {
+			for _, shares := range c.allPubSharesByKey {
 				for keyshareIdx, pubshare := range shares {
 					if eth2p0.BLSPubKey(pubshare) == share {
 						return eth2p0.BLSPubKey{}, errors.New("mismatching validator client key share index, Mth key share submitted to Nth charon peer",
@@ -115,15 +111,75 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		return key, nil
 	}
 
-	return &Component{
-		getVerifyShareFunc: getVerifyShareFunc,
-		getPubShareFunc:    getPubShareFunc,
-		getPubKeyFunc:      getPubKeyFunc,
-		sharesByKey:        coreSharesByKey,
-		eth2Cl:             eth2Cl,
-		shareIdx:           shareIdx,
-		feeRecipientFunc:   feeRecipientFunc,
-		builderEnabled:     builderEnabled,
+	return c, nil
+}
+
+// AddValidators adds the public shares of validators by root public key, ignoring existing validators.
+func (c *Component) AddValidators(allPubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey) error {
+	commit, err := c.PrepareValidators(allPubSharesByKey)
+	if err != nil {
+		return err
+	}
+
+	commit()
+
+	return nil
+}
+
+// PrepareValidators validates the public shares of validators by root public key and returns a function
+// adding them, ignoring existing validators. It supports adding validators at runtime without partially
+// applying invalid validators, e.g. when reloading the cluster lock.
+func (c *Component) PrepareValidators(allPubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey) (func(), error) {
+	type validator struct {
+		CorePubkey core.PubKey
+		Shares     map[int]tblsv2.PublicKey
+		Pubshare   tblsv2.PublicKey
+		CoreShare  core.PubKey
+		Eth2Pubkey eth2p0.BLSPubKey
+	}
+
+	var vals []validator
+	for corePubkey, shares := range allPubSharesByKey {
+		pubshare, ok := shares[c.shareIdx]
+		if !ok {
+			return nil, errors.New("missing validator public share", z.Str("pubkey", corePubkey.String()))
+		}
+
+		coreShare, err := core.PubKeyFromBytes(pubshare[:])
+		if err != nil {
+			return nil, err
+		}
+
+		eth2Pubkey, err := corePubkey.ToETH2()
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, validator{
+			CorePubkey: corePubkey,
+			Shares:     shares,
+			Pubshare:   pubshare,
+			CoreShare:  coreShare,
+			Eth2Pubkey: eth2Pubkey,
+		})
+	}
+
+	return func() {
+		c.sharesMu.Lock()
+		defer c.sharesMu.Unlock()
+
+		for _, val := range vals {
+			if _, ok := c.allPubSharesByKey[val.CorePubkey]; ok {
+				continue
+			}
+
+			eth2Share := eth2p0.BLSPubKey(val.Pubshare)
+			c.allPubSharesByKey[val.CorePubkey] = val.Shares
+			c.sharesByCoreKey[val.CorePubkey] = val.Pubshare
+			c.sharesByKey[val.CorePubkey] = val.CoreShare
+			c.eth2SharesByKey[val.Eth2Pubkey] = eth2Share
+			c.eth2KeysByShare[eth2Share] = val.Eth2Pubkey
+		}
 	}, nil
 }
 
@@ -141,8 +197,19 @@ type Component struct {
 	getPubShareFunc func(eth2p0.BLSPubKey) (eth2p0.BLSPubKey, bool)
 	// getPubKeyFunc returns the root public key for a public share.
 	getPubKeyFunc func(eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error)
+
+	// sharesMu protects the validator public share maps below, since validators may be added at runtime.
+	sharesMu *sync.RWMutex
+	// allPubSharesByKey contains all the public shares (value) by root public key (key).
+	allPubSharesByKey map[core.PubKey]map[int]tblsv2.PublicKey
 	// sharesByKey contains this node's public shares (value) by root public (key)
 	sharesByKey map[core.PubKey]core.PubKey
+	// sharesByCoreKey contains this node's public shares (value) by root public (key).
+	sharesByCoreKey map[core.PubKey]tblsv2.PublicKey
+	// eth2SharesByKey contains this node's public shares (value) by root public (key).
+	eth2SharesByKey map[eth2p0.BLSPubKey]eth2p0.BLSPubKey
+	// eth2KeysByShare contains the root public keys (value) by this node's public shares (key).
+	eth2KeysByShare map[eth2p0.BLSPubKey]eth2p0.BLSPubKey
 
 	// Registered input functions
 
@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path"
@@ -49,6 +50,10 @@ func loadDefinition(ctx context.Context, conf Config) (cluster.Definition, error
 			return cluster.Definition{}, errors.Wrap(err, "read definition")
 		}
 
+		if err = cluster.VerifyDefinitionJSON(buf); err != nil {
+			return cluster.Definition{}, errors.Wrap(err, "invalid definition file", z.Str("path", conf.DefFile))
+		}
+
 		if err = json.Unmarshal(buf, &def); err != nil {
 			return cluster.Definition{}, errors.Wrap(err, "unmarshal definition")
 		}
@@ -105,7 +110,7 @@ func writeKeysToKeymanager(ctx context.Context, keymanagerURL string, shares []s
 		keystores = append(keystores, store)
 	}
 
-	cl := keymanager.New(keymanagerURL)
+	cl := keymanager.New(keymanagerURL, "")
 	err := cl.ImportKeystores(ctx, keystores, passwords)
 	if err != nil {
 		return err
@@ -131,23 +136,92 @@ func writeKeysToDisk(datadir string, shares []share) error {
 }
 
 // writeLock writes the lock file to disk.
-//
+// The indented json is streamed to a temporary file, avoiding an intermediate copy of the marshalled lock.
+// The temporary file is renamed once complete, so a half-written lock file is never left behind.
+// A stale read-only temporary file from an interrupted run is removed first.
+// The output is identical to json.MarshalIndent. The lock is encrypted at rest if a password is provided.
+func writeLock(datadir string, lock cluster.Lock, password string) error {
+	lockPath := path.Join(datadir, "cluster-lock.json")
+	tmpPath := lockPath + ".tmp"
+
+	var encrypted []byte
+	if password != "" {
+		var err error
+		encrypted, err = cluster.EncryptLock(lock, password)
+		if err != nil {
+			return err
+		}
+	}
 
-func writeLock(datadir string, lock cluster.Lock) error {
-	b, err := json.MarshalIndent(lock, "", " ")
-	if err != nil {
-		return errors.Wrap(err, "marshal lock")
+	if err := os.Remove(tmpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "remove stale lock file")
 	}
 
 	//nolint:gosec // File needs to be read-only for everybody
-	err = os.WriteFile(path.Join(datadir, "cluster-lock.json"), b, 0o444) // Read-only
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o444) // Read-only
+	if err != nil {
+		return errors.Wrap(err, "create lock file")
+	}
+
+	if encrypted != nil {
+		_, err = f.Write(encrypted)
+	} else {
+		enc := json.NewEncoder(&trimNewlineWriter{w: f})
+		enc.SetIndent("", " ")
+		err = enc.Encode(lock)
+	}
 	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+
 		return errors.Wrap(err, "write lock")
 	}
 
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(err, "close lock file")
+	}
+
+	if err := os.Rename(tmpPath, lockPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(err, "rename lock file")
+	}
+
 	return nil
 }
 
+// trimNewlineWriter drops the trailing newline appended by json.Encoder, so the output matches json.MarshalIndent.
+// Newlines are held back until followed by subsequent writes.
+type trimNewlineWriter struct {
+	w       io.Writer
+	pending bool
+}
+
+func (t *trimNewlineWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if t.pending {
+		if _, err := t.w.Write([]byte{'\n'}); err != nil {
+			return 0, err
+		}
+		t.pending = false
+	}
+
+	n := len(p)
+	if p[n-1] == '\n' {
+		p = p[:n-1]
+		t.pending = true
+	}
+
+	if _, err := t.w.Write(p); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
 // writeDepositData writes deposit data file to disk.
 func writeDepositData(depositDatas []eth2p0.DepositData, network string, dataDir string) error {
 	// Serialize the deposit data into bytes
@@ -168,6 +242,42 @@ func writeDepositData(depositDatas []eth2p0.DepositData, network string, dataDir
 	return nil
 }
 
+// removeOutputs removes the partially written DKG output files and directories.
+// It logs a hint to delete any outputs that could not be removed manually.
+func removeOutputs(ctx context.Context, paths []string) {
+	for _, p := range paths {
+		if err := os.RemoveAll(p); err != nil {
+			log.Warn(ctx, "Failed removing partial DKG output, please delete it manually before retrying", err, z.Str("path", p))
+			continue
+		}
+
+		log.Info(ctx, "Removed partial DKG output", z.Str("path", p))
+	}
+}
+
+// validatorDepositDataPath returns the path of the deposit data file of a single validator.
+func validatorDepositDataPath(dataDir string, depositData eth2p0.DepositData) string {
+	return path.Join(dataDir, fmt.Sprintf("deposit-data-%#x.json", depositData.PublicKey[:]))
+}
+
+// writeValidatorDepositData writes a separate deposit data file per validator to disk.
+func writeValidatorDepositData(depositDatas []eth2p0.DepositData, network string, dataDir string) error {
+	for _, depositData := range depositDatas {
+		bytes, err := deposit.MarshalDepositData([]eth2p0.DepositData{depositData}, network)
+		if err != nil {
+			return err
+		}
+
+		//nolint:gosec // File needs to be read-only for everybody
+		err = os.WriteFile(validatorDepositDataPath(dataDir, depositData), bytes, 0o444)
+		if err != nil {
+			return errors.Wrap(err, "write validator deposit data")
+		}
+	}
+
+	return nil
+}
+
 // checkWrites writes sample files to check disk writes and removes sample files after verification.
 func checkWrites(dataDir string) error {
 	const checkBody = "delete me: dummy file used to check write permissions"
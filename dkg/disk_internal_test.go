@@ -3,14 +3,23 @@
 package dkg
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path"
 	"testing"
 
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+	"github.com/obolnetwork/charon/testutil"
 )
 
 func TestLoadDefinition(t *testing.T) {
@@ -43,10 +52,26 @@ func TestLoadDefinition(t *testing.T) {
 	err = os.WriteFile(invalidFile2, b2, 0o666)
 	require.NoError(t, err)
 
+	// Draft definition with an unknown field
+	draft, _, _ := cluster.NewForT(t, 1, 2, 3, 0, cluster.WithVersion("v1.6.0"))
+	b3, err := json.Marshal(draft.Definition)
+	require.NoError(t, err)
+	rawJSONString = make(map[string]any)
+	require.NoError(t, json.Unmarshal(b3, &rawJSONString))
+	rawJSONString["num_validator"] = 1
+
+	b3, err = json.Marshal(rawJSONString)
+	require.NoError(t, err)
+
+	invalidFile3 := "invalid-cluster-definition3.json"
+	err = os.WriteFile(invalidFile3, b3, 0o666)
+	require.NoError(t, err)
+
 	defer func() {
 		require.NoError(t, os.Remove(validFile))
 		require.NoError(t, os.Remove(invalidFile))
 		require.NoError(t, os.Remove(invalidFile2))
+		require.NoError(t, os.Remove(invalidFile3))
 	}()
 
 	tests := []struct {
@@ -90,6 +115,12 @@ func TestLoadDefinition(t *testing.T) {
 			noVerify: false,
 			wantErr:  true,
 		},
+		{
+			name:     "Load draft definition with unknown field",
+			defFile:  invalidFile3,
+			noVerify: true,
+			wantErr:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,3 +137,159 @@ func TestLoadDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteLock(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 10, 3, 4, 0)
+
+	dir := t.TempDir()
+	require.NoError(t, writeLock(dir, lock, ""))
+
+	actual, err := os.ReadFile(path.Join(dir, "cluster-lock.json"))
+	require.NoError(t, err)
+
+	expected, err := json.MarshalIndent(lock, "", " ")
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	info, err := os.Stat(path.Join(dir, "cluster-lock.json"))
+	require.NoError(t, err)
+	require.EqualValues(t, 0o444, info.Mode().Perm())
+
+	t.Run("encrypted", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, writeLock(dir, lock, "secret"))
+
+		b, err := os.ReadFile(path.Join(dir, "cluster-lock.json"))
+		require.NoError(t, err)
+		require.True(t, cluster.IsEncryptedLock(b))
+
+		decrypted, err := cluster.DecryptLock(b, "secret")
+		require.NoError(t, err)
+		require.NoError(t, decrypted.VerifyHashes())
+		require.Equal(t, lock.LockHash, decrypted.LockHash)
+	})
+
+	t.Run("stale tmp file", func(t *testing.T) {
+		dir := t.TempDir()
+		tmpPath := path.Join(dir, "cluster-lock.json.tmp")
+		require.NoError(t, os.WriteFile(tmpPath, []byte("stale"), 0o444))
+
+		require.NoError(t, writeLock(dir, lock, ""))
+
+		actual, err := os.ReadFile(path.Join(dir, "cluster-lock.json"))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		_, err = os.Stat(tmpPath)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestWriteValidatorDepositData(t *testing.T) {
+	const network = "goerli"
+
+	var depositDatas []eth2p0.DepositData
+	for i := 0; i < 3; i++ {
+		depositDatas = append(depositDatas, newDepositData(t, network))
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, writeValidatorDepositData(depositDatas, network, dir))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, len(depositDatas))
+
+	for _, depositData := range depositDatas {
+		actual, err := os.ReadFile(validatorDepositDataPath(dir, depositData))
+		require.NoError(t, err)
+
+		expected, err := deposit.MarshalDepositData([]eth2p0.DepositData{depositData}, network)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		var entries []map[string]any
+		require.NoError(t, json.Unmarshal(actual, &entries))
+		require.Len(t, entries, 1)
+		require.Equal(t, fmt.Sprintf("%x", depositData.PublicKey[:]), entries[0]["pubkey"])
+	}
+}
+
+// newDepositData returns a new random signed deposit data for the network.
+func newDepositData(t *testing.T, network string) eth2p0.DepositData {
+	t.Helper()
+
+	secret, err := tblsv2.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pk, err := tblsv2.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	pubkey, err := tblsconv2.PubkeyToETH2(pk)
+	require.NoError(t, err)
+
+	msg, err := deposit.NewMessage(pubkey, testutil.RandomETHAddress())
+	require.NoError(t, err)
+
+	sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+	require.NoError(t, err)
+
+	sig, err := tblsv2.Sign(secret, sigRoot[:])
+	require.NoError(t, err)
+
+	return eth2p0.DepositData{
+		PublicKey:             msg.PublicKey,
+		WithdrawalCredentials: msg.WithdrawalCredentials,
+		Amount:                msg.Amount,
+		Signature:             tblsconv2.SigToETH2(sig),
+	}
+}
+
+func TestTrimNewlineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &trimNewlineWriter{w: &buf}
+
+	for _, s := range []string{"a\n", "\n", "b", "c\n"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+
+	require.Equal(t, "a\n\nbc", buf.String())
+}
+
+func BenchmarkWriteLock(b *testing.B) {
+	const (
+		numVals  = 10000
+		numNodes = 4
+	)
+
+	var feeRecipients, withdrawals []string
+	for i := 0; i < numVals; i++ {
+		feeRecipients = append(feeRecipients, testutil.RandomETHAddress())
+		withdrawals = append(withdrawals, testutil.RandomETHAddress())
+	}
+
+	def, err := cluster.NewDefinition("bench", numVals, 3, feeRecipients, withdrawals,
+		"0x00000000", cluster.Creator{}, make([]cluster.Operator, numNodes), rand.Reader)
+	require.NoError(b, err)
+
+	lock := cluster.Lock{Definition: def}
+	for i := 0; i < numVals; i++ {
+		val := cluster.DistValidator{PubKey: testutil.RandomBytes48()}
+		for j := 0; j < numNodes; j++ {
+			val.PubShares = append(val.PubShares, testutil.RandomBytes48())
+		}
+		lock.Validators = append(lock.Validators, val)
+	}
+
+	dir := b.TempDir()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = os.Remove(path.Join(dir, "cluster-lock.json"))
+		require.NoError(b, writeLock(dir, lock, ""))
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"sort"
 	"time"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -15,8 +19,10 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/forkjoin"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/pushmetrics"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
@@ -31,16 +37,26 @@ import (
 )
 
 type Config struct {
-	DefFile        string
-	KeymanagerAddr string
-	NoVerify       bool
-	DataDir        string
-	P2P            p2p.Config
-	Log            log.Config
+	DefFile             string
+	KeymanagerAddr      string
+	NoVerify            bool
+	DataDir             string
+	PrivKeyPasswordFile string
+	LockPasswordFile    string
+	P2P                 p2p.Config
+	Log                 log.Config
 
 	PublishAddr string
 	Publish     bool
 
+	PushgatewayAddr string
+
+	DepositDataPerValidator bool
+
+	// SigVerifyConcurrency is the maximum number of concurrent partial signature verifications,
+	// defaults to the number of CPUs if zero.
+	SigVerifyConcurrency int
+
 	TestDef          *cluster.Definition
 	TestSyncCallback func(connected int, id peer.ID)
 }
@@ -52,7 +68,10 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	ctx = log.WithTopic(ctx, "dkg")
 	defer func() {
-		if err != nil {
+		if errors.Is(err, context.Canceled) {
+			log.Error(ctx, "DKG ceremony aborted", err)
+			log.Info(ctx, "No cluster lock was written, all operators must restart the DKG ceremony")
+		} else if err != nil {
 			log.Error(ctx, "Fatal error", err)
 		}
 	}()
@@ -64,9 +83,28 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
+	if err := verifyTSSScheme(def); err != nil {
+		return err
+	}
+
+	if conf.PushgatewayAddr != "" {
+		t0 := time.Now()
+		defer func() {
+			pushErr := pushmetrics.Push(ctx, conf.PushgatewayAddr, pushmetrics.Operation{
+				Name:          "dkg",
+				Duration:      time.Since(t0),
+				NumValidators: def.NumValidators,
+				Err:           err,
+			})
+			if pushErr != nil {
+				log.Warn(ctx, "Failed pushing metrics to pushgateway", pushErr)
+			}
+		}()
+	}
+
 	// Check if keymanager address is reachable.
 	if conf.KeymanagerAddr != "" {
-		cl := keymanager.New(conf.KeymanagerAddr)
+		cl := keymanager.New(conf.KeymanagerAddr, "")
 		if err = cl.VerifyConnection(ctx); err != nil {
 			return errors.Wrap(err, "verify keymanager address")
 		}
@@ -88,7 +126,18 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	clusterID := fmt.Sprintf("%#x", def.DefinitionHash)
 
-	key, err := p2p.LoadPrivKey(conf.DataDir)
+	password, err := p2p.LoadPrivKeyPassword(conf.PrivKeyPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := p2p.LoadPrivKeyWithPassword(conf.DataDir, password)
+	if err != nil {
+		return err
+	}
+
+	// Load the lock password before the ceremony, so a missing file doesn't abort it after completion.
+	lockPassword, err := cluster.LoadLockPassword(conf.LockPasswordFile)
 	if err != nil {
 		return err
 	}
@@ -165,7 +214,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	// Sign, exchange and aggregate Deposit Data
-	depositDatas, err := signAndAggDepositData(ctx, ex, shares, def.WithdrawalAddresses(), network, nodeIdx)
+	depositDatas, err := signAndAggDepositData(ctx, ex, shares, def.WithdrawalAddresses(), network, nodeIdx, conf.SigVerifyConcurrency)
 	if err != nil {
 		return err
 	}
@@ -173,7 +222,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	log.Debug(ctx, "Aggregated deposit data signatures")
 
 	// Sign, exchange and aggregate Lock Hash signatures
-	lock, err := signAndAggLockHash(ctx, shares, def, nodeIdx, ex, depositDatas)
+	lock, err := signAndAggLockHash(ctx, shares, def, nodeIdx, ex, depositDatas, conf.SigVerifyConcurrency)
 	if err != nil {
 		return err
 	}
@@ -190,6 +239,33 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	// Write keystores, deposit data and cluster lock files after exchange of partial signatures in order
 	// to prevent partial data writes in case of peer connection lost
+	if err = writeOutputs(ctx, conf, shares, lock, depositDatas, network, lockPassword); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Successfully completed DKG ceremony 🎉")
+
+	return nil
+}
+
+// writeOutputs writes the keyshares, cluster lock and deposit data files.
+// Files written to disk are removed if an error occurs or the context is cancelled midway,
+// so an aborted DKG never leaves partial outputs behind.
+func writeOutputs(ctx context.Context, conf Config, shares []share, lock cluster.Lock,
+	depositDatas []eth2p0.DepositData, network string, lockPassword string,
+) (err error) {
+	var written []string
+	// track records the path for removal on failure if it doesn't exist yet.
+	track := func(file string) {
+		if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+			written = append(written, file)
+		}
+	}
+	defer func() {
+		if err != nil {
+			removeOutputs(ctx, written)
+		}
+	}()
 
 	if conf.KeymanagerAddr != "" { // Save to keymanager
 		if err = writeKeysToKeymanager(ctx, conf.KeymanagerAddr, shares); err != nil {
@@ -197,29 +273,52 @@ func Run(ctx context.Context, conf Config) (err error) {
 		}
 		log.Debug(ctx, "Imported keyshares to keymanager", z.Str("keymanager_address", conf.KeymanagerAddr))
 	} else { // Else save to disk
+		track(path.Join(conf.DataDir, "validator_keys"))
 		if err = writeKeysToDisk(conf.DataDir, shares); err != nil {
 			return err
 		}
 		log.Debug(ctx, "Saved keyshares to disk")
 	}
 
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
 	if conf.Publish {
-		if err = writeLockToAPI(ctx, conf.PublishAddr, lock); err != nil {
+		if err := writeLockToAPI(ctx, conf.PublishAddr, lock); err != nil {
 			log.Warn(ctx, "Couldn't publish lock file to Obol API", err)
 		}
 	}
 
-	if err = writeLock(conf.DataDir, lock); err != nil {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	track(path.Join(conf.DataDir, "cluster-lock.json"))
+	if err = writeLock(conf.DataDir, lock, lockPassword); err != nil {
 		return err
 	}
 	log.Debug(ctx, "Saved lock file to disk")
 
-	if err := writeDepositData(depositDatas, network, conf.DataDir); err != nil {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	track(path.Join(conf.DataDir, "deposit-data.json"))
+	if err = writeDepositData(depositDatas, network, conf.DataDir); err != nil {
 		return err
 	}
 	log.Debug(ctx, "Saved deposit data file to disk")
 
-	log.Info(ctx, "Successfully completed DKG ceremony 🎉")
+	if conf.DepositDataPerValidator {
+		for _, depositData := range depositDatas {
+			track(validatorDepositDataPath(conf.DataDir, depositData))
+		}
+		if err = writeValidatorDepositData(depositDatas, network, conf.DataDir); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Saved validator deposit data files to disk")
+	}
 
 	return nil
 }
@@ -354,7 +453,7 @@ func startSyncProtocol(ctx context.Context, tcpNode host.Host, key *k1.PrivateKe
 
 // signAndAggLockHash returns cluster lock file with aggregated signature after signing, exchange and aggregation of partial signatures.
 func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definition,
-	nodeIdx cluster.NodeIdx, ex *exchanger, depositDatas []eth2p0.DepositData,
+	nodeIdx cluster.NodeIdx, ex *exchanger, depositDatas []eth2p0.DepositData, concurrency int,
 ) (cluster.Lock, error) {
 	vals, err := createDistValidators(shares, depositDatas)
 	if err != nil {
@@ -370,6 +469,10 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 		return cluster.Lock{}, err
 	}
 
+	if err := lock.VerifyDepositData(); err != nil {
+		return cluster.Lock{}, err
+	}
+
 	lockHashSig, err := signLockHash(nodeIdx.ShareIdx, shares, lock.LockHash)
 	if err != nil {
 		return cluster.Lock{}, err
@@ -390,7 +493,7 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 		pubkeyToShares[pk] = sh
 	}
 
-	aggSigLockHash, aggPkLockHash, err := aggLockHashSig(peerSigs, pubkeyToShares, lock.LockHash)
+	aggSigLockHash, aggPkLockHash, err := aggLockHashSig(ctx, peerSigs, pubkeyToShares, lock.LockHash, concurrency)
 	if err != nil {
 		return cluster.Lock{}, err
 	}
@@ -407,7 +510,7 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 
 // signAndAggDepositData returns the deposit datas for each DV after signing, exchange and aggregation of partial signatures.
 func signAndAggDepositData(ctx context.Context, ex *exchanger, shares []share, withdrawalAddresses []string,
-	network string, nodeIdx cluster.NodeIdx,
+	network string, nodeIdx cluster.NodeIdx, concurrency int,
 ) ([]eth2p0.DepositData, error) {
 	parSig, despositMsgs, err := signDepositMsgs(shares, nodeIdx.ShareIdx, withdrawalAddresses, network)
 	if err != nil {
@@ -419,21 +522,17 @@ func signAndAggDepositData(ctx context.Context, ex *exchanger, shares []share, w
 		return nil, err
 	}
 
-	return aggDepositData(peerSigs, shares, despositMsgs, network)
+	return aggDepositData(ctx, peerSigs, shares, despositMsgs, network, concurrency)
 }
 
 // aggLockHashSig returns the aggregated multi signature of the lock hash
 // signed by all the private key shares of all the distributed validators.
-func aggLockHashSig(data map[core.PubKey][]core.ParSignedData, shares map[core.PubKey]share, hash []byte) (tblsv2.Signature, []tblsv2.PublicKey, error) {
-	var (
-		sigs    []tblsv2.Signature
-		pubkeys []tblsv2.PublicKey
-	)
-
-	for pk, psigs := range data {
-		pk := pk
-		psigs := psigs
-		for _, s := range psigs {
+func aggLockHashSig(ctx context.Context, data map[core.PubKey][]core.ParSignedData, shares map[core.PubKey]share,
+	hash []byte, concurrency int,
+) (tblsv2.Signature, []tblsv2.PublicKey, error) {
+	var verifications []parSigVerification
+	for _, pk := range sortedPubKeys(data) {
+		for _, s := range sortedByShareIdx(data[pk]) {
 			sig, err := tblsconv2.SignatureFromBytes(s.Signature())
 			if err != nil {
 				return tblsv2.Signature{}, nil, errors.Wrap(err, "signature from bytes")
@@ -451,17 +550,34 @@ func aggLockHashSig(data map[core.PubKey][]core.ParSignedData, shares map[core.P
 				return tblsv2.Signature{}, nil, errors.New("invalid pubshare")
 			}
 
-			err = tblsv2.Verify(pubshare, hash, sig)
-			if err != nil {
-				return tblsv2.Signature{}, nil, errors.Wrap(err, "invalid lock hash partial signature from peer",
-					z.Int("peerIdx", s.ShareIdx-1), z.Str("pubkey", pk.String()))
-			}
-
-			sigs = append(sigs, sig)
-			pubkeys = append(pubkeys, pubshare)
+			verifications = append(verifications, parSigVerification{
+				PubKey:   pk,
+				ShareIdx: s.ShareIdx,
+				PubShare: pubshare,
+				Msg:      hash,
+				Sig:      sig,
+			})
 		}
 	}
 
+	invalidIdx, err := verifyParSigs(ctx, verifications, concurrency)
+	if invalidIdx >= 0 {
+		v := verifications[invalidIdx]
+		return tblsv2.Signature{}, nil, errors.Wrap(err, "invalid lock hash partial signature from peer",
+			z.Int("peerIdx", v.ShareIdx-1), z.Str("pubkey", v.PubKey.String()))
+	} else if err != nil {
+		return tblsv2.Signature{}, nil, err
+	}
+
+	var (
+		sigs    []tblsv2.Signature
+		pubkeys []tblsv2.PublicKey
+	)
+	for _, v := range verifications {
+		sigs = append(sigs, v.Sig)
+		pubkeys = append(pubkeys, v.PubShare)
+	}
+
 	// Full BLS Signature Aggregation
 	aggSig, err := tblsv2.Aggregate(sigs)
 	if err != nil {
@@ -537,8 +653,8 @@ func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string,
 }
 
 // aggDepositData returns the threshold aggregated deposit datas per DV.
-func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
-	msgs map[core.PubKey]eth2p0.DepositMessage, network string,
+func aggDepositData(ctx context.Context, data map[core.PubKey][]core.ParSignedData, shares []share,
+	msgs map[core.PubKey]eth2p0.DepositMessage, network string, concurrency int,
 ) ([]eth2p0.DepositData, error) {
 	pubkeyToPubShares := make(map[core.PubKey]map[int]tblsv2.PublicKey)
 	for _, sh := range shares {
@@ -550,12 +666,12 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 		pubkeyToPubShares[pk] = sh.PublicShares
 	}
 
-	var resp []eth2p0.DepositData
-
-	for pk, psigsData := range data {
-		pk := pk
-		psigsData := psigsData
-
+	var (
+		pks           = sortedPubKeys(data)
+		sigRoots      = make(map[core.PubKey][]byte)
+		verifications []parSigVerification
+	)
+	for _, pk := range pks {
 		msg, ok := msgs[pk]
 		if !ok {
 			return nil, errors.New("deposit message not found")
@@ -564,9 +680,9 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 		if err != nil {
 			return nil, err
 		}
+		sigRoots[pk] = sigRoot[:]
 
-		psigs := make(map[int]tblsv2.Signature)
-		for _, s := range psigsData {
+		for _, s := range sortedByShareIdx(data[pk]) {
 			sig, err := tblsconv2.SignatureFromBytes(s.Signature())
 			if err != nil {
 				return nil, errors.Wrap(err, "signature from core")
@@ -584,17 +700,37 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 				return nil, errors.New("invalid pubshare")
 			}
 
-			err = tblsv2.Verify(pubshare, sigRoot[:], sig)
-			if err != nil {
-				return nil, errors.New("invalid deposit data partial signature from peer",
-					z.Int("peerIdx", s.ShareIdx-1), z.Str("pubkey", pk.String()))
-			}
+			verifications = append(verifications, parSigVerification{
+				PubKey:   pk,
+				ShareIdx: s.ShareIdx,
+				PubShare: pubshare,
+				Msg:      sigRoots[pk],
+				Sig:      sig,
+			})
+		}
+	}
+
+	invalidIdx, err := verifyParSigs(ctx, verifications, concurrency)
+	if invalidIdx >= 0 {
+		v := verifications[invalidIdx]
+		return nil, errors.New("invalid deposit data partial signature from peer",
+			z.Int("peerIdx", v.ShareIdx-1), z.Str("pubkey", v.PubKey.String()))
+	} else if err != nil {
+		return nil, err
+	}
 
-			psigs[s.ShareIdx] = sig
+	psigsByPubkey := make(map[core.PubKey]map[int]tblsv2.Signature)
+	for _, v := range verifications {
+		if psigsByPubkey[v.PubKey] == nil {
+			psigsByPubkey[v.PubKey] = make(map[int]tblsv2.Signature)
 		}
+		psigsByPubkey[v.PubKey][v.ShareIdx] = v.Sig
+	}
 
+	var resp []eth2p0.DepositData
+	for _, pk := range pks {
 		// Aggregate signatures per DV
-		asig, err := tblsv2.ThresholdAggregate(psigs)
+		asig, err := tblsv2.ThresholdAggregate(psigsByPubkey[pk])
 		if err != nil {
 			return nil, err
 		}
@@ -604,11 +740,12 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 			return nil, err
 		}
 
-		err = tblsv2.Verify(pubkey, sigRoot[:], asig)
+		err = tblsv2.Verify(pubkey, sigRoots[pk], asig)
 		if err != nil {
 			return nil, errors.Wrap(err, "invalid deposit data aggregated signature")
 		}
 
+		msg := msgs[pk]
 		resp = append(resp, eth2p0.DepositData{
 			PublicKey:             msg.PublicKey,
 			WithdrawalCredentials: msg.WithdrawalCredentials,
@@ -620,6 +757,80 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 	return resp, nil
 }
 
+// parSigVerification is a partial signature to verify.
+type parSigVerification struct {
+	PubKey   core.PubKey
+	ShareIdx int
+	PubShare tblsv2.PublicKey
+	Msg      []byte
+	Sig      tblsv2.Signature
+}
+
+// verifyParSigs verifies the partial signatures using at most concurrency workers, defaulting to the number of CPUs.
+// It returns the index and error of the first invalid partial signature, i.e., the same result as sequential
+// verification irrespective of concurrency. It returns a negative index if all are valid or the context is cancelled.
+func verifyParSigs(ctx context.Context, verifications []parSigVerification, concurrency int) (int, error) {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	fork, join, cancel := forkjoin.New(ctx,
+		func(_ context.Context, idx int) (int, error) {
+			v := verifications[idx]
+			return idx, tblsv2.Verify(v.PubShare, v.Msg, v.Sig)
+		},
+		forkjoin.WithWorkers(concurrency),
+		forkjoin.WithInputBuffer(len(verifications)),
+		forkjoin.WithoutFailFast(), // Verify all to deterministically return the first invalid signature.
+	)
+	defer cancel()
+
+	for i := range verifications {
+		fork(i)
+	}
+
+	errs := make([]error, len(verifications))
+	for res := range join() {
+		errs[res.Input] = res.Err
+	}
+
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return -1, nil
+}
+
+// sortedPubKeys returns the public keys of the partial signed data in a deterministic order.
+func sortedPubKeys(data map[core.PubKey][]core.ParSignedData) []core.PubKey {
+	var resp []core.PubKey
+	for pk := range data {
+		resp = append(resp, pk)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i] < resp[j]
+	})
+
+	return resp
+}
+
+// sortedByShareIdx returns a copy of the partial signed data sorted by share index.
+func sortedByShareIdx(psigs []core.ParSignedData) []core.ParSignedData {
+	resp := append([]core.ParSignedData(nil), psigs...)
+	sort.SliceStable(resp, func(i, j int) bool {
+		return resp[i].ShareIdx < resp[j].ShareIdx
+	})
+
+	return resp
+}
+
 // createDistValidators returns a slice of distributed validators from the provided
 // shares and deposit datas.
 func createDistValidators(shares []share, depositDatas []eth2p0.DepositData) ([]cluster.DistValidator, error) {
@@ -667,3 +878,19 @@ func writeLockToAPI(ctx context.Context, publishAddr string, lock cluster.Lock)
 
 	return nil
 }
+
+// verifyTSSScheme returns an error if the definition's threshold secret sharing scheme isn't registered
+// or isn't supported by the DKG algorithm. FROST always uses the default scheme.
+func verifyTSSScheme(def cluster.Definition) error {
+	scheme, err := tblsv2.GetTSSScheme(def.TSSScheme)
+	if err != nil {
+		return err
+	}
+
+	if def.DKGAlgorithm != "keycast" && scheme.Name() != tblsv2.DefaultTSSScheme {
+		return errors.New("tss scheme only supported by keycast dkg algorithm",
+			z.Str("scheme", scheme.Name()), z.Str("algorithm", def.DKGAlgorithm))
+	}
+
+	return nil
+}
@@ -3,11 +3,18 @@
 package dkg
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path"
 	"testing"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/deposit"
@@ -69,18 +76,19 @@ func TestInvalidSignatures(t *testing.T) {
 	// Aggregate and verify deposit data signatures
 	msg := testutil.RandomDepositMsg(t)
 
-	_, err = aggDepositData(
+	_, err = aggDepositData(context.Background(),
 		map[core.PubKey][]core.ParSignedData{corePubkey: getSigs([]byte("any digest"))},
 		[]share{shares},
 		map[core.PubKey]eth2p0.DepositMessage{corePubkey: msg},
 		eth2util.Goerli.Name,
+		0,
 	)
 	require.EqualError(t, err, "invalid deposit data partial signature from peer")
 
 	// Aggregate and verify cluster lock hash signatures
 	lockMsg := []byte("cluster lock hash")
 
-	_, _, err = aggLockHashSig(map[core.PubKey][]core.ParSignedData{corePubkey: getSigs(lockMsg)}, map[core.PubKey]share{corePubkey: shares}, lockMsg)
+	_, _, err = aggLockHashSig(context.Background(), map[core.PubKey][]core.ParSignedData{corePubkey: getSigs(lockMsg)}, map[core.PubKey]share{corePubkey: shares}, lockMsg, 0)
 	require.EqualError(t, err, "invalid lock hash partial signature from peer: signature not verified")
 }
 
@@ -141,17 +149,175 @@ func TestValidSignatures(t *testing.T) {
 	sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
 	require.NoError(t, err)
 
-	_, err = aggDepositData(
+	_, err = aggDepositData(context.Background(),
 		map[core.PubKey][]core.ParSignedData{corePubkey: getSigs(sigRoot[:])},
 		[]share{shares},
 		map[core.PubKey]eth2p0.DepositMessage{corePubkey: msg},
 		network,
+		0,
 	)
 	require.NoError(t, err)
 
 	// Aggregate and verify cluster lock hash signatures
 	lockMsg := []byte("cluster lock hash")
 
-	_, _, err = aggLockHashSig(map[core.PubKey][]core.ParSignedData{corePubkey: getSigs(lockMsg)}, map[core.PubKey]share{corePubkey: shares}, lockMsg)
+	_, _, err = aggLockHashSig(context.Background(), map[core.PubKey][]core.ParSignedData{corePubkey: getSigs(lockMsg)}, map[core.PubKey]share{corePubkey: shares}, lockMsg, 0)
 	require.NoError(t, err)
 }
+
+func TestParallelSigVerification(t *testing.T) {
+	const (
+		n       = 4
+		th      = 3
+		numVals = 8
+		badVal  = 5
+		badIdx  = 2
+	)
+
+	lockMsg := []byte("cluster lock hash")
+	data := make(map[core.PubKey][]core.ParSignedData)
+	shares := make(map[core.PubKey]share)
+	var badPubkey core.PubKey
+
+	for v := 0; v < numVals; v++ {
+		secret, err := tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+		pubkey, err := tblsv2.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		secretShares, err := tblsv2.ThresholdSplit(secret, n, th)
+		require.NoError(t, err)
+
+		pk, err := core.PubKeyFromBytes(pubkey[:])
+		require.NoError(t, err)
+
+		pubshares := make(map[int]tblsv2.PublicKey)
+		for idx, secretShare := range secretShares {
+			pubshares[idx], err = tblsv2.SecretToPublicKey(secretShare)
+			require.NoError(t, err)
+
+			msg := lockMsg
+			if v == badVal && idx >= badIdx { // Multiple invalid shares, lowest share index is reported.
+				msg = []byte("invalid msg")
+				badPubkey = pk
+			}
+
+			sig, err := tblsv2.Sign(secretShare, msg)
+			require.NoError(t, err)
+			data[pk] = append(data[pk], core.NewPartialSignature(tblsconv2.SigToCore(sig), idx))
+		}
+
+		shares[pk] = share{PubKey: pubkey, PublicShares: pubshares}
+	}
+
+	_, _, seqErr := aggLockHashSig(context.Background(), data, shares, lockMsg, 1)
+	require.ErrorContains(t, seqErr, "invalid lock hash partial signature from peer")
+	require.EqualValues(t, badIdx-1, errField(t, seqErr, "peerIdx").Integer)
+	require.Equal(t, badPubkey.String(), errField(t, seqErr, "pubkey").String)
+
+	for _, concurrency := range []int{0, 2, 4, 16} {
+		_, _, parErr := aggLockHashSig(context.Background(), data, shares, lockMsg, concurrency)
+		require.Equal(t, seqErr.Error(), parErr.Error())
+		require.Equal(t, errField(t, seqErr, "peerIdx"), errField(t, parErr, "peerIdx"))
+		require.Equal(t, errField(t, seqErr, "pubkey"), errField(t, parErr, "pubkey"))
+	}
+}
+
+// errField returns the zap field with the key of the structured error.
+func errField(t *testing.T, err error, key string) zap.Field {
+	t.Helper()
+
+	serr, ok := err.(interface{ Fields() []z.Field }) //nolint:errorlint
+	require.True(t, ok)
+
+	var resp []zap.Field
+	for _, field := range serr.Fields() {
+		field(func(f zap.Field) {
+			if f.Key == key {
+				resp = append(resp, f)
+			}
+		})
+	}
+	require.Len(t, resp, 1)
+
+	return resp[0]
+}
+
+func BenchmarkVerifyParSigs(b *testing.B) {
+	const numSigs = 100
+
+	secret, err := tblsv2.GenerateSecretKey()
+	require.NoError(b, err)
+	pubkey, err := tblsv2.SecretToPublicKey(secret)
+	require.NoError(b, err)
+
+	msg := []byte("cluster lock hash")
+	sig, err := tblsv2.Sign(secret, msg)
+	require.NoError(b, err)
+
+	var verifications []parSigVerification
+	for i := 0; i < numSigs; i++ {
+		verifications = append(verifications, parSigVerification{ShareIdx: i + 1, PubShare: pubkey, Msg: msg, Sig: sig})
+	}
+
+	for _, concurrency := range []int{1, 4, 0} {
+		b.Run(fmt.Sprintf("concurrency_%d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				idx, err := verifyParSigs(context.Background(), verifications, concurrency)
+				require.NoError(b, err)
+				require.Equal(b, -1, idx)
+			}
+		})
+	}
+}
+
+func TestWriteOutputs(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+
+	scheme, err := tblsv2.GetTSSScheme(tblsv2.DefaultTSSScheme)
+	require.NoError(t, err)
+
+	allShares, err := createShares(scheme, 2, 4, 3)
+	require.NoError(t, err)
+	shares := allShares[0]
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		err := writeOutputs(context.Background(), Config{DataDir: dir}, shares, lock, nil, eth2util.Goerli.Name, "")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"cluster-lock.json", "deposit-data.json", "validator_keys"}, dirEntries(t, dir))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel() // Abort after the keyshares are written.
+
+		dir := t.TempDir()
+		err := writeOutputs(ctx, Config{DataDir: dir}, shares, lock, nil, eth2util.Goerli.Name, "")
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, dirEntries(t, dir))
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		dir := t.TempDir()
+		// Existing deposit data directory fails the last write and must not be removed.
+		require.NoError(t, os.Mkdir(path.Join(dir, "deposit-data.json"), 0o755))
+
+		err := writeOutputs(context.Background(), Config{DataDir: dir}, shares, lock, nil, eth2util.Goerli.Name, "")
+		require.ErrorContains(t, err, "write deposit data")
+		require.Equal(t, []string{"deposit-data.json"}, dirEntries(t, dir))
+	})
+}
+
+func dirEntries(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var resp []string
+	for _, entry := range entries {
+		resp = append(resp, entry.Name())
+	}
+
+	return resp
+}
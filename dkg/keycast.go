@@ -79,8 +79,13 @@ func joinKeyCast(ctx context.Context, tp kcTransport, nodeIdx int) ([]share, err
 func leadKeyCast(ctx context.Context, tp kcTransport, def cluster.Definition) ([]share, error) {
 	numNodes := len(def.Operators)
 
+	scheme, err := tblsv2.GetTSSScheme(def.TSSScheme)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create shares for all nodes.
-	allShares, err := createShares(def.NumValidators, numNodes, def.Threshold)
+	allShares, err := createShares(scheme, def.NumValidators, numNodes, def.Threshold)
 	if err != nil {
 		return nil, err
 	}
@@ -142,8 +147,8 @@ func leadKeyCast(ctx context.Context, tp kcTransport, def cluster.Definition) ([
 	return resp, nil
 }
 
-// createShares returns a slice of shares to send to each node.
-func createShares(numValidators, numNodes, threshold int) ([][]share, error) {
+// createShares returns a slice of shares to send to each node split using the threshold secret sharing scheme.
+func createShares(scheme tblsv2.TSSScheme, numValidators, numNodes, threshold int) ([][]share, error) {
 	resp := make([][]share, numNodes)
 	for i := 0; i < numValidators; i++ {
 		rootSecret, err := tblsv2.GenerateSecretKey()
@@ -156,7 +161,7 @@ func createShares(numValidators, numNodes, threshold int) ([][]share, error) {
 			return nil, err
 		}
 
-		shares, err := tblsv2.ThresholdSplit(rootSecret, uint(numNodes), uint(threshold))
+		shares, err := scheme.Split(rootSecret, uint(numNodes), uint(threshold))
 		if err != nil {
 			return nil, err
 		}
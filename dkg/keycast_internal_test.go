@@ -6,6 +6,7 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/obolnetwork/charon/cluster"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 	"github.com/obolnetwork/charon/testutil"
 )
 
@@ -66,6 +68,79 @@ func TestKeyCastNoNetwork(t *testing.T) {
 	require.NoError(t, eg.Wait())
 }
 
+// countingTSSScheme is a threshold secret sharing scheme wrapping the default scheme counting splits.
+type countingTSSScheme struct {
+	tblsv2.TSSScheme
+	splits *atomic.Int64
+}
+
+func (countingTSSScheme) Name() string {
+	return "counting"
+}
+
+func (s countingTSSScheme) Split(secret tblsv2.PrivateKey, total uint, threshold uint) (map[int]tblsv2.PrivateKey, error) {
+	s.splits.Add(1)
+	return s.TSSScheme.Split(secret, total, threshold)
+}
+
+func TestKeyCastTSSScheme(t *testing.T) {
+	defaultScheme, err := tblsv2.GetTSSScheme(tblsv2.DefaultTSSScheme)
+	require.NoError(t, err)
+
+	scheme := countingTSSScheme{TSSScheme: defaultScheme, splits: new(atomic.Int64)}
+	tblsv2.RegisterTSSScheme(scheme)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		nodes = 3
+		vals  = 2
+	)
+
+	var ops []cluster.Operator
+	for i := 0; i < nodes; i++ {
+		_, r := testutil.RandomENR(t, i)
+		ops = append(ops, cluster.Operator{ENR: r.String()})
+	}
+
+	var addrs []string
+	for i := 0; i < vals; i++ {
+		addrs = append(addrs, testutil.RandomETHAddress())
+	}
+
+	def, err := cluster.NewDefinition("test def", vals, nodes, addrs, addrs, "", cluster.Creator{}, ops,
+		rand.New(rand.NewSource(0)), cluster.WithVersion("v1.6.0"), cluster.WithTSSScheme(scheme.Name()))
+	require.NoError(t, err)
+
+	require.ErrorContains(t, verifyTSSScheme(def), "tss scheme only supported by keycast dkg algorithm")
+	def.DKGAlgorithm = "keycast"
+	require.NoError(t, verifyTSSScheme(def))
+
+	tp := new(memTransport)
+
+	var eg errgroup.Group
+	for i := 0; i < nodes; i++ {
+		i := i // Copy loop variable.
+		eg.Go(func() error {
+			shares, err := runKeyCast(ctx, def, tp, i)
+			if err != nil {
+				cancel()
+				return err
+			}
+			require.Len(t, shares, vals)
+
+			return nil
+		})
+	}
+
+	require.NoError(t, eg.Wait())
+	require.EqualValues(t, vals, scheme.splits.Load())
+
+	def.TSSScheme = "unknown"
+	require.ErrorContains(t, verifyTSSScheme(def), "unknown tss scheme")
+}
+
 // memTransport is a very simple in-memory kcTransport for testing.
 // The dealers servFunc is called directly by participants.
 type memTransport struct {
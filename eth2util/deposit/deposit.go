@@ -4,6 +4,7 @@
 package deposit
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -18,10 +19,17 @@ import (
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 )
 
-var (
+const (
+	// ExecutionWithdrawalPrefix is the 0x01 execution address withdrawal credentials prefix.
 	// https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/validator.md#eth1_address_withdrawal_prefix
-	eth1AddressWithdrawalPrefix = []byte{0x01}
+	ExecutionWithdrawalPrefix byte = 0x01
+
+	// CompoundingWithdrawalPrefix is the 0x02 compounding execution address withdrawal credentials prefix.
+	// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/validator.md#compounding_withdrawal_prefix
+	CompoundingWithdrawalPrefix byte = 0x02
+)
 
+var (
 	// the amount of ether in gwei required to activate a validator.
 	validatorAmt = eth2p0.Gwei(32000000000)
 
@@ -31,9 +39,22 @@ var (
 	depositCliVersion = "2.3.0"
 )
 
+// depositDataSSZLen is the ssz length of a DepositData container: pubkey (48), withdrawal credentials (32),
+// amount (8) and signature (96).
+const depositDataSSZLen = 184
+
 // NewMessage returns a deposit message created using the provided parameters.
 func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string) (eth2p0.DepositMessage, error) {
-	creds, err := withdrawalCredsFromAddr(withdrawalAddr)
+	return NewMessageWithPrefix(pubkey, withdrawalAddr, ExecutionWithdrawalPrefix)
+}
+
+// NewMessageWithPrefix returns a deposit message with withdrawal credentials of the provided prefix type.
+func NewMessageWithPrefix(pubkey eth2p0.BLSPubKey, withdrawalAddr string, prefix byte) (eth2p0.DepositMessage, error) {
+	if prefix != ExecutionWithdrawalPrefix && prefix != CompoundingWithdrawalPrefix {
+		return eth2p0.DepositMessage{}, errors.New("unsupported withdrawal credentials prefix", z.Int("prefix", int(prefix)))
+	}
+
+	creds, err := withdrawalCredsFromAddr(withdrawalAddr, prefix)
 	if err != nil {
 		return eth2p0.DepositMessage{}, err
 	}
@@ -45,13 +66,38 @@ func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string) (eth2p0.DepositM
 	}, nil
 }
 
+// MarshalOption configures optional deposit data metadata.
+type MarshalOption func(*marshalOpts)
+
+// marshalOpts contains the optional deposit data metadata.
+type marshalOpts struct {
+	contractAddress string
+	chainID         int64
+}
+
+// WithDepositContract returns an option that includes the deposit contract address and chain ID
+// in the deposit data metadata. This is required for custom networks deploying their own deposit contract.
+func WithDepositContract(address string, chainID int64) MarshalOption {
+	return func(opts *marshalOpts) {
+		opts.contractAddress = address
+		opts.chainID = chainID
+	}
+}
+
 // MarshalDepositData serializes a list of deposit data into a single file.
-func MarshalDepositData(depositDatas []eth2p0.DepositData, network string) ([]byte, error) {
+// The output matches the staking-deposit-cli format, with each entry including the
+// network_name, fork_version and deposit_cli_version metadata of the provided network.
+func MarshalDepositData(depositDatas []eth2p0.DepositData, network string, opts ...MarshalOption) ([]byte, error) {
 	forkVersion, err := eth2util.NetworkToForkVersion(network)
 	if err != nil {
 		return nil, err
 	}
 
+	var o marshalOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var ddList []depositDataJSON
 	for _, depositData := range depositDatas {
 		msg := eth2p0.DepositMessage{
@@ -64,20 +110,10 @@ func MarshalDepositData(depositDatas []eth2p0.DepositData, network string) ([]by
 			return nil, err
 		}
 
-		// Verify deposit data signature
-		sigData, err := GetMessageSigningRoot(msg, network)
-		if err != nil {
+		if err := verifyDepositDataSig(depositData, network); err != nil {
 			return nil, err
 		}
 
-		blsSig := tblsv2.Signature(depositData.Signature)
-		blsPubkey := tblsv2.PublicKey(depositData.PublicKey)
-
-		err = tblsv2.Verify(blsPubkey, sigData[:], blsSig)
-		if err != nil {
-			return nil, errors.Wrap(err, "invalid deposit data signature")
-		}
-
 		dataRoot, err := depositData.HashTreeRoot()
 		if err != nil {
 			return nil, errors.Wrap(err, "deposit data hash root")
@@ -92,6 +128,8 @@ func MarshalDepositData(depositDatas []eth2p0.DepositData, network string) ([]by
 			DepositDataRoot:       fmt.Sprintf("%x", dataRoot),
 			ForkVersion:           strings.TrimPrefix(forkVersion, "0x"),
 			NetworkName:           network,
+			DepositContract:       o.contractAddress,
+			ChainID:               o.chainID,
 			DepositCliVersion:     depositCliVersion,
 		})
 	}
@@ -108,7 +146,74 @@ func MarshalDepositData(depositDatas []eth2p0.DepositData, network string) ([]by
 	return bytes, nil
 }
 
-// getDepositDomain returns the deposit signature domain.
+// MarshalDepositDataSSZ serializes a list of deposit data as the ssz encoding of a list of consensus spec
+// DepositData containers, sorted by public key as in MarshalDepositData.
+func MarshalDepositDataSSZ(depositDatas []eth2p0.DepositData, network string) ([]byte, error) {
+	sorted := append([]eth2p0.DepositData(nil), depositDatas...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].PublicKey[:], sorted[j].PublicKey[:]) < 0
+	})
+
+	var resp []byte
+	for _, depositData := range sorted {
+		if err := verifyDepositDataSig(depositData, network); err != nil {
+			return nil, err
+		}
+
+		var err error
+		resp, err = depositData.MarshalSSZTo(resp)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal deposit data ssz")
+		}
+	}
+
+	return resp, nil
+}
+
+// UnmarshalDepositDataSSZ returns the list of deposit data serialized by MarshalDepositDataSSZ.
+func UnmarshalDepositDataSSZ(b []byte) ([]eth2p0.DepositData, error) {
+	if len(b)%depositDataSSZLen != 0 {
+		return nil, errors.New("invalid deposit data ssz length", z.Int("length", len(b)))
+	}
+
+	var resp []eth2p0.DepositData
+	for i := 0; i < len(b); i += depositDataSSZLen {
+		var depositData eth2p0.DepositData
+		if err := depositData.UnmarshalSSZ(b[i : i+depositDataSSZLen]); err != nil {
+			return nil, errors.Wrap(err, "unmarshal deposit data ssz")
+		}
+		resp = append(resp, depositData)
+	}
+
+	return resp, nil
+}
+
+// verifyDepositDataSig returns an error if the deposit data signature is invalid.
+func verifyDepositDataSig(depositData eth2p0.DepositData, network string) error {
+	msg := eth2p0.DepositMessage{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+
+	sigData, err := GetMessageSigningRoot(msg, network)
+	if err != nil {
+		return err
+	}
+
+	blsSig := tblsv2.Signature(depositData.Signature)
+	blsPubkey := tblsv2.PublicKey(depositData.PublicKey)
+
+	if err := tblsv2.Verify(blsPubkey, sigData[:], blsSig); err != nil {
+		return errors.Wrap(err, "invalid deposit data signature")
+	}
+
+	return nil
+}
+
+// getDepositDomain returns the deposit signature domain of the network's genesis fork version.
+// As per the consensus spec, the deposit domain type and zero genesis validators root are the same for all networks
+// (incl. gnosis), since deposits are valid before genesis, so the domain only differs by genesis fork version.
 func getDepositDomain(forkVersion eth2p0.Version) (eth2p0.Domain, error) {
 	forkData := &eth2p0.ForkData{
 		CurrentVersion:        forkVersion,
@@ -154,8 +259,8 @@ func GetMessageSigningRoot(msg eth2p0.DepositMessage, network string) ([32]byte,
 	return resp, nil
 }
 
-// withdrawalCredsFromAddr returns the Withdrawal Credentials corresponding to a '0x01' Ethereum withdrawal address.
-func withdrawalCredsFromAddr(addr string) ([32]byte, error) {
+// withdrawalCredsFromAddr returns the Withdrawal Credentials of the prefix type corresponding to an Ethereum withdrawal address.
+func withdrawalCredsFromAddr(addr string, prefix byte) ([32]byte, error) {
 	// Check for validity of address.
 	if _, err := eth2util.ChecksumAddress(addr); err != nil {
 		return [32]byte{}, errors.Wrap(err, "invalid withdrawal address", z.Str("addr", addr))
@@ -167,8 +272,8 @@ func withdrawalCredsFromAddr(addr string) ([32]byte, error) {
 	}
 
 	var creds [32]byte
-	copy(creds[0:], eth1AddressWithdrawalPrefix) // Add 1 byte prefix.
-	copy(creds[12:], addrBytes)                  // Add 20 bytes of ethereum address suffix.
+	creds[0] = prefix           // Add 1 byte prefix.
+	copy(creds[12:], addrBytes) // Add 20 bytes of ethereum address suffix.
 
 	return creds, nil
 }
@@ -183,5 +288,7 @@ type depositDataJSON struct {
 	DepositDataRoot       string `json:"deposit_data_root"`
 	ForkVersion           string `json:"fork_version"`
 	NetworkName           string `json:"network_name"`
+	DepositContract       string `json:"deposit_contract_address,omitempty"`
+	ChainID               int64  `json:"chain_id,omitempty"`
 	DepositCliVersion     string `json:"deposit_cli_version"`
 }
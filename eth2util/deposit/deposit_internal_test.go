@@ -11,10 +11,14 @@ import (
 
 func TestWithdrawalCredentials(t *testing.T) {
 	expectedWithdrawalCreds := "010000000000000000000000c0404ed740a69d11201f5ed297c5732f562c6e4e"
-	creds, err := withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e")
+	creds, err := withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e", ExecutionWithdrawalPrefix)
 	require.NoError(t, err)
 
 	credsHex := hex.EncodeToString(creds[:])
 
 	require.Equal(t, expectedWithdrawalCreds, credsHex)
+
+	creds, err = withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e", CompoundingWithdrawalPrefix)
+	require.NoError(t, err)
+	require.Equal(t, "02"+expectedWithdrawalCreds[2:], hex.EncodeToString(creds[:]))
 }
@@ -4,6 +4,9 @@ package deposit_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -60,6 +63,201 @@ func TestMarshalDepositData(t *testing.T) {
 	require.NoError(t, err)
 
 	testutil.RequireGoldenBytes(t, actual)
+
+	t.Run("deposit contract", func(t *testing.T) {
+		const contract = "0x4242424242424242424242424242424242424242"
+
+		b, err := deposit.MarshalDepositData(datas, network, deposit.WithDepositContract(contract, 1337))
+		require.NoError(t, err)
+
+		var ddList []map[string]any
+		require.NoError(t, json.Unmarshal(b, &ddList))
+		require.Len(t, ddList, len(datas))
+		for _, dd := range ddList {
+			require.Equal(t, contract, dd["deposit_contract_address"])
+			require.EqualValues(t, 1337, dd["chain_id"])
+		}
+	})
+}
+
+func TestGnosisDepositDomain(t *testing.T) {
+	// Deposit domains are compute_domain(DOMAIN_DEPOSIT, GENESIS_FORK_VERSION, ZERO_HASH).
+	domains := map[string]string{
+		eth2util.Mainnet.Name: "0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		eth2util.Gnosis.Name:  "0x03000000f925ddc5e2ae63752ccc93b8e3ecbc14259c1b3447464e8d4940230c",
+	}
+
+	msg := eth2p0.DepositMessage{
+		PublicKey:             eth2p0.BLSPubKey(testutil.RandomBytes48()),
+		WithdrawalCredentials: testutil.RandomBytes32(),
+		Amount:                32000000000,
+	}
+
+	msgRoot, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+
+	roots := make(map[string][32]byte)
+	for network, domainHex := range domains {
+		b, err := hex.DecodeString(strings.TrimPrefix(domainHex, "0x"))
+		require.NoError(t, err)
+
+		var domain eth2p0.Domain
+		copy(domain[:], b)
+
+		expect, err := (&eth2p0.SigningData{ObjectRoot: msgRoot, Domain: domain}).HashTreeRoot()
+		require.NoError(t, err)
+
+		root, err := deposit.GetMessageSigningRoot(msg, network)
+		require.NoError(t, err)
+		require.Equal(t, expect, root, network)
+
+		roots[network] = root
+	}
+
+	require.NotEqual(t, roots[eth2util.Mainnet.Name], roots[eth2util.Gnosis.Name])
+}
+
+func TestDepositDataMetadata(t *testing.T) {
+	const (
+		privKey        = "01477d4bfbbcebe1fef8d4d6f624ecbb6e3178558bb1b0d6286c816c66842a6d"
+		withdrawalAddr = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+	)
+
+	tests := []struct {
+		Network     eth2util.Network
+		ForkVersion string
+	}{
+		{Network: eth2util.Goerli, ForkVersion: "00001020"},
+		{Network: eth2util.Mainnet, ForkVersion: "00000000"},
+	}
+
+	for _, test := range tests {
+		network := test.Network
+		t.Run(network.Name, func(t *testing.T) {
+			sk, pk := GetKeys(t, privKey)
+
+			msg, err := deposit.NewMessage(pk, withdrawalAddr)
+			require.NoError(t, err)
+
+			sigRoot, err := deposit.GetMessageSigningRoot(msg, network.Name)
+			require.NoError(t, err)
+
+			sig, err := tblsv2.Sign(sk, sigRoot[:])
+			require.NoError(t, err)
+
+			b, err := deposit.MarshalDepositData([]eth2p0.DepositData{{
+				PublicKey:             msg.PublicKey,
+				WithdrawalCredentials: msg.WithdrawalCredentials,
+				Amount:                msg.Amount,
+				Signature:             tblsconv2.SigToETH2(sig),
+			}}, network.Name)
+			require.NoError(t, err)
+
+			var ddList []map[string]any
+			require.NoError(t, json.Unmarshal(b, &ddList))
+			require.Len(t, ddList, 1)
+			require.Equal(t, network.Name, ddList[0]["network_name"])
+			require.Equal(t, test.ForkVersion, ddList[0]["fork_version"])
+			require.Equal(t, "2.3.0", ddList[0]["deposit_cli_version"])
+			require.NotContains(t, ddList[0], "deposit_contract_address")
+			require.NotContains(t, ddList[0], "chain_id")
+		})
+	}
+}
+
+func TestMarshalDepositDataSSZ(t *testing.T) {
+	privKeys := []string{
+		"01477d4bfbbcebe1fef8d4d6f624ecbb6e3178558bb1b0d6286c816c66842a6d",
+		"5b77c0f0ef7c4ddc123d55b8bd93daeefbd7116764a941c0061a496649e145b5",
+		"1dabcbfc9258f0f28606bf9e3b1c9f06d15a6e4eb0fbc28a43835eaaed7623fc",
+	}
+	const withdrawalAddr = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+
+	var (
+		datas   []eth2p0.DepositData
+		network = eth2util.Goerli
+	)
+	for _, privKey := range privKeys {
+		sk, pk := GetKeys(t, privKey)
+
+		msg, err := deposit.NewMessage(pk, withdrawalAddr)
+		require.NoError(t, err)
+
+		sigRoot, err := deposit.GetMessageSigningRoot(msg, network.Name)
+		require.NoError(t, err)
+
+		sig, err := tblsv2.Sign(sk, sigRoot[:])
+		require.NoError(t, err)
+
+		datas = append(datas, eth2p0.DepositData{
+			PublicKey:             msg.PublicKey,
+			WithdrawalCredentials: msg.WithdrawalCredentials,
+			Amount:                msg.Amount,
+			Signature:             tblsconv2.SigToETH2(sig),
+		})
+	}
+
+	b, err := deposit.MarshalDepositDataSSZ(datas, network.Name)
+	require.NoError(t, err)
+	require.Len(t, b, len(datas)*184)
+
+	decoded, err := deposit.UnmarshalDepositDataSSZ(b)
+	require.NoError(t, err)
+	require.ElementsMatch(t, datas, decoded)
+
+	jsonBytes, err := deposit.MarshalDepositData(datas, network.Name)
+	require.NoError(t, err)
+
+	var ddList []map[string]any
+	require.NoError(t, json.Unmarshal(jsonBytes, &ddList))
+	require.Len(t, ddList, len(decoded))
+
+	// Deposit domain with zero genesis validators root, see consensus spec compute_domain.
+	var forkVersion eth2p0.Version
+	fv, err := hex.DecodeString(strings.TrimPrefix(network.ForkVersionHex, "0x"))
+	require.NoError(t, err)
+	copy(forkVersion[:], fv)
+	forkRoot, err := (&eth2p0.ForkData{CurrentVersion: forkVersion}).HashTreeRoot()
+	require.NoError(t, err)
+	var domain eth2p0.Domain
+	copy(domain[:], []byte{0x03, 0x00, 0x00, 0x00})
+	copy(domain[4:], forkRoot[:])
+
+	for i, depositData := range decoded {
+		msg := eth2p0.DepositMessage{
+			PublicKey:             depositData.PublicKey,
+			WithdrawalCredentials: depositData.WithdrawalCredentials,
+			Amount:                depositData.Amount,
+		}
+		msgRoot, err := msg.HashTreeRoot()
+		require.NoError(t, err)
+
+		// The signing root of the ssz message root equals the deposit signing root.
+		sigRoot, err := (&eth2p0.SigningData{ObjectRoot: msgRoot, Domain: domain}).HashTreeRoot()
+		require.NoError(t, err)
+		expected, err := deposit.GetMessageSigningRoot(msg, network.Name)
+		require.NoError(t, err)
+		require.Equal(t, expected, sigRoot)
+
+		// The ssz roots match the json roots, both sorted by pubkey.
+		dataRoot, err := depositData.HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%x", depositData.PublicKey), ddList[i]["pubkey"])
+		require.Equal(t, fmt.Sprintf("%x", msgRoot), ddList[i]["deposit_message_root"])
+		require.Equal(t, fmt.Sprintf("%x", dataRoot), ddList[i]["deposit_data_root"])
+	}
+
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := deposit.UnmarshalDepositDataSSZ(b[:len(b)-1])
+		require.ErrorContains(t, err, "invalid deposit data ssz length")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		invalid := append([]eth2p0.DepositData(nil), datas...)
+		invalid[0].Amount++
+		_, err := deposit.MarshalDepositDataSSZ(invalid, network.Name)
+		require.ErrorContains(t, err, "invalid deposit data signature")
+	})
 }
 
 // Get the private and public keys in appropriate format for the test.
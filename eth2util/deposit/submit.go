@@ -0,0 +1,468 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package deposit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+// logsPageSize is the maximum number of blocks queried per eth_getLogs request,
+// since execution clients limit the range and response size of log queries.
+const logsPageSize = 10000
+
+// depositContractABI is the ABI of the deposit contract's deposit function and DepositEvent log.
+const depositContractABI = `[
+	{"type":"function","name":"deposit","stateMutability":"payable","outputs":[],"inputs":[
+		{"name":"pubkey","type":"bytes"},{"name":"withdrawal_credentials","type":"bytes"},
+		{"name":"signature","type":"bytes"},{"name":"deposit_data_root","type":"bytes32"}]},
+	{"type":"event","name":"DepositEvent","anonymous":false,"inputs":[
+		{"name":"pubkey","type":"bytes","indexed":false},{"name":"withdrawal_credentials","type":"bytes","indexed":false},
+		{"name":"amount","type":"bytes","indexed":false},{"name":"signature","type":"bytes","indexed":false},
+		{"name":"index","type":"bytes","indexed":false}]}
+]`
+
+var (
+	// depositContracts maps network names to their official deposit contract addresses.
+	// Gnosis isn't supported since its deposit contract requires GNO tokens instead of ether.
+	depositContracts = map[string]string{
+		eth2util.Mainnet.Name: "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		eth2util.Goerli.Name:  "0xff50ed3d0ec03aC01D4C79aAd74928BFF48a7b2b",
+		eth2util.Sepolia.Name: "0x7f02C3E3c98b133055B8B348B2Ac625669Ed295D",
+		eth2util.Ropsten.Name: "0x6f22fFbC56eFF051aECF839396DD1eD9aD6BBA9D",
+		eth2util.Holesky.Name: "0x4242424242424242424242424242424242424242",
+	}
+
+	// depositContractBlocks maps network names to the deployment blocks of their official deposit contracts.
+	depositContractBlocks = map[string]uint64{
+		eth2util.Mainnet.Name: 11052984,
+		eth2util.Goerli.Name:  4367322,
+		eth2util.Sepolia.Name: 1273020,
+		eth2util.Ropsten.Name: 12269949,
+		eth2util.Holesky.Name: 0,
+	}
+)
+
+// SubmitConfig configures the submission of deposits to the deposit contract.
+type SubmitConfig struct {
+	// Endpoint is the execution layer JSON-RPC endpoint.
+	Endpoint string
+	// PrivKey is the private key of the account funding the deposits.
+	PrivKey *ecdsa.PrivateKey
+	// FromBlock is the block from which to search for existing deposits.
+	// Zero defaults to the deposit contract deployment block of known networks.
+	FromBlock uint64
+	// DryRun logs the deposits that would be submitted without submitting them.
+	DryRun bool
+	// ConfirmTimeout is the maximum time to wait for each deposit transaction to be included.
+	ConfirmTimeout time.Duration
+	// PollInterval is the interval between deposit transaction receipt requests.
+	PollInterval time.Duration
+}
+
+// SubmitResult is the result of submitting a single deposit.
+type SubmitResult struct {
+	// PubKey is the 0x prefixed hex validator public key.
+	PubKey string
+	// TxHash is the 0x prefixed hex deposit transaction hash, empty if skipped or dry-run.
+	TxHash string
+	// Skipped is true if the public key was already deposited.
+	Skipped bool
+}
+
+// submitDeposit is a deposit data entry ready for submission.
+type submitDeposit struct {
+	Data eth2p0.DepositData
+	Root eth2p0.Root
+}
+
+// submitBackend is the subset of the go-ethereum execution layer client used to submit deposits.
+type submitBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Submit submits the deposits in the deposit data file contents to the deposit contract.
+// Deposits of public keys already deposited to the contract are skipped. Each deposit transaction
+// is submitted only after the previous one was included successfully. It refuses to submit any deposits
+// while transactions of the funding account are unconfirmed, since these may be deposits of a previous run.
+func Submit(ctx context.Context, data []byte, conf SubmitConfig) ([]SubmitResult, error) {
+	cl, err := ethclient.DialContext(ctx, conf.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial execution endpoint")
+	}
+	defer cl.Close()
+
+	return submit(ctx, cl, data, conf)
+}
+
+// submit submits the deposits using the provided execution layer backend, see Submit.
+func submit(ctx context.Context, cl submitBackend, data []byte, conf SubmitConfig) ([]SubmitResult, error) {
+	deposits, contractHex, chainID, err := parseDepositData(data)
+	if err != nil {
+		return nil, err
+	} else if !common.IsHexAddress(contractHex) {
+		return nil, errors.New("invalid deposit contract address", z.Str("address", contractHex))
+	}
+	contract := common.HexToAddress(contractHex)
+
+	contractABI, err := abi.JSON(strings.NewReader(depositContractABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse deposit contract abi")
+	}
+
+	actualChainID, err := cl.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get chain id")
+	} else if actualChainID.Cmp(big.NewInt(chainID)) != 0 {
+		return nil, errors.New("execution endpoint chain ID mismatch",
+			z.I64("expected", chainID), z.Str("actual", actualChainID.String()))
+	}
+
+	from := crypto.PubkeyToAddress(conf.PrivKey.PublicKey)
+
+	// Unconfirmed transactions are checked before querying deposits, so deposits of transactions
+	// included in the meantime are either returned by the query or were still unconfirmed.
+	nonce, err := confirmedNonce(ctx, cl, from)
+	if err != nil {
+		return nil, err
+	}
+
+	fromBlock := conf.FromBlock
+	if fromBlock == 0 {
+		fromBlock = depositContractBlock(contractHex)
+	}
+
+	deposited, err := depositedPubKeys(ctx, cl, contractABI, contract, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SubmitResult
+	for _, d := range deposits {
+		pubkey := fmt.Sprintf("%#x", d.Data.PublicKey[:])
+		ctx := log.WithCtx(ctx, z.Str("pubkey", pubkey))
+
+		if deposited[d.Data.PublicKey] {
+			log.Info(ctx, "Skipping already deposited validator")
+			results = append(results, SubmitResult{PubKey: pubkey, Skipped: true})
+
+			continue
+		}
+
+		value := new(big.Int).Mul(new(big.Int).SetUint64(uint64(d.Data.Amount)), big.NewInt(1e9)) // Gwei to wei.
+
+		if conf.DryRun {
+			log.Info(ctx, "Dry-run, not submitting deposit", z.Str("contract", contract.Hex()),
+				z.Str("from", from.Hex()), z.Str("wei", value.String()))
+			results = append(results, SubmitResult{PubKey: pubkey})
+
+			continue
+		}
+
+		callData, err := contractABI.Pack("deposit", d.Data.PublicKey[:], d.Data.WithdrawalCredentials, d.Data.Signature[:], [32]byte(d.Root))
+		if err != nil {
+			return nil, errors.Wrap(err, "pack deposit call data")
+		}
+
+		tx, err := newDepositTx(ctx, cl, actualChainID, nonce, from, contract, value, callData)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err = types.SignTx(tx, types.LatestSignerForChainID(actualChainID), conf.PrivKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "sign deposit transaction")
+		}
+
+		if err := cl.SendTransaction(ctx, tx); err != nil {
+			return nil, errors.Wrap(err, "send deposit transaction")
+		}
+
+		txHash := tx.Hash().Hex()
+		log.Info(ctx, "Submitted deposit transaction, awaiting confirmation", z.Str("tx_hash", txHash), z.U64("nonce", nonce))
+
+		if err := awaitReceipt(ctx, cl, tx.Hash(), conf.ConfirmTimeout, conf.PollInterval); err != nil {
+			return nil, errors.Wrap(err, "await deposit transaction, don't resubmit before it is included or dropped",
+				z.Str("tx_hash", txHash), z.U64("nonce", nonce))
+		}
+
+		log.Info(ctx, "Deposit transaction confirmed", z.Str("tx_hash", txHash))
+
+		results = append(results, SubmitResult{PubKey: pubkey, TxHash: txHash})
+		nonce++
+	}
+
+	return results, nil
+}
+
+// confirmedNonce returns the confirmed nonce of the account or an error if the account has unconfirmed transactions.
+func confirmedNonce(ctx context.Context, cl submitBackend, account common.Address) (uint64, error) {
+	pending, err := cl.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, errors.Wrap(err, "get pending nonce")
+	}
+
+	confirmed, err := cl.NonceAt(ctx, account, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "get confirmed nonce")
+	}
+
+	if pending > confirmed {
+		return 0, errors.New("funding account has unconfirmed transactions, await their inclusion before resubmitting deposits",
+			z.Str("account", account.Hex()), z.U64("confirmed_nonce", confirmed), z.U64("pending_nonce", pending))
+	}
+
+	return confirmed, nil
+}
+
+// newDepositTx returns a new unsigned EIP-1559 deposit transaction with the current suggested fees and estimated gas.
+func newDepositTx(ctx context.Context, cl submitBackend, chainID *big.Int, nonce uint64,
+	from common.Address, contract common.Address, value *big.Int, callData []byte,
+) (*types.Transaction, error) {
+	head, err := cl.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest header")
+	} else if head.BaseFee == nil {
+		return nil, errors.New("execution endpoint doesn't support EIP-1559 transactions")
+	}
+
+	tipCap, err := cl.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "suggest gas tip cap")
+	}
+
+	gas, err := cl.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &contract, Value: value, Data: callData})
+	if err != nil {
+		return nil, errors.Wrap(err, "estimate deposit gas")
+	}
+
+	// Allow the base fee to double before the transaction is included.
+	feeCap := new(big.Int).Add(tipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        &contract,
+		Value:     value,
+		Data:      callData,
+	}), nil
+}
+
+// parseDepositData returns the verified deposits, the deposit contract address and the chain ID
+// of the deposit data file contents.
+func parseDepositData(data []byte) ([]submitDeposit, string, int64, error) {
+	var ddList []depositDataJSON
+	if err := json.Unmarshal(data, &ddList); err != nil {
+		return nil, "", 0, errors.Wrap(err, "unmarshal deposit data")
+	} else if len(ddList) == 0 {
+		return nil, "", 0, errors.New("empty deposit data")
+	}
+
+	var (
+		network  = ddList[0].NetworkName
+		contract = ddList[0].DepositContract
+		chainID  = ddList[0].ChainID
+		err      error
+	)
+	if contract == "" {
+		var ok bool
+		contract, ok = depositContracts[network]
+		if !ok {
+			return nil, "", 0, errors.New("unknown deposit contract for network", z.Str("network", network))
+		}
+	}
+	if chainID == 0 {
+		chainID, err = eth2util.NetworkToChainID(network)
+		if err != nil {
+			return nil, "", 0, err
+		}
+	}
+
+	var resp []submitDeposit
+	for _, dd := range ddList {
+		if dd.NetworkName != network || dd.DepositContract != ddList[0].DepositContract || dd.ChainID != ddList[0].ChainID {
+			return nil, "", 0, errors.New("mismatching deposit data networks", z.Str("pubkey", dd.PubKey))
+		}
+
+		d, err := verifyDepositData(dd)
+		if err != nil {
+			return nil, "", 0, err
+		}
+
+		resp = append(resp, d)
+	}
+
+	return resp, contract, chainID, nil
+}
+
+// verifyDepositData returns the deposit after verifying its signature and deposit data root.
+func verifyDepositData(dd depositDataJSON) (submitDeposit, error) {
+	var (
+		data eth2p0.DepositData
+		root eth2p0.Root
+	)
+
+	for _, field := range []struct {
+		Name   string
+		Hex    string
+		Target []byte
+	}{
+		{Name: "pubkey", Hex: dd.PubKey, Target: data.PublicKey[:]},
+		{Name: "signature", Hex: dd.Signature, Target: data.Signature[:]},
+		{Name: "deposit_data_root", Hex: dd.DepositDataRoot, Target: root[:]},
+	} {
+		b, err := hex.DecodeString(strings.TrimPrefix(field.Hex, "0x"))
+		if err != nil || len(b) != len(field.Target) {
+			return submitDeposit{}, errors.New("invalid deposit data field", z.Str("field", field.Name), z.Str("pubkey", dd.PubKey))
+		}
+		copy(field.Target, b)
+	}
+
+	creds, err := hex.DecodeString(strings.TrimPrefix(dd.WithdrawalCredentials, "0x"))
+	if err != nil || len(creds) != 32 {
+		return submitDeposit{}, errors.New("invalid deposit data field", z.Str("field", "withdrawal_credentials"), z.Str("pubkey", dd.PubKey))
+	}
+	data.WithdrawalCredentials = creds
+	data.Amount = eth2p0.Gwei(dd.Amount)
+
+	actualRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return submitDeposit{}, errors.Wrap(err, "deposit data hash root")
+	} else if actualRoot != root {
+		return submitDeposit{}, errors.New("deposit data root mismatch", z.Str("pubkey", dd.PubKey))
+	}
+
+	sigRoot, err := GetMessageSigningRoot(eth2p0.DepositMessage{
+		PublicKey:             data.PublicKey,
+		WithdrawalCredentials: data.WithdrawalCredentials,
+		Amount:                data.Amount,
+	}, dd.NetworkName)
+	if err != nil {
+		return submitDeposit{}, err
+	}
+
+	err = tblsv2.Verify(tblsv2.PublicKey(data.PublicKey), sigRoot[:], tblsv2.Signature(data.Signature))
+	if err != nil {
+		return submitDeposit{}, errors.Wrap(err, "invalid deposit data signature", z.Str("pubkey", dd.PubKey))
+	}
+
+	return submitDeposit{Data: data, Root: root}, nil
+}
+
+// depositContractBlock returns the deployment block of the official deposit contract address or zero if unknown.
+func depositContractBlock(contract string) uint64 {
+	for network, address := range depositContracts {
+		if strings.EqualFold(address, contract) {
+			return depositContractBlocks[network]
+		}
+	}
+
+	return 0
+}
+
+// depositedPubKeys returns the validator public keys of all deposit contract DepositEvent logs since fromBlock.
+// The logs are queried in pages of logsPageSize blocks up to the latest block.
+func depositedPubKeys(ctx context.Context, cl submitBackend, contractABI abi.ABI, contract common.Address,
+	fromBlock uint64,
+) (map[eth2p0.BLSPubKey]bool, error) {
+	latest, err := cl.BlockNumber(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest block number")
+	}
+
+	event := contractABI.Events["DepositEvent"]
+
+	resp := make(map[eth2p0.BLSPubKey]bool)
+	for start := fromBlock; start <= latest; start += logsPageSize {
+		end := start + logsPageSize - 1
+		if end > latest {
+			end = latest
+		}
+
+		logs, err := cl.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{contract},
+			Topics:    [][]common.Hash{{event.ID}},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "get deposit logs", z.U64("from", start), z.U64("to", end))
+		}
+
+		for _, l := range logs {
+			values, err := event.Inputs.Unpack(l.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, "unpack deposit log")
+			}
+
+			pubkey, ok := values[0].([]byte)
+			if !ok || len(pubkey) != len(eth2p0.BLSPubKey{}) {
+				return nil, errors.New("invalid deposit log pubkey")
+			}
+
+			resp[eth2p0.BLSPubKey(pubkey)] = true
+		}
+	}
+
+	return resp, nil
+}
+
+// awaitReceipt blocks until the transaction receipt is available and returns an error if the transaction failed.
+func awaitReceipt(ctx context.Context, cl submitBackend, txHash common.Hash, timeout, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := cl.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return errors.New("deposit transaction failed", z.U64("status", receipt.Status))
+			}
+
+			return nil
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return errors.Wrap(err, "get deposit transaction receipt")
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "timeout awaiting deposit transaction receipt")
+		case <-ticker.C:
+		}
+	}
+}
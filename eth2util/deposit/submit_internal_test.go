@@ -0,0 +1,356 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package deposit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util"
+	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
+	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
+)
+
+func TestSubmit(t *testing.T) {
+	const (
+		numDeposits = 3
+		withdrawal  = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+	)
+
+	network := eth2util.Goerli
+
+	var datas []eth2p0.DepositData
+	for i := 0; i < numDeposits; i++ {
+		datas = append(datas, newDepositData(t, network.Name, withdrawal))
+	}
+
+	depositJSON, err := MarshalDepositData(datas, network.Name)
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// The first validator was already deposited in the latest block.
+	deployBlock := depositContractBlocks[network.Name]
+	latest := deployBlock + 2*logsPageSize + 5
+	el := newSimulatedEL(t, network.ChainID, latest, datas[0].PublicKey)
+
+	conf := SubmitConfig{
+		PrivKey:        key,
+		ConfirmTimeout: time.Second,
+		PollInterval:   time.Millisecond,
+	}
+
+	t.Run("dry-run", func(t *testing.T) {
+		conf := conf
+		conf.DryRun = true
+
+		results, err := submit(context.Background(), el, depositJSON, conf)
+		require.NoError(t, err)
+		require.Len(t, results, numDeposits)
+		require.Empty(t, el.Txs())
+	})
+
+	results, err := submit(context.Background(), el, depositJSON, conf)
+	require.NoError(t, err)
+	require.Len(t, results, numDeposits)
+
+	txs := el.Txs()
+	require.Len(t, txs, numDeposits-1) // One transaction per non-deposited entry.
+
+	wantValue := new(big.Int).Mul(big.NewInt(32e9), big.NewInt(1e9))
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	var submitted []string
+	for i, tx := range txs {
+		require.EqualValues(t, types.DynamicFeeTxType, tx.Type())
+		require.EqualValues(t, i, tx.Nonce())
+		require.Equal(t, common.HexToAddress(depositContracts[network.Name]), *tx.To())
+		require.Equal(t, wantValue, tx.Value())
+
+		sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(network.ChainID)), tx)
+		require.NoError(t, err)
+		require.Equal(t, from, sender)
+
+		submitted = append(submitted, fmt.Sprintf("%#x", unpackDepositPubKey(t, tx.Data())))
+	}
+
+	for i, res := range results {
+		if res.Skipped {
+			require.Equal(t, fmt.Sprintf("%#x", datas[0].PublicKey[:]), res.PubKey)
+			continue
+		}
+		require.NotEmpty(t, res.TxHash)
+		require.Contains(t, submitted, res.PubKey, i)
+	}
+	require.NotContains(t, submitted, fmt.Sprintf("%#x", datas[0].PublicKey[:]))
+
+	// The logs are queried in pages from the deposit contract deployment block.
+	ranges := el.LogRanges()
+	require.Equal(t, [2]uint64{deployBlock, deployBlock + logsPageSize - 1}, ranges[0])
+	require.Equal(t, [2]uint64{deployBlock + 2*logsPageSize, latest}, ranges[2])
+
+	t.Run("chain id mismatch", func(t *testing.T) {
+		el := newSimulatedEL(t, 1, 0)
+
+		_, err := submit(context.Background(), el, depositJSON, conf)
+		require.ErrorContains(t, err, "chain ID mismatch")
+	})
+
+	t.Run("unconfirmed", func(t *testing.T) {
+		el := newSimulatedEL(t, network.ChainID, latest)
+		el.SetMining(false)
+
+		conf := conf
+		conf.ConfirmTimeout = 10 * time.Millisecond
+
+		_, err := submit(context.Background(), el, depositJSON, conf)
+		require.ErrorContains(t, err, "timeout awaiting deposit transaction receipt")
+		require.Len(t, el.Txs(), 1)
+
+		// Deposits aren't resubmitted while the previous transaction is pending.
+		_, err = submit(context.Background(), el, depositJSON, conf)
+		require.ErrorContains(t, err, "funding account has unconfirmed transactions")
+		require.Len(t, el.Txs(), 1)
+
+		// Once included, the previous deposit is skipped.
+		el.SetMining(true)
+
+		results, err := submit(context.Background(), el, depositJSON, conf)
+		require.NoError(t, err)
+		require.True(t, results[0].Skipped)
+		require.Len(t, el.Txs(), numDeposits)
+
+		// Each validator is deposited exactly once.
+		pubkeys := make(map[string]bool)
+		for i, tx := range el.Txs() {
+			require.EqualValues(t, i, tx.Nonce())
+			pubkeys[fmt.Sprintf("%#x", unpackDepositPubKey(t, tx.Data()))] = true
+		}
+		require.Len(t, pubkeys, numDeposits)
+	})
+}
+
+// unpackDepositPubKey returns the validator public key of the deposit contract call data.
+func unpackDepositPubKey(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	contractABI, err := abi.JSON(strings.NewReader(depositContractABI))
+	require.NoError(t, err)
+
+	method, err := contractABI.MethodById(data)
+	require.NoError(t, err)
+	require.Equal(t, "deposit", method.Name)
+
+	values, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+
+	pubkey, ok := values[0].([]byte)
+	require.True(t, ok)
+
+	return pubkey
+}
+
+// newDepositData returns a new random signed deposit data.
+func newDepositData(t *testing.T, network string, withdrawalAddr string) eth2p0.DepositData {
+	t.Helper()
+
+	secret, err := tblsv2.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pk, err := tblsv2.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	pubkey, err := tblsconv2.PubkeyToETH2(pk)
+	require.NoError(t, err)
+
+	msg, err := NewMessage(pubkey, withdrawalAddr)
+	require.NoError(t, err)
+
+	sigRoot, err := GetMessageSigningRoot(msg, network)
+	require.NoError(t, err)
+
+	sig, err := tblsv2.Sign(secret, sigRoot[:])
+	require.NoError(t, err)
+
+	return eth2p0.DepositData{
+		PublicKey:             msg.PublicKey,
+		WithdrawalCredentials: msg.WithdrawalCredentials,
+		Amount:                msg.Amount,
+		Signature:             tblsconv2.SigToETH2(sig),
+	}
+}
+
+// simulatedEL is a simulated execution layer backend that records transactions.
+// Transactions are included immediately when mining, emitting deposit logs in the latest block.
+type simulatedEL struct {
+	t       *testing.T
+	chainID int64
+	latest  uint64
+	event   abi.Event
+
+	mu        sync.Mutex
+	mining    bool
+	txs       []*types.Transaction
+	pending   []*types.Transaction
+	included  map[common.Hash]bool
+	deposited [][]byte
+	ranges    [][2]uint64
+}
+
+// newSimulatedEL returns a simulated execution layer with the provided chain ID, latest block
+// and existing deposits included in the latest block.
+func newSimulatedEL(t *testing.T, chainID int64, latest uint64, deposited ...eth2p0.BLSPubKey) *simulatedEL {
+	t.Helper()
+
+	contractABI, err := abi.JSON(strings.NewReader(depositContractABI))
+	require.NoError(t, err)
+
+	el := &simulatedEL{
+		t:        t,
+		chainID:  chainID,
+		latest:   latest,
+		event:    contractABI.Events["DepositEvent"],
+		mining:   true,
+		included: make(map[common.Hash]bool),
+	}
+	for _, pubkey := range deposited {
+		el.deposited = append(el.deposited, append([]byte(nil), pubkey[:]...))
+	}
+
+	return el
+}
+
+// SetMining enables or disables the inclusion of transactions, including pending transactions when enabled.
+func (s *simulatedEL) SetMining(mining bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mining = mining
+	if mining {
+		for _, tx := range s.pending {
+			s.include(tx)
+		}
+		s.pending = nil
+	}
+}
+
+// include includes the deposit transaction. It must be called with the lock held.
+func (s *simulatedEL) include(tx *types.Transaction) {
+	s.included[tx.Hash()] = true
+	s.deposited = append(s.deposited, unpackDepositPubKey(s.t, tx.Data()))
+}
+
+func (s *simulatedEL) Txs() []*types.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.txs
+}
+
+// LogRanges returns the block ranges of the log queries.
+func (s *simulatedEL) LogRanges() [][2]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ranges
+}
+
+func (s *simulatedEL) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(s.chainID), nil
+}
+
+func (s *simulatedEL) BlockNumber(context.Context) (uint64, error) {
+	return s.latest, nil
+}
+
+func (s *simulatedEL) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	require.LessOrEqual(s.t, q.ToBlock.Uint64()-q.FromBlock.Uint64(), uint64(logsPageSize))
+	require.Equal(s.t, s.event.ID, q.Topics[0][0])
+	s.ranges = append(s.ranges, [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()})
+
+	if q.ToBlock.Uint64() < s.latest {
+		return nil, nil
+	}
+
+	var logs []types.Log
+	for _, pubkey := range s.deposited {
+		data, err := s.event.Inputs.Pack(pubkey, make([]byte, 32), make([]byte, 8), make([]byte, 96), make([]byte, 8))
+		require.NoError(s.t, err)
+
+		logs = append(logs, types.Log{Address: q.Addresses[0], Topics: []common.Hash{s.event.ID}, Data: data})
+	}
+
+	return logs, nil
+}
+
+func (s *simulatedEL) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return uint64(len(s.txs) - len(s.pending)), nil
+}
+
+func (s *simulatedEL) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return uint64(len(s.txs)), nil
+}
+
+func (s *simulatedEL) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(1e9)}, nil
+}
+
+func (s *simulatedEL) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1e8), nil
+}
+
+func (s *simulatedEL) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 60000, nil
+}
+
+func (s *simulatedEL) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	require.EqualValues(s.t, len(s.txs), tx.Nonce())
+	require.Equal(s.t, big.NewInt(1e8), tx.GasTipCap())
+	require.Equal(s.t, big.NewInt(21e8), tx.GasFeeCap())
+
+	s.txs = append(s.txs, tx)
+	if s.mining {
+		s.include(tx)
+	} else {
+		s.pending = append(s.pending, tx)
+	}
+
+	return nil
+}
+
+func (s *simulatedEL) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.included[txHash] {
+		return nil, ethereum.NotFound
+	}
+
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash}, nil
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eip3076_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+)
+
+func TestNewBaseline(t *testing.T) {
+	genesis, root, err := eth2util.NetworkToGenesis(eth2util.Goerli.Name)
+	require.NoError(t, err)
+
+	pubkeys := [][]byte{{0x01, 0x02}, {0x03, 0x04}}
+
+	// 100 slots and 6 seconds after genesis is in slot 100 of epoch 3.
+	now := genesis.Add(100*eth2util.Goerli.SlotDuration + 6*time.Second)
+
+	interchange, err := eip3076.NewBaseline(eth2util.Goerli.Name, pubkeys, now)
+	require.NoError(t, err)
+	require.Equal(t, eip3076.Interchange{
+		Metadata: eip3076.Metadata{
+			InterchangeFormatVersion: "5",
+			GenesisValidatorsRoot:    root,
+		},
+		Data: []eip3076.Validator{
+			{
+				PubKey:             "0x0102",
+				SignedBlocks:       []eip3076.Block{{Slot: "100"}},
+				SignedAttestations: []eip3076.Attestation{{SourceEpoch: "3", TargetEpoch: "3"}},
+			},
+			{
+				PubKey:             "0x0304",
+				SignedBlocks:       []eip3076.Block{{Slot: "100"}},
+				SignedAttestations: []eip3076.Attestation{{SourceEpoch: "3", TargetEpoch: "3"}},
+			},
+		},
+	}, interchange)
+
+	t.Run("before genesis", func(t *testing.T) {
+		interchange, err := eip3076.NewBaseline(eth2util.Goerli.Name, pubkeys, genesis.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, "0", interchange.Data[0].SignedBlocks[0].Slot)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		_, err := eip3076.NewBaseline("unknown", pubkeys, now)
+		require.Error(t, err)
+	})
+}
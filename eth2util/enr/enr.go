@@ -63,6 +63,7 @@ func Parse(enrStr string) (Record, error) {
 
 	r := Record{
 		Signature: elements[0],
+		seq:       fromBigEndian(elements[1]),
 		kvs:       make(map[string][]byte),
 	}
 
@@ -119,6 +120,11 @@ func WithUDP(port int) Option {
 
 // New returns a new enr record for the given private key and provided options.
 func New(privkey *k1.PrivateKey, opts ...Option) (Record, error) {
+	return NewWithSeq(privkey, 0, opts...)
+}
+
+// NewWithSeq returns a new enr record with the provided sequence number for the given private key and provided options.
+func NewWithSeq(privkey *k1.PrivateKey, seq int, opts ...Option) (Record, error) {
 	kvs := map[string][]byte{
 		keyID:        []byte(valID),
 		keySecp256k1: privkey.PubKey().SerializeCompressed(),
@@ -128,7 +134,7 @@ func New(privkey *k1.PrivateKey, opts ...Option) (Record, error) {
 		opt(kvs)
 	}
 
-	sig, err := sign(privkey, kvs)
+	sig, err := sign(privkey, seq, kvs)
 	if err != nil {
 		return Record{}, err
 	}
@@ -136,6 +142,7 @@ func New(privkey *k1.PrivateKey, opts ...Option) (Record, error) {
 	return Record{
 		PubKey:    privkey.PubKey(),
 		Signature: sig,
+		seq:       seq,
 		kvs:       kvs,
 	}, nil
 }
@@ -147,9 +154,15 @@ type Record struct {
 	// Signature of the record.
 	Signature []byte
 
+	seq int
 	kvs map[string][]byte
 }
 
+// Seq returns the sequence number of the record.
+func (r Record) Seq() int {
+	return r.seq
+}
+
 // IP returns the IP address of the record or false if not present.
 func (r Record) IP() (net.IP, bool) {
 	ip, ok := r.kvs[keyIP]
@@ -170,18 +183,18 @@ func (r Record) UDP() (int, bool) {
 
 // String returns the base64 encoded string representation of the record.
 func (r Record) String() string {
-	return "enr:" + base64.RawURLEncoding.EncodeToString(encodeElements(r.Signature, r.kvs))
+	return "enr:" + base64.RawURLEncoding.EncodeToString(encodeElements(r.Signature, r.seq, r.kvs))
 }
 
 // encodeElements return the RLP encoding of a minimal set of record elements including optional signature.
-func encodeElements(signature []byte, kvs map[string][]byte) []byte {
+func encodeElements(signature []byte, seq int, kvs map[string][]byte) []byte {
 	var keys []string
 	for k := range kvs {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	elements := [][]byte{toBigEndian(0)} // Sequence number=0
+	elements := [][]byte{toBigEndian(seq)}
 	for _, key := range keys {
 		elements = append(elements, []byte(key), kvs[key])
 	}
@@ -194,9 +207,9 @@ func encodeElements(signature []byte, kvs map[string][]byte) []byte {
 }
 
 // sign returns a enr record signature.
-func sign(privkey *k1.PrivateKey, kvs map[string][]byte) ([]byte, error) {
+func sign(privkey *k1.PrivateKey, seq int, kvs map[string][]byte) ([]byte, error) {
 	h := sha3.NewLegacyKeccak256()
-	_, _ = h.Write(encodeElements(nil, kvs))
+	_, _ = h.Write(encodeElements(nil, seq, kvs))
 	digest := h.Sum(nil)
 
 	sig, err := k1util.Sign(privkey, digest)
@@ -54,6 +54,20 @@ func TestEncodeDecode(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestSeq(t *testing.T) {
+	privkey, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	r1, err := enr.NewWithSeq(privkey, 300)
+	require.NoError(t, err)
+	require.Equal(t, 300, r1.Seq())
+
+	r2, err := enr.Parse(r1.String())
+	require.NoError(t, err)
+	require.Equal(t, r1, r2)
+	require.Equal(t, 300, r2.Seq())
+}
+
 func TestIPTCP(t *testing.T) {
 	privkey, err := k1.GeneratePrivateKey()
 	require.NoError(t, err)
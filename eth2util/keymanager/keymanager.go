@@ -18,16 +18,21 @@ import (
 	"github.com/obolnetwork/charon/eth2util/keystore"
 )
 
-// New returns a new Client.
-func New(url string) Client {
+// ErrUnauthorized is returned when the keymanager rejects the provided authentication token.
+var ErrUnauthorized = errors.NewSentinel("keymanager authentication failed")
+
+// New returns a new Client. The optional auth token is sent as bearer token with all requests.
+func New(url string, authToken string) Client {
 	return Client{
-		baseURL: url,
+		baseURL:   url,
+		authToken: authToken,
 	}
 }
 
 // Client is the REST client for keymanager API requests.
 type Client struct {
-	baseURL string // Base keymanager URL
+	baseURL   string // Base keymanager URL
+	authToken string // Optional bearer authentication token
 }
 
 // ImportKeystores pushes the keystores and passwords to keymanager.
@@ -48,7 +53,7 @@ func (c Client) ImportKeystores(ctx context.Context, keystores []keystore.Keysto
 		Passwords: passwords,
 	}
 
-	err = postKeys(ctx, addr, req)
+	err = postKeys(ctx, addr, c.authToken, req)
 	if err != nil {
 		return err
 	}
@@ -76,6 +81,48 @@ func (c Client) VerifyConnection(ctx context.Context) error {
 	return nil
 }
 
+// VerifyAuth returns an error if the keymanager rejects the auth token by listing its keystores.
+// It returns ErrUnauthorized if the keymanager responds with 401 or 403.
+// See https://ethereum.github.io/keymanager-APIs/#/Local%20Key%20Manager/listKeys.
+func (c Client) VerifyAuth(ctx context.Context) error {
+	addr, err := url.JoinPath(c.baseURL, "/eth/v1/keystores")
+	if err != nil {
+		return errors.Wrap(err, "invalid base url", z.Str("base_url", c.baseURL))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr, nil)
+	if err != nil {
+		return errors.Wrap(err, "new get request", z.Str("url", addr))
+	}
+	setAuth(req, c.authToken)
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "list keystores")
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return errors.Wrap(ErrUnauthorized, "list keystores", z.Int("status", resp.StatusCode))
+	} else if resp.StatusCode/100 != 2 {
+		return errors.New("failed listing keystores", z.Int("status", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// setAuth adds the bearer authorization header to the request if the token is not empty.
+func setAuth(req *http.Request, authToken string) {
+	if authToken == "" {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+authToken)
+}
+
 // keymanagerReq represents the keymanager API request body for POST request.
 // Refer: https://ethereum.github.io/keymanager-APIs/#/Local%20Key%20Manager/importKeystores
 type keymanagerReq struct {
@@ -84,7 +131,7 @@ type keymanagerReq struct {
 }
 
 // postKeys pushes the secrets to the provided keymanager address. The HTTP request times out after 10s.
-func postKeys(ctx context.Context, addr string, reqBody keymanagerReq) error {
+func postKeys(ctx context.Context, addr string, authToken string, reqBody keymanagerReq) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -98,6 +145,7 @@ func postKeys(ctx context.Context, addr string, reqBody keymanagerReq) error {
 		return errors.Wrap(err, "new post request", z.Str("url", addr))
 	}
 	req.Header.Add("Content-Type", `application/json`)
+	setAuth(req, authToken)
 
 	resp, err := new(http.Client).Do(req)
 	if err != nil {
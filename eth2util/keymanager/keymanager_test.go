@@ -75,7 +75,7 @@ func TestImportKeystores(t *testing.T) {
 		}))
 		defer srv.Close()
 
-		cl := keymanager.New(srv.URL)
+		cl := keymanager.New(srv.URL, "")
 		err := cl.ImportKeystores(ctx, keystores, passwords)
 		require.NoError(t, err)
 
@@ -95,13 +95,13 @@ func TestImportKeystores(t *testing.T) {
 		}))
 		defer srv.Close()
 
-		cl := keymanager.New(srv.URL)
+		cl := keymanager.New(srv.URL, "")
 		err := cl.ImportKeystores(ctx, keystores, passwords)
 		require.ErrorContains(t, err, "failed posting keys")
 	})
 
 	t.Run("mismatching lengths", func(t *testing.T) {
-		cl := keymanager.New("")
+		cl := keymanager.New("", "")
 		err := cl.ImportKeystores(ctx, keystores, []string{})
 		require.ErrorContains(t, err, "lengths of keystores and passwords don't match")
 	})
@@ -114,23 +114,44 @@ func TestVerifyConnection(t *testing.T) {
 		srv := httptest.NewServer(nil)
 		defer srv.Close()
 
-		cl := keymanager.New(srv.URL)
+		cl := keymanager.New(srv.URL, "")
 		require.NoError(t, cl.VerifyConnection(ctx))
 	})
 
 	t.Run("cannot ping address", func(t *testing.T) {
-		cl := keymanager.New("1.1.1.1")
+		cl := keymanager.New("1.1.1.1", "")
 		require.Error(t, cl.VerifyConnection(ctx))
 		require.ErrorContains(t, cl.VerifyConnection(ctx), "cannot ping address")
 	})
 
 	t.Run("invalid address", func(t *testing.T) {
-		cl := keymanager.New("1.1.0:34")
+		cl := keymanager.New("1.1.0:34", "")
 		require.Error(t, cl.VerifyConnection(ctx))
 		require.ErrorContains(t, cl.VerifyConnection(ctx), "parse address")
 	})
 }
 
+func TestVerifyAuth(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, r.URL.Path, "/eth/v1/keystores")
+		require.Equal(t, http.MethodGet, r.Method)
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, keymanager.New(srv.URL, "secret").VerifyAuth(ctx))
+	require.ErrorIs(t, keymanager.New(srv.URL, "wrong").VerifyAuth(ctx), keymanager.ErrUnauthorized)
+	require.ErrorIs(t, keymanager.New(srv.URL, "").VerifyAuth(ctx), keymanager.ErrUnauthorized)
+}
+
 // mockKeymanagerReq is a mock keymanager request for use in tests.
 type mockKeymanagerReq struct {
 	Keystores []noopKeystore `json:"keystores"`
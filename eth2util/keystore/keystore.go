@@ -14,19 +14,26 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
 	tblsv2 "github.com/obolnetwork/charon/tbls/v2"
 	tblsconv2 "github.com/obolnetwork/charon/tbls/v2/tblsconv"
 )
 
-// insecureCost decreases the cipher key cost from the default 18 to 4 which speeds up
-// encryption and decryption at the cost of security.
-const insecureCost = 4
+const (
+	// insecureCost decreases the cipher key cost from the default 18 to 4 which speeds up
+	// encryption and decryption at the cost of security.
+	insecureCost = 4
+
+	// sharedPasswordEnv is the environment variable prefix of keystore passwords, see EnvPasswords.
+	sharedPasswordEnv = "CHARON_KEYSTORE_PASSWORD"
+)
 
 type confirmInsecure struct{}
 
@@ -39,17 +46,67 @@ var ConfirmInsecureKeys confirmInsecure
 // 🚨 The keystores are insecure and should only be used for testing large validator sets
 // as it speeds up encryption and decryption at the cost of security.
 func StoreKeysInsecure(secrets []tblsv2.PrivateKey, dir string, _ confirmInsecure) error {
-	return storeKeysInternal(secrets, dir, "keystore-insecure-%d.json",
+	return storeKeysFrom(secrets, dir, "keystore-insecure-%d.json", 0,
 		keystorev4.WithCost(new(testing.T), insecureCost))
 }
 
 // StoreKeys stores the secrets in dir/keystore-%d.json EIP 2335 Keystore files
 // with new random passwords stored in dir/Keystore-%d.txt.
 func StoreKeys(secrets []tblsv2.PrivateKey, dir string) error {
-	return storeKeysInternal(secrets, dir, "keystore-%d.json")
+	return storeKeysFrom(secrets, dir, "keystore-%d.json", 0)
+}
+
+// AppendKeys stores the secrets like StoreKeys in stageDir, but numbers the keystore files
+// after the existing keystore files in dir, so they can be moved to dir without overwriting any.
+func AppendKeys(secrets []tblsv2.PrivateKey, dir string, stageDir string) error {
+	first, err := nextKeystoreIdx(dir)
+	if err != nil {
+		return err
+	}
+
+	return storeKeysFrom(secrets, stageDir, "keystore-%d.json", first)
+}
+
+// AppendKeysInsecure stores the secrets like StoreKeysInsecure in stageDir, but numbers the keystore files
+// after the existing keystore files in dir, so they can be moved to dir without overwriting any.
+//
+// 🚨 The keystores are insecure and should only be used for testing large validator sets
+// as it speeds up encryption and decryption at the cost of security.
+func AppendKeysInsecure(secrets []tblsv2.PrivateKey, dir string, stageDir string, _ confirmInsecure) error {
+	first, err := nextKeystoreIdx(dir)
+	if err != nil {
+		return err
+	}
+
+	return storeKeysFrom(secrets, stageDir, "keystore-insecure-%d.json", first,
+		keystorev4.WithCost(new(testing.T), insecureCost))
+}
+
+// nextKeystoreIdx returns the index following the highest index of the keystore files in dir, or zero if none exist.
+func nextKeystoreIdx(dir string) (int, error) {
+	files, err := filepath.Glob(path.Join(dir, "keystore-*.json"))
+	if err != nil {
+		return 0, errors.Wrap(err, "read files")
+	}
+
+	var next int
+	for _, f := range files {
+		name := strings.TrimSuffix(filepath.Base(f), ".json")
+		idx, err := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid keystore file name", z.Str("file", f))
+		}
+
+		if idx >= next {
+			next = idx + 1
+		}
+	}
+
+	return next, nil
 }
 
-func storeKeysInternal(secrets []tblsv2.PrivateKey, dir string, filenameFmt string, opts ...keystorev4.Option) error {
+// storeKeysFrom stores the secrets in keystore files numbered from the first index.
+func storeKeysFrom(secrets []tblsv2.PrivateKey, dir string, filenameFmt string, first int, opts ...keystorev4.Option) error {
 	for i, secret := range secrets {
 		password, err := randomHex32()
 		if err != nil {
@@ -66,7 +123,7 @@ func storeKeysInternal(secrets []tblsv2.PrivateKey, dir string, filenameFmt stri
 			return errors.Wrap(err, "marshal keystore")
 		}
 
-		filename := path.Join(dir, fmt.Sprintf(filenameFmt, i))
+		filename := path.Join(dir, fmt.Sprintf(filenameFmt, first+i))
 
 		//nolint:gosec // File needs to be read-only for everybody
 		if err := os.WriteFile(filename, b, 0o444); err != nil {
@@ -81,9 +138,34 @@ func storeKeysInternal(secrets []tblsv2.PrivateKey, dir string, filenameFmt stri
 	return nil
 }
 
+// PasswordFunc returns the password of the keystore file and true, or false if not provided.
+type PasswordFunc func(keyFile string) (string, bool)
+
+// EnvPasswords returns a PasswordFunc that provides keystore passwords from environment variables.
+// The password of dir/keystore-<n>.json is read from CHARON_KEYSTORE_PASSWORD_<N>, where <N> is the
+// upper case <n>, falling back to the password shared by all keystores in CHARON_KEYSTORE_PASSWORD.
+func EnvPasswords() PasswordFunc {
+	return func(keyFile string) (string, bool) {
+		n := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(keyFile), "keystore-"), ".json")
+		n = strings.ToUpper(strings.ReplaceAll(n, "-", "_"))
+
+		if password, ok := os.LookupEnv(sharedPasswordEnv + "_" + n); ok {
+			return password, true
+		}
+
+		return os.LookupEnv(sharedPasswordEnv)
+	}
+}
+
 // LoadKeys returns all secrets stored in dir/keystore-*.json 2335 Keystore files
 // using password stored in dir/keystore-*.txt.
 func LoadKeys(dir string) ([]tblsv2.PrivateKey, error) {
+	return LoadKeysWithPasswords(dir, nil)
+}
+
+// LoadKeysWithPasswords returns all secrets stored in dir/keystore-*.json 2335 Keystore files
+// using passwords provided by the function, falling back to passwords stored in dir/keystore-*.txt.
+func LoadKeysWithPasswords(dir string, passwordFunc PasswordFunc) ([]tblsv2.PrivateKey, error) {
 	files, err := filepath.Glob(path.Join(dir, "keystore-*.json"))
 	if err != nil {
 		return nil, errors.Wrap(err, "read files")
@@ -105,9 +187,16 @@ func LoadKeys(dir string) ([]tblsv2.PrivateKey, error) {
 			return nil, errors.Wrap(err, "unmarshal keystore")
 		}
 
-		password, err := loadPassword(f)
-		if err != nil {
-			return nil, err
+		password, ok := "", false
+		if passwordFunc != nil {
+			password, ok = passwordFunc(f)
+		}
+
+		if !ok {
+			password, err = loadPassword(f)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		secret, err := decrypt(store, password)
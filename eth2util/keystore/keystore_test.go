@@ -4,6 +4,8 @@ package keystore_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -32,6 +34,73 @@ func TestStoreLoad(t *testing.T) {
 	require.Equal(t, secrets, actual)
 }
 
+func TestAppendKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	var secrets []tblsv2.PrivateKey
+	for i := 0; i < 3; i++ {
+		secret, err := tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+
+		secrets = append(secrets, secret)
+	}
+
+	err := keystore.StoreKeysInsecure(secrets[:2], dir, keystore.ConfirmInsecureKeys)
+	require.NoError(t, err)
+
+	existing, err := os.ReadFile(filepath.Join(dir, "keystore-insecure-1.json"))
+	require.NoError(t, err)
+
+	stageDir := t.TempDir()
+	err = keystore.AppendKeysInsecure(secrets[2:], dir, stageDir, keystore.ConfirmInsecureKeys)
+	require.NoError(t, err)
+
+	for _, name := range []string{"keystore-insecure-2.json", "keystore-insecure-2.txt"} {
+		require.NoFileExists(t, filepath.Join(dir, name))
+		require.NoError(t, os.Rename(filepath.Join(stageDir, name), filepath.Join(dir, name)))
+	}
+
+	actual, err := os.ReadFile(filepath.Join(dir, "keystore-insecure-1.json"))
+	require.NoError(t, err)
+	require.Equal(t, existing, actual)
+
+	loaded, err := keystore.LoadKeys(dir)
+	require.NoError(t, err)
+	require.Equal(t, secrets, loaded)
+}
+
+func TestLoadEnvPasswords(t *testing.T) {
+	dir := t.TempDir()
+
+	var secrets []tblsv2.PrivateKey
+	for i := 0; i < 3; i++ {
+		secret, err := tblsv2.GenerateSecretKey()
+		require.NoError(t, err)
+
+		secrets = append(secrets, secret)
+	}
+
+	err := keystore.StoreKeys(secrets, dir)
+	require.NoError(t, err)
+
+	// Move the passwords of the first two keystores to env vars, keeping the password file of the last.
+	for i := 0; i < 2; i++ {
+		passwordFile := filepath.Join(dir, fmt.Sprintf("keystore-%d.txt", i))
+		password, err := os.ReadFile(passwordFile)
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(passwordFile))
+
+		t.Setenv(fmt.Sprintf("CHARON_KEYSTORE_PASSWORD_%d", i), string(password))
+	}
+
+	_, err = keystore.LoadKeys(dir)
+	require.ErrorContains(t, err, "read password file")
+
+	actual, err := keystore.LoadKeysWithPasswords(dir, keystore.EnvPasswords())
+	require.NoError(t, err)
+	require.Equal(t, secrets, actual)
+}
+
 func TestLoadEmpty(t *testing.T) {
 	_, err := keystore.LoadKeys(".")
 	require.Error(t, err)
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/obolnetwork/charon/app/errors"
 )
@@ -20,38 +21,75 @@ type Network struct {
 	Name string
 	// ForkVersionHex represents fork version of the network in hex.
 	ForkVersionHex string
+	// SlotDuration represents the slot duration of the network.
+	SlotDuration time.Duration
+	// SlotsPerEpoch represents the number of slots per epoch of the network.
+	SlotsPerEpoch uint64
+	// GenesisTimestamp represents the genesis unix timestamp of the network.
+	GenesisTimestamp int64
+	// GenesisValidatorsRootHex represents the genesis validators root of the network in hex.
+	GenesisValidatorsRootHex string
 }
 
 var (
 	Mainnet = Network{
-		ChainID:        1,
-		Name:           "mainnet",
-		ForkVersionHex: "0x00000000",
+		ChainID:                  1,
+		Name:                     "mainnet",
+		ForkVersionHex:           "0x00000000",
+		SlotDuration:             12 * time.Second,
+		SlotsPerEpoch:            32,
+		GenesisTimestamp:         1606824023,
+		GenesisValidatorsRootHex: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
 	}
 	Goerli = Network{
-		ChainID:        5,
-		Name:           "goerli",
-		ForkVersionHex: "0x00001020",
+		ChainID:                  5,
+		Name:                     "goerli",
+		ForkVersionHex:           "0x00001020",
+		SlotDuration:             12 * time.Second,
+		SlotsPerEpoch:            32,
+		GenesisTimestamp:         1616508000,
+		GenesisValidatorsRootHex: "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
 	}
 	Gnosis = Network{
-		ChainID:        100,
-		Name:           "gnosis",
-		ForkVersionHex: "0x00000064",
+		ChainID:                  100,
+		Name:                     "gnosis",
+		ForkVersionHex:           "0x00000064",
+		SlotDuration:             5 * time.Second,
+		SlotsPerEpoch:            16,
+		GenesisTimestamp:         1638993340,
+		GenesisValidatorsRootHex: "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47",
 	}
 	Sepolia = Network{
-		ChainID:        11155111,
-		Name:           "sepolia",
-		ForkVersionHex: "0x90000069",
+		ChainID:                  11155111,
+		Name:                     "sepolia",
+		ForkVersionHex:           "0x90000069",
+		SlotDuration:             12 * time.Second,
+		SlotsPerEpoch:            32,
+		GenesisTimestamp:         1655733600,
+		GenesisValidatorsRootHex: "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078",
 	}
 	Ropsten = Network{
-		ChainID:        3,
-		Name:           "ropsten",
-		ForkVersionHex: "0x80000069",
+		ChainID:                  3,
+		Name:                     "ropsten",
+		ForkVersionHex:           "0x80000069",
+		SlotDuration:             12 * time.Second,
+		SlotsPerEpoch:            32,
+		GenesisTimestamp:         1653922800,
+		GenesisValidatorsRootHex: "0x44f1e56283ca88b35c789f7f449e52339bc1fefe3a45913a43a6d16edcd33cf1",
+	}
+	Holesky = Network{
+		ChainID:                  17000,
+		Name:                     "holesky",
+		ForkVersionHex:           "0x01017000",
+		SlotDuration:             12 * time.Second,
+		SlotsPerEpoch:            32,
+		GenesisTimestamp:         1695902400,
+		GenesisValidatorsRootHex: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
 	}
 )
 
 var supportedNetworks = []Network{
-	Mainnet, Goerli, Gnosis, Sepolia, Ropsten,
+	Mainnet, Goerli, Gnosis, Sepolia, Ropsten, Holesky,
 }
 
 // ForkVersionToChainID returns the chainID corresponding to the provided fork version.
@@ -87,6 +125,40 @@ func NetworkToForkVersion(name string) (string, error) {
 	return "", errors.New("invalid network name")
 }
 
+// NetworkToChainID returns the chainID corresponding to the network name.
+func NetworkToChainID(name string) (int64, error) {
+	for _, network := range supportedNetworks {
+		if name == network.Name {
+			return network.ChainID, nil
+		}
+	}
+
+	return 0, errors.New("invalid network name")
+}
+
+// NetworkToSlotParams returns the slot duration and slots per epoch corresponding to the network name.
+func NetworkToSlotParams(name string) (time.Duration, uint64, error) {
+	for _, network := range supportedNetworks {
+		if name == network.Name {
+			return network.SlotDuration, network.SlotsPerEpoch, nil
+		}
+	}
+
+	return 0, 0, errors.New("invalid network name")
+}
+
+// NetworkToGenesis returns the genesis time and the genesis validators root in hex (0x prefixed)
+// corresponding to the network name.
+func NetworkToGenesis(name string) (time.Time, string, error) {
+	for _, network := range supportedNetworks {
+		if name == network.Name {
+			return time.Unix(network.GenesisTimestamp, 0), network.GenesisValidatorsRootHex, nil
+		}
+	}
+
+	return time.Time{}, "", errors.New("invalid network name")
+}
+
 // NetworkToForkVersionBytes returns the fork version bytes corresponding to the network name.
 func NetworkToForkVersionBytes(name string) ([]byte, error) {
 	forkVersion, err := NetworkToForkVersion(name)
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -56,6 +57,16 @@ func TestNetworkToForkVersion(t *testing.T) {
 	require.Equal(t, fv, "")
 }
 
+func TestNetworkToSlotParams(t *testing.T) {
+	slotDuration, slotsPerEpoch, err := eth2util.NetworkToSlotParams(eth2util.Gnosis.Name)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, slotDuration)
+	require.EqualValues(t, 16, slotsPerEpoch)
+
+	_, _, err = eth2util.NetworkToSlotParams(invalidNetwork)
+	require.ErrorContains(t, err, "invalid network name")
+}
+
 func TestNetworkToForkVersionBytes(t *testing.T) {
 	sepoliaForkVersion, err := hex.DecodeString(strings.TrimPrefix(eth2util.Sepolia.ForkVersionHex, "0x"))
 	require.NoError(t, err)
@@ -0,0 +1,82 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// startupDialTimeout is the timeout of dialing each peer at startup.
+const startupDialTimeout = 10 * time.Second
+
+// NewPeerDialer returns a start function that dials all peers once at startup
+// instead of waiting for them to connect lazily, so quorum connectivity is reached sooner after boot.
+func NewPeerDialer(h host.Host, peers []Peer) lifecycle.HookFuncCtx {
+	return func(ctx context.Context) {
+		ctx = log.WithTopic(ctx, "p2p")
+
+		dial := func(ctx context.Context, p Peer) error {
+			return h.Connect(ctx, p.AddrInfo())
+		}
+
+		dialPeers(ctx, h.ID(), peers, startupDialTimeout, dial)
+	}
+}
+
+// dialPeers dials all peers excluding self concurrently, logs the results and returns the names of the connected peers.
+func dialPeers(ctx context.Context, self peer.ID, peers []Peer, timeout time.Duration,
+	dial func(context.Context, Peer) error,
+) []string {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		connected []string
+		failed    []string
+	)
+	for _, p := range peers {
+		if p.ID == self {
+			continue // Do not dial self
+		}
+
+		wg.Add(1)
+		go func(p Peer) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			err := dial(ctx, p)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				log.Debug(ctx, "Failed dialing peer at startup", z.Str("peer", p.Name), z.Err(err))
+				failed = append(failed, p.Name)
+
+				return
+			}
+
+			connected = append(connected, p.Name)
+		}(p)
+	}
+
+	wg.Wait()
+
+	sort.Strings(connected)
+	sort.Strings(failed)
+
+	log.Info(ctx, "Dialed peers at startup", z.Any("connected", connected), z.Any("failed", failed))
+
+	return connected
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestDialPeers(t *testing.T) {
+	const n = 4
+
+	var peers []Peer
+	for i := 0; i < n; i++ {
+		record, err := enr.New(testutil.GenerateInsecureK1Key(t, i))
+		require.NoError(t, err)
+
+		p, err := NewPeerFromENR(record, i)
+		require.NoError(t, err)
+
+		peers = append(peers, p)
+	}
+
+	var (
+		mu          sync.Mutex
+		dialed      []string
+		noDeadlines int
+	)
+	dial := func(ctx context.Context, p Peer) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		dialed = append(dialed, p.Name)
+		if _, ok := ctx.Deadline(); !ok {
+			noDeadlines++
+		}
+
+		if p.Index == n-1 {
+			return errors.New("unreachable")
+		}
+
+		return nil
+	}
+
+	connected := dialPeers(context.Background(), peers[0].ID, peers, time.Second, dial)
+
+	sort.Strings(dialed)
+	require.Equal(t, sortedNames(peers[1:]), dialed)
+	require.Equal(t, sortedNames(peers[1:n-1]), connected)
+	require.Zero(t, noDeadlines)
+}
+
+func sortedNames(peers []Peer) []string {
+	var names []string
+	for _, p := range peers {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -5,6 +5,7 @@ package p2p
 import (
 	"os"
 	"path"
+	"strings"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 
@@ -12,6 +13,10 @@ import (
 	"github.com/obolnetwork/charon/app/k1util"
 )
 
+// PrivKeyPasswordEnv is the environment variable that provides the charon-enr-private-key password
+// if no password file is configured.
+const PrivKeyPasswordEnv = "CHARON_PRIVATE_KEY_PASSWORD"
+
 // KeyPath returns the charon-enr-private-key path relative to the data dir.
 func KeyPath(datadir string) string {
 	return path.Join(datadir, "charon-enr-private-key")
@@ -27,6 +32,33 @@ func LoadPrivKey(dataDir string) (*k1.PrivateKey, error) {
 	return key, nil
 }
 
+// LoadPrivKeyWithPassword returns the secp256k1 key saved in the directory, decrypting it
+// with the password if it is encrypted.
+func LoadPrivKeyWithPassword(dataDir string, password string) (*k1.PrivateKey, error) {
+	key, err := k1util.LoadWithPassword(KeyPath(dataDir), password)
+	if err != nil {
+		return nil, errors.Wrap(err, "load priv key")
+	}
+
+	return key, nil
+}
+
+// LoadPrivKeyPassword returns the private key password read from the file if provided,
+// otherwise from the PrivKeyPasswordEnv environment variable. It returns an empty
+// password if neither is set.
+func LoadPrivKeyPassword(passwordFile string) (string, error) {
+	if passwordFile == "" {
+		return os.Getenv(PrivKeyPasswordEnv), nil
+	}
+
+	b, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return "", errors.Wrap(err, "read private key password file")
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
 // NewSavedPrivKey generates a new ecdsa k1 key and saves it to the directory.
 func NewSavedPrivKey(datadir string) (*k1.PrivateKey, error) {
 	if err := os.MkdirAll(datadir, 0o755); err != nil {
@@ -4,6 +4,7 @@ package p2p
 
 import (
 	"fmt"
+	"hash/fnv"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -383,3 +384,15 @@ func PeerName(id peer.ID) string {
 
 	return fmt.Sprintf("%s-%s", adjectives[adjIdx], nouns[nounIdx])
 }
+
+// HashName returns a deterministic pseudo random human friendly name for the data, e.g. a hash.
+func HashName(data []byte) string {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	hashValue := h.Sum64()
+
+	nounIdx := hashValue % uint64(len(nouns))
+	adjIdx := (hashValue / uint64(len(nouns))) % uint64(len(adjectives))
+
+	return fmt.Sprintf("%s-%s", adjectives[adjIdx], nouns[nounIdx])
+}
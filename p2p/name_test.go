@@ -41,3 +41,10 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+func TestHashName(t *testing.T) {
+	name := p2p.HashName([]byte("hash"))
+	require.Equal(t, name, p2p.HashName([]byte("hash")))
+	require.NotEqual(t, name, p2p.HashName([]byte("other")))
+	require.Regexp(t, "^[a-z]+-[a-z]+$", name)
+}
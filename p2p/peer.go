@@ -72,6 +72,26 @@ func NewPeerFromENR(record enr.Record, index int) (Peer, error) {
 	}, nil
 }
 
+// AddrsFromENR returns the tcp multiaddresses advertised by the ENR or nil if it doesn't contain an ip and tcp port.
+func AddrsFromENR(record enr.Record) ([]ma.Multiaddr, error) {
+	ip, ok := record.IP()
+	if !ok {
+		return nil, nil
+	}
+
+	port, ok := record.TCP()
+	if !ok {
+		return nil, nil
+	}
+
+	addr, err := multiAddrFromIPPort(ip, port)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ma.Multiaddr{addr}, nil
+}
+
 // PeerIDToKey returns the public key of the peer ID.
 func PeerIDToKey(p peer.ID) (*k1.PublicKey, error) {
 	pk, err := p.ExtractPublicKey()
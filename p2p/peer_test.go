@@ -4,6 +4,7 @@ package p2p_test
 
 import (
 	"context"
+	"net"
 	"os"
 	"testing"
 
@@ -34,6 +35,25 @@ func TestNewPeer(t *testing.T) {
 	require.Equal(t, "16Uiu2HAkzdQ5Y9SYT91K1ue5SxXwgmajXntfScGnLYeip5hHyWmT", p.ID.String())
 }
 
+func TestAddrsFromENR(t *testing.T) {
+	p2pKey := testutil.GenerateInsecureK1Key(t, 1)
+
+	record, err := enr.New(p2pKey)
+	require.NoError(t, err)
+
+	addrs, err := p2p.AddrsFromENR(record)
+	require.NoError(t, err)
+	require.Empty(t, addrs)
+
+	record, err = enr.New(p2pKey, enr.WithIP(net.IPv4(1, 2, 3, 4)), enr.WithTCP(3610))
+	require.NoError(t, err)
+
+	addrs, err = p2p.AddrsFromENR(record)
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	require.Equal(t, "/ip4/1.2.3.4/tcp/3610", addrs[0].String())
+}
+
 func TestNewHost(t *testing.T) {
 	privKey, err := k1.GeneratePrivateKey()
 	require.NoError(t, err)
@@ -1,5 +1,7 @@
 // Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
 
+//go:build cgo
+
 package v2
 
 import (
@@ -13,6 +15,9 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 )
 
+// HerumiAvailable is true if the cgo based Herumi implementation is available.
+const HerumiAvailable = true
+
 var initOnce = sync.Once{}
 
 // PSA: as much as init() is (almost) an antipattern in Go, Herumi BLS implementation needs an initialization routine
@@ -214,6 +219,47 @@ func (Herumi) ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Si
 	return *(*Signature)(complete.Serialize()), nil
 }
 
+func (Herumi) RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	var (
+		rawPubKeys []bls.PublicKey
+		rawIDs     []bls.ID
+	)
+
+	for idx, rawPubKey := range publicSharesByIndex {
+		// do a local copy, we're dealing with references here
+		rawPubKey := rawPubKey
+		var pubKey bls.PublicKey
+		if err := pubKey.Deserialize(rawPubKey[:]); err != nil {
+			return PublicKey{}, errors.Wrap(
+				err,
+				"cannot unmarshal public share into Herumi public key",
+				z.Int("public_share_number", idx),
+			)
+		}
+
+		rawPubKeys = append(rawPubKeys, pubKey)
+
+		var id bls.ID
+		if err := id.SetDecString(strconv.Itoa(idx)); err != nil {
+			return PublicKey{}, errors.Wrap(
+				err,
+				"public share id isn't a number",
+				z.Int("public_share_number", idx),
+			)
+		}
+
+		rawIDs = append(rawIDs, id)
+	}
+
+	var complete bls.PublicKey
+
+	if err := complete.Recover(rawPubKeys, rawIDs); err != nil {
+		return PublicKey{}, errors.Wrap(err, "cannot combine public shares")
+	}
+
+	return *(*PublicKey)(complete.Serialize()), nil
+}
+
 func (Herumi) Verify(compressedPublicKey PublicKey, data []byte, rawSignature Signature) error {
 	var pubKey bls.PublicKey
 	if err := pubKey.Deserialize(compressedPublicKey[:]); err != nil {
@@ -0,0 +1,14 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build !cgo
+
+package v2
+
+// HerumiAvailable is false since the Herumi implementation requires cgo.
+const HerumiAvailable = false
+
+// Herumi falls back to the pure-Go Kryptology implementation when built without cgo.
+// This allows charon to run on platforms without cgo support at the cost of performance.
+type Herumi struct {
+	Kryptology
+}
@@ -183,6 +183,40 @@ func (Kryptology) ThresholdAggregate(partialSignaturesByIndex map[int]Signature)
 	return *(*Signature)(ret), nil
 }
 
+func (Kryptology) RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	curve := curves.BLS12381G1()
+
+	var ids []uint32
+	for idx := range publicSharesByIndex {
+		ids = append(ids, uint32(idx))
+	}
+
+	scheme, err := share.NewShamir(uint32(len(ids)), uint32(len(ids)), curve)
+	if err != nil {
+		return PublicKey{}, errors.Wrap(err, "new Shamir")
+	}
+
+	coeffs, err := scheme.LagrangeCoeffs(ids)
+	if err != nil {
+		return PublicKey{}, errors.Wrap(err, "lagrange coefficients")
+	}
+
+	// Interpolate the public shares at zero.
+	complete := curve.Point.Identity()
+	for idx, pubShare := range publicSharesByIndex {
+		// do a local copy, we're dealing with references here
+		pubShare := pubShare
+		point, err := curve.Point.FromAffineCompressed(pubShare[:])
+		if err != nil {
+			return PublicKey{}, errors.Wrap(err, "unmarshal public share into kryptology object", z.Int("public_share_number", idx))
+		}
+
+		complete = complete.Add(point.Mul(coeffs[uint32(idx)]))
+	}
+
+	return *(*PublicKey)(complete.ToAffineCompressed()), nil
+}
+
 func (Kryptology) Verify(compressedPublicKey PublicKey, data []byte, signature Signature) error {
 	rawKey := new(bls_sig.PublicKey)
 	if err := rawKey.UnmarshalBinary(compressedPublicKey[:]); err != nil {
@@ -39,6 +39,9 @@ type Implementation interface {
 	// ThresholdAggregate aggregates the partial signatures passed in input in the final original signature.
 	ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error)
 
+	// RecoverPublicKey recovers the original public key off the input public shares by their share index.
+	RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error)
+
 	// Verify verifies that signature has been produced with the private key associated with compressedPublicKey, on
 	// the provided data.
 	Verify(compressedPublicKey PublicKey, data []byte, signature Signature) error
@@ -83,6 +86,10 @@ func ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature,
 	return impl.ThresholdAggregate(partialSignaturesByIndex)
 }
 
+func RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	return impl.RecoverPublicKey(publicSharesByIndex)
+}
+
 func Verify(compressedPublicKey PublicKey, data []byte, signature Signature) error {
 	return impl.Verify(compressedPublicKey, data, signature)
 }
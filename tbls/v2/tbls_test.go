@@ -96,6 +96,37 @@ func (ts *TestSuite) Test_ThresholdAggregate() {
 	require.Equal(ts.T(), totalOGSig, totalSig)
 }
 
+func (ts *TestSuite) Test_RecoverPublicKey() {
+	secret, err := v2.GenerateSecretKey()
+	require.NoError(ts.T(), err)
+
+	pubkey, err := v2.SecretToPublicKey(secret)
+	require.NoError(ts.T(), err)
+
+	shares, err := v2.ThresholdSplit(secret, 5, 3)
+	require.NoError(ts.T(), err)
+
+	pubShares := map[int]v2.PublicKey{}
+
+	for idx, key := range shares {
+		pubShare, err := v2.SecretToPublicKey(key)
+		require.NoError(ts.T(), err)
+		pubShares[idx] = pubShare
+	}
+
+	recovered, err := v2.RecoverPublicKey(pubShares)
+	require.NoError(ts.T(), err)
+	require.Equal(ts.T(), pubkey, recovered)
+
+	// Any threshold subset of public shares recovers the original public key.
+	delete(pubShares, 1)
+	delete(pubShares, 5)
+
+	recovered, err = v2.RecoverPublicKey(pubShares)
+	require.NoError(ts.T(), err)
+	require.Equal(ts.T(), pubkey, recovered)
+}
+
 func (ts *TestSuite) Test_Verify() {
 	data := []byte("hello obol!")
 
@@ -181,6 +212,62 @@ func TestKryptologyImplementation(t *testing.T) {
 	runSuite(t, v2.Kryptology{})
 }
 
+func TestCrossImplementation(t *testing.T) {
+	impls := map[string]v2.Implementation{
+		"herumi":     v2.Herumi{},
+		"kryptology": v2.Kryptology{},
+	}
+	data := []byte("hello obol!")
+
+	secret, err := v2.Kryptology{}.GenerateSecretKey()
+	require.NoError(t, err)
+
+	var (
+		pubkeys []v2.PublicKey
+		sigs    []v2.Signature
+	)
+	for _, impl := range impls {
+		pubkey, err := impl.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		pubkeys = append(pubkeys, pubkey)
+
+		sig, err := impl.Sign(secret, data)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+	}
+
+	// All implementations produce identical public keys and signatures.
+	require.Equal(t, pubkeys[0], pubkeys[1])
+	require.Equal(t, sigs[0], sigs[1])
+
+	for signName, signImpl := range impls {
+		shares, err := signImpl.ThresholdSplit(secret, 4, 3)
+		require.NoError(t, err)
+
+		partials := make(map[int]v2.Signature)
+		for idx, share := range shares {
+			partials[idx], err = signImpl.Sign(share, data)
+			require.NoError(t, err)
+		}
+
+		for verifyName, verifyImpl := range impls {
+			require.NoError(t, verifyImpl.Verify(pubkeys[0], data, sigs[0]), verifyName)
+
+			recovered, err := verifyImpl.RecoverSecret(shares, 4, 3)
+			require.NoError(t, err, signName+"->"+verifyName)
+			require.Equal(t, secret, recovered, signName+"->"+verifyName)
+
+			aggSig, err := verifyImpl.ThresholdAggregate(partials)
+			require.NoError(t, err, signName+"->"+verifyName)
+			require.Equal(t, sigs[0], aggSig, signName+"->"+verifyName)
+
+			sig, err := verifyImpl.Aggregate(sigs)
+			require.NoError(t, err)
+			require.NoError(t, verifyImpl.VerifyAggregate([]v2.PublicKey{pubkeys[0], pubkeys[0]}, sig, data))
+		}
+	}
+}
+
 func runBenchmark(b *testing.B, impl v2.Implementation) {
 	b.Helper()
 	s := NewTestSuite(impl)
@@ -290,6 +377,15 @@ func (r randomizedImpl) ThresholdAggregate(partialSignaturesByIndex map[int]v2.S
 	return impl.ThresholdAggregate(partialSignaturesByIndex)
 }
 
+func (r randomizedImpl) RecoverPublicKey(publicSharesByIndex map[int]v2.PublicKey) (v2.PublicKey, error) {
+	impl, err := r.selectImpl()
+	if err != nil {
+		return v2.PublicKey{}, err
+	}
+
+	return impl.RecoverPublicKey(publicSharesByIndex)
+}
+
 func (r randomizedImpl) Verify(compressedPublicKey v2.PublicKey, data []byte, signature v2.Signature) error {
 	impl, err := r.selectImpl()
 	if err != nil {
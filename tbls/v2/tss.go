@@ -0,0 +1,95 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package v2
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// TSSSchemeShamir is the name of the Shamir secret sharing scheme over the BLS12-381 scalar field
+	// with 1-indexed share IDs, as implemented by ThresholdSplit and RecoverSecret.
+	TSSSchemeShamir = "shamir"
+
+	// DefaultTSSScheme is the name of the default threshold secret sharing scheme.
+	// Cluster locks without a scheme use the default scheme.
+	DefaultTSSScheme = TSSSchemeShamir
+)
+
+var (
+	tssSchemes = map[string]TSSScheme{
+		TSSSchemeShamir: shamirScheme{},
+	}
+	tssSchemesLock sync.Mutex
+)
+
+// TSSScheme is a threshold secret sharing scheme splitting a private key into key shares
+// identified by 1-indexed share indexes.
+type TSSScheme interface {
+	// Name returns the name of the scheme recorded in the cluster lock.
+	Name() string
+
+	// Split splits the secret into total key shares, of which threshold are required to recover it.
+	Split(secret PrivateKey, total uint, threshold uint) (map[int]PrivateKey, error)
+
+	// Recover recovers the original secret from at least threshold key shares by share index.
+	Recover(shares map[int]PrivateKey, total uint, threshold uint) (PrivateKey, error)
+}
+
+// RegisterTSSScheme registers the scheme by name, replacing any existing scheme with the same name.
+func RegisterTSSScheme(scheme TSSScheme) {
+	tssSchemesLock.Lock()
+	defer tssSchemesLock.Unlock()
+
+	tssSchemes[scheme.Name()] = scheme
+}
+
+// GetTSSScheme returns the registered scheme by name or the default scheme if the name is empty.
+func GetTSSScheme(name string) (TSSScheme, error) {
+	if name == "" {
+		name = DefaultTSSScheme
+	}
+
+	tssSchemesLock.Lock()
+	defer tssSchemesLock.Unlock()
+
+	scheme, ok := tssSchemes[name]
+	if !ok {
+		return nil, errors.New("unknown tss scheme", z.Str("scheme", name))
+	}
+
+	return scheme, nil
+}
+
+// TSSSchemes returns the sorted names of all registered schemes.
+func TSSSchemes() []string {
+	tssSchemesLock.Lock()
+	defer tssSchemesLock.Unlock()
+
+	var resp []string
+	for name := range tssSchemes {
+		resp = append(resp, name)
+	}
+	sort.Strings(resp)
+
+	return resp
+}
+
+// shamirScheme is the default Shamir secret sharing scheme using the package backing implementation.
+type shamirScheme struct{}
+
+func (shamirScheme) Name() string {
+	return TSSSchemeShamir
+}
+
+func (shamirScheme) Split(secret PrivateKey, total uint, threshold uint) (map[int]PrivateKey, error) {
+	return ThresholdSplit(secret, total, threshold)
+}
+
+func (shamirScheme) Recover(shares map[int]PrivateKey, total uint, threshold uint) (PrivateKey, error) {
+	return RecoverSecret(shares, total, threshold)
+}
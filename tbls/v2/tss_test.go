@@ -0,0 +1,46 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package v2_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v2 "github.com/obolnetwork/charon/tbls/v2"
+)
+
+func (ts *TestSuite) Test_DefaultTSSScheme() {
+	scheme, err := v2.GetTSSScheme(v2.DefaultTSSScheme)
+	require.NoError(ts.T(), err)
+	require.Equal(ts.T(), v2.DefaultTSSScheme, scheme.Name())
+
+	secret, err := v2.GenerateSecretKey()
+	require.NoError(ts.T(), err)
+
+	shares, err := scheme.Split(secret, 4, 3)
+	require.NoError(ts.T(), err)
+	require.Len(ts.T(), shares, 4)
+
+	// Any threshold shares recover the secret.
+	delete(shares, 2)
+	recovered, err := scheme.Recover(shares, 4, 3)
+	require.NoError(ts.T(), err)
+	require.Equal(ts.T(), secret, recovered)
+
+	// Shares are compatible with the package threshold functions.
+	recovered, err = v2.RecoverSecret(shares, 4, 3)
+	require.NoError(ts.T(), err)
+	require.Equal(ts.T(), secret, recovered)
+}
+
+func TestGetTSSScheme(t *testing.T) {
+	scheme, err := v2.GetTSSScheme("")
+	require.NoError(t, err)
+	require.Equal(t, v2.DefaultTSSScheme, scheme.Name())
+
+	_, err = v2.GetTSSScheme("unknown")
+	require.ErrorContains(t, err, "unknown tss scheme")
+
+	require.Equal(t, []string{v2.TSSSchemeShamir}, v2.TSSSchemes())
+}
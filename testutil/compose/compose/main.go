@@ -116,7 +116,10 @@ func newNewCmd() *cobra.Command {
 
 	dir := addDirFlag(cmd.Flags())
 	keygen := cmd.Flags().String("keygen", string(conf.KeyGen), "Key generation process: create, split, dkg")
+	dkgAlgo := cmd.Flags().String("dkg-algorithm", conf.DKGAlgorithm, "DKG algorithm to use for keygen=dkg: default, keycast, frost")
 	buildLocal := cmd.Flags().Bool("build-local", conf.BuildLocal, "Enables building a local charon container from source. Note this requires the CHARON_REPO env var.")
+	buildOS := cmd.Flags().String("build-os", conf.BuildOS, "Target operating system of the local charon container build: linux")
+	buildArch := cmd.Flags().String("build-arch", conf.BuildArch, "Target architecture of the local charon container build: amd64, arm64")
 	beaconNode := cmd.Flags().String("beacon-node", conf.BeaconNode, "Beacon node URL endpoint or 'mock' for simnet.")
 	extRelay := cmd.Flags().String("external-relay", "", "Optional external relay HTTP url.")
 	splitKeys := cmd.Flags().String("split-keys-dir", conf.SplitKeysDir, "Directory containing keys to split for keygen==create, or empty not to split.")
@@ -126,10 +129,14 @@ func newNewCmd() *cobra.Command {
 	nodes := cmd.Flags().Int("nodes", conf.NumNodes, "Number of charon nodes in the cluster.")
 	insecureKeys := cmd.Flags().Bool("insecure-keys", conf.InsecureKeys, "To generate keys quickly.")
 	slotDuration := cmd.Flags().Duration("simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
+	imageDigest := cmd.Flags().String("image-digest", conf.ImageDigest, "Optional charon image digest (sha256:<hex>) to pin overriding the image tag.")
 
 	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
 		conf.KeyGen = compose.KeyGen(*keygen)
+		conf.DKGAlgorithm = *dkgAlgo
 		conf.BuildLocal = *buildLocal
+		conf.BuildOS = *buildOS
+		conf.BuildArch = *buildArch
 		conf.BeaconNode = *beaconNode
 		conf.SplitKeysDir = *splitKeys
 		conf.FeatureSet = *featureSet
@@ -139,6 +146,7 @@ func newNewCmd() *cobra.Command {
 		conf.Threshold = cluster.Threshold(conf.NumNodes)
 		conf.InsecureKeys = *insecureKeys
 		conf.SlotDuration = *slotDuration
+		conf.ImageDigest = *imageDigest
 
 		if conf.BuildLocal {
 			conf.ImageTag = "local"
@@ -8,11 +8,16 @@ import (
 	"os"
 	"path"
 	"testing"
+	"testing/fstest"
 	"text/template"
+	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/testutil"
 )
 
@@ -103,3 +108,290 @@ func TestParseTemplate(t *testing.T) {
 	_, err = getVC(VCTeku, 0, 1, false)
 	require.NoError(t, err)
 }
+
+func TestImageDigest(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	noPull = true
+
+	conf := NewDefaultConfig()
+	conf.ImageDigest = digest
+
+	dir := t.TempDir()
+	data, err := Define(context.Background(), dir, conf)
+	require.NoError(t, err)
+	require.Equal(t, digest, data.CharonImageDigest)
+
+	b, err := os.ReadFile(path.Join(dir, "docker-compose.yml"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "image: obolnetwork/charon@"+digest)
+	require.NotContains(t, string(b), "image: obolnetwork/charon:")
+
+	conf.ImageDigest = "sha256:invalid"
+	_, err = Define(context.Background(), t.TempDir(), conf)
+	require.ErrorContains(t, err, "invalid image digest")
+}
+
+func TestValidateThreshold(t *testing.T) {
+	noPull = true
+
+	tests := []struct {
+		Name      string
+		NumNodes  int
+		Threshold int
+		NumVals   int
+		Err       string
+	}{
+		{Name: "valid", NumNodes: 4, Threshold: 3, NumVals: 1},
+		{Name: "threshold equals nodes", NumNodes: 4, Threshold: 4, NumVals: 1},
+		{Name: "zero threshold", NumNodes: 4, Threshold: 0, NumVals: 1, Err: "threshold must be between 1 and number of nodes"},
+		{Name: "threshold exceeds nodes", NumNodes: 4, Threshold: 5, NumVals: 1, Err: "threshold must be between 1 and number of nodes"},
+		{Name: "zero validators", NumNodes: 4, Threshold: 3, NumVals: 0, Err: "num validators must be positive"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			conf := NewDefaultConfig()
+			conf.NumNodes = test.NumNodes
+			conf.Threshold = test.Threshold
+			conf.NumValidators = test.NumVals
+
+			_, err := Define(context.Background(), t.TempDir(), conf)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInsecureKeys(t *testing.T) {
+	noPull = true
+
+	conf := NewDefaultConfig()
+	conf.KeyGen = KeyGenCreate
+	conf.InsecureKeys = true
+
+	dir := t.TempDir()
+	_, err := Define(context.Background(), dir, conf)
+	require.NoError(t, err)
+
+	conf.Step = stepDefined
+	_, err = Lock(context.Background(), dir, conf)
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path.Join(dir, "docker-compose.yml"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "command: [create,cluster]")
+	require.Contains(t, string(b), `CHARON_INSECURE_KEYS: "true"`)
+
+	t.Run("dkg unsupported", func(t *testing.T) {
+		conf := NewDefaultConfig()
+		conf.KeyGen = KeyGenDKG
+		conf.InsecureKeys = true
+
+		_, err := Define(context.Background(), t.TempDir(), conf)
+		require.ErrorContains(t, err, "insecure keys only supported with keygen=create")
+	})
+}
+
+func TestSaveP2PKeys(t *testing.T) {
+	const n = 10
+
+	var (
+		keys []*k1.PrivateKey
+		want []string
+	)
+	for i := 0; i < n; i++ {
+		key := testutil.GenerateInsecureK1Key(t, i)
+		keys = append(keys, key)
+
+		record, err := enr.New(key)
+		require.NoError(t, err)
+		want = append(want, record.String())
+	}
+
+	dir := t.TempDir()
+	enrs, err := saveP2PKeys(context.Background(), dir, keys)
+	require.NoError(t, err)
+	require.Equal(t, want, enrs)
+
+	for i, key := range keys {
+		loaded, err := k1util.Load(nodeFile(dir, i, "charon-enr-private-key"))
+		require.NoError(t, err)
+		require.True(t, key.PubKey().IsEqual(loaded.PubKey()))
+	}
+}
+
+func TestNodeEnvs(t *testing.T) {
+	noPull = true
+
+	conf := NewDefaultConfig()
+	conf.KeyGen = KeyGenDKG
+	conf.Step = stepDefined
+	conf.NodeEnvs = map[int]map[string]string{
+		0: {"data-dir": "/compose/custom0"},
+		2: {"data_dir": "/compose/custom2", "log-level": "info", "extra": "value"},
+	}
+
+	dir := t.TempDir()
+	_, err := Lock(context.Background(), dir, conf)
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path.Join(dir, "docker-compose.yml"))
+	require.NoError(t, err)
+	yml := string(b)
+
+	require.Contains(t, yml, "CHARON_DATA_DIR: /compose/custom0")
+	require.Contains(t, yml, "CHARON_DATA_DIR: /compose/node1")
+	require.Contains(t, yml, "CHARON_DATA_DIR: /compose/custom2")
+	require.Contains(t, yml, "CHARON_DATA_DIR: /compose/node3")
+	require.NotContains(t, yml, "CHARON_DATA_DIR: /compose/node0")
+	require.NotContains(t, yml, "CHARON_DATA_DIR: /compose/node2")
+	require.Contains(t, yml, "CHARON_LOG_LEVEL: info")
+	require.Contains(t, yml, "CHARON_EXTRA: value")
+}
+
+func TestCopyStaticFS(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"static/grafana/grafana.ini": {Data: []byte("ini")},
+			"static/vc/run.sh":           {Data: []byte("#!/bin/sh")},
+		}
+
+		dir := t.TempDir()
+		require.NoError(t, copyStaticFS(fsys, dir))
+
+		b, err := os.ReadFile(path.Join(dir, "grafana", "grafana.ini"))
+		require.NoError(t, err)
+		require.Equal(t, "ini", string(b))
+
+		info, err := os.Stat(path.Join(dir, "vc", "run.sh"))
+		require.NoError(t, err)
+		require.EqualValues(t, 0o755, info.Mode().Perm())
+	})
+
+	t.Run("static files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"static/file.txt": {Data: []byte("file")},
+		}
+
+		err := copyStaticFS(fsys, t.TempDir())
+		require.ErrorContains(t, err, "static files not supported")
+	})
+
+	t.Run("child static dirs", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"static/grafana/child/file.txt": {Data: []byte("file")},
+		}
+
+		dir := t.TempDir()
+		err := copyStaticFS(fsys, dir)
+		require.ErrorContains(t, err, "child static dirs not supported")
+		require.NoDirExists(t, path.Join(dir, "grafana"))
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		require.NoError(t, validateStaticFS(static))
+	})
+}
+
+func TestDKGAlgorithm(t *testing.T) {
+	const seed = 0
+	keyGenFunc = func() (*k1.PrivateKey, error) {
+		return testutil.GenerateInsecureK1Key(t, seed), nil
+	}
+	noPull = true
+
+	conf := NewDefaultConfig()
+	conf.KeyGen = KeyGenDKG
+	conf.DKGAlgorithm = "keycast"
+
+	dir := t.TempDir()
+	data, err := Define(context.Background(), dir, conf)
+	require.NoError(t, err)
+	require.Contains(t, data.Nodes[0].EnvVars, kv{"dkg_algorithm", "keycast"})
+
+	b, err := os.ReadFile(path.Join(dir, "docker-compose.yml"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "CHARON_DKG_ALGORITHM: keycast")
+
+	conf.DKGAlgorithm = "invalid"
+	_, err = Define(context.Background(), t.TempDir(), conf)
+	require.ErrorContains(t, err, "unsupported dkg algorithm")
+}
+
+func TestBuildLocalRetries(t *testing.T) {
+	t.Setenv("CHARON_REPO", t.TempDir())
+
+	cachedDelay, cachedRun := buildRetryDelay, runBuildFunc
+	t.Cleanup(func() {
+		buildRetryDelay, runBuildFunc = cachedDelay, cachedRun
+	})
+	buildRetryDelay = time.Millisecond
+
+	// stubBuild returns a build func that returns the outputs in order, succeeding afterwards.
+	stubBuild := func(outputs ...string) (func(context.Context, string, []string) (string, error), *int) {
+		var calls int
+		return func(context.Context, string, []string) (string, error) {
+			calls++
+			if calls > len(outputs) {
+				return "ok", nil
+			}
+
+			return outputs[calls-1], errors.New("exit status 1")
+		}, &calls
+	}
+
+	t.Run("transient then success", func(t *testing.T) {
+		var calls *int
+		runBuildFunc, calls = stubBuild("dial tcp: i/o timeout")
+		require.NoError(t, buildLocal(context.Background(), NewDefaultConfig()))
+		require.Equal(t, 2, *calls)
+	})
+
+	t.Run("compile error", func(t *testing.T) {
+		var calls *int
+		runBuildFunc, calls = stubBuild("undefined: foo")
+		require.ErrorContains(t, buildLocal(context.Background(), NewDefaultConfig()), "exec docker build")
+		require.Equal(t, 1, *calls)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		var calls *int
+		runBuildFunc, calls = stubBuild("no such host", "no such host", "no such host")
+		require.ErrorContains(t, buildLocal(context.Background(), NewDefaultConfig()), "failed after retries")
+		require.Equal(t, buildAttempts, *calls)
+	})
+}
+
+func TestBuildPlatform(t *testing.T) {
+	t.Setenv("CHARON_REPO", t.TempDir())
+
+	cachedRun := runBuildFunc
+	t.Cleanup(func() {
+		runBuildFunc = cachedRun
+	})
+
+	var args []string
+	runBuildFunc = func(_ context.Context, _ string, a []string) (string, error) {
+		args = a
+		return "", nil
+	}
+
+	conf := NewDefaultConfig()
+	require.NoError(t, buildLocal(context.Background(), conf))
+	require.Equal(t, []string{"build", "--platform", "linux/amd64", "-t", "obolnetwork/charon:local", "."}, args)
+
+	conf.BuildArch = "arm64"
+	require.NoError(t, buildLocal(context.Background(), conf))
+	require.Contains(t, args, "linux/arm64")
+
+	conf.BuildArch = "386"
+	require.ErrorContains(t, buildLocal(context.Background(), conf), "unsupported build arch")
+
+	conf.BuildArch = "arm64"
+	conf.BuildOS = "darwin"
+	require.ErrorContains(t, buildLocal(context.Background(), conf), "unsupported build os")
+}
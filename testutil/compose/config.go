@@ -10,6 +10,9 @@ const (
 	defaultImageTag   = "latest"
 	defaultBeaconNode = "mock"
 	defaultKeyGen     = KeyGenCreate
+	defaultDKGAlgo    = "frost"
+	defaultBuildOS    = "linux"
+	defaultBuildArch  = "amd64"
 	defaultNumVals    = 1
 	defaultNumNodes   = 4
 	defaultThreshold  = 3
@@ -23,6 +26,24 @@ const (
 	cmdCreateDKG     = "[create,dkg]"
 )
 
+// supportedDKGAlgos defines the DKG algorithms supported by charon.
+var supportedDKGAlgos = map[string]bool{
+	"default": true,
+	"keycast": true,
+	"frost":   true,
+}
+
+// supportedBuildOSes defines the supported target operating systems of local builds.
+var supportedBuildOSes = map[string]bool{
+	"linux": true,
+}
+
+// supportedBuildArches defines the supported target architectures of local builds.
+var supportedBuildArches = map[string]bool{
+	"amd64": true,
+	"arm64": true,
+}
+
 var charonPorts = []port{
 	{External: 3600, Internal: 3600}, // # Validator API
 	{External: 3610, Internal: 3610}, // # Libp2p
@@ -77,12 +98,25 @@ type Config struct {
 	// ImageTag defines the charon docker image tag: obolnetwork/charon:{ImageTag}.
 	ImageTag string `json:"image_tag"`
 
+	// ImageDigest optionally pins the charon docker image by digest: obolnetwork/charon@{ImageDigest}.
+	// It overrides ImageTag if not empty.
+	ImageDigest string `json:"image_digest"`
+
 	// BuildLocal enables building a local docker container from source overriding ImageTag with 'local'.
 	BuildLocal bool `json:"build_local"`
 
+	// BuildOS defines the target operating system (GOOS) of the local docker container build.
+	BuildOS string `json:"build_os"`
+
+	// BuildArch defines the target architecture (GOARCH) of the local docker container build.
+	BuildArch string `json:"build_arch"`
+
 	// KeyGen defines the key generation process.
 	KeyGen KeyGen `json:"key_gen"`
 
+	// DKGAlgorithm defines the DKG algorithm to use for keygen==dkg, see supportedDKGAlgos.
+	DKGAlgorithm string `json:"dkg_algorithm"`
+
 	// SplitKeysDir directory containing keys to split for keygen==create.
 	SplitKeysDir string `json:"split_keys_dir"`
 
@@ -102,12 +136,15 @@ type Config struct {
 	DisableMonitoringPorts bool `json:"disable_monitoring_ports"`
 
 	// InsecureKeys generates insecure keys. Useful when testing large validator sets
-	// as it speeds up keystore encryption and decryption.
+	// as it speeds up keystore encryption and decryption. Only supported with keygen=create.
 	InsecureKeys bool `json:"insecure_keys"`
 
 	// SlotDuration configures slot duration on simnet beacon mock for all the nodes in the cluster.
 	SlotDuration time.Duration `json:"slot_duration"`
 
+	// NodeEnvs optionally overrides or adds charon environment variables by node index, e.g. {0: {"output_dir": "/compose/node0"}}.
+	NodeEnvs map[int]map[string]string `json:"node_envs"`
+
 	// SyntheticBlockProposals configures use of synthetic block proposals in simnet cluster.
 	SyntheticBlockProposals bool `json:"synthetic_block_proposals"`
 }
@@ -130,8 +167,11 @@ func NewDefaultConfig() Config {
 		Threshold:               defaultThreshold,
 		NumValidators:           defaultNumVals,
 		ImageTag:                defaultImageTag,
+		BuildOS:                 defaultBuildOS,
+		BuildArch:               defaultBuildArch,
 		VCs:                     []VCType{VCTeku, VCLighthouse, VCMock},
 		KeyGen:                  defaultKeyGen,
+		DKGAlgorithm:            defaultDKGAlgo,
 		BeaconNode:              defaultBeaconNode,
 		Step:                    stepNew,
 		FeatureSet:              defaultFeatureSet,
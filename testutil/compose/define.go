@@ -7,15 +7,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/forkjoin"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
@@ -25,6 +29,9 @@ import (
 // zeroXDead is the 0x00..00dead Ethereum address.
 const zeroXDead = `"0x000000000000000000000000000000000000dead"`
 
+// imageDigestRegex matches a docker image content digest.
+var imageDigestRegex = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
 // Clean deletes all compose directory files and artifacts.
 func Clean(ctx context.Context, dir string) error {
 	ctx = log.WithTopic(ctx, "clean")
@@ -79,12 +86,30 @@ func Define(ctx context.Context, dir string, conf Config) (TmplData, error) {
 	}
 
 	if conf.BuildLocal {
-		if err := buildLocal(ctx); err != nil {
+		if err := buildLocal(ctx, conf); err != nil {
 			return TmplData{}, err
 		}
 	}
 
-	if !noPull && !conf.BuildLocal && conf.ImageTag == "latest" {
+	if err := validateImageDigest(conf.ImageDigest); err != nil {
+		return TmplData{}, err
+	}
+
+	if err := validateThreshold(conf); err != nil {
+		return TmplData{}, err
+	}
+
+	if conf.DKGAlgorithm == "" {
+		conf.DKGAlgorithm = defaultDKGAlgo
+	} else if !supportedDKGAlgos[conf.DKGAlgorithm] {
+		return TmplData{}, errors.New("unsupported dkg algorithm", z.Str("dkg_algorithm", conf.DKGAlgorithm))
+	}
+
+	if conf.InsecureKeys && conf.KeyGen != KeyGenCreate {
+		return TmplData{}, errors.New("insecure keys only supported with keygen=create", z.Any("keygen", conf.KeyGen))
+	}
+
+	if !noPull && !conf.BuildLocal && conf.ImageDigest == "" && conf.ImageTag == "latest" {
 		if err := pullLatest(ctx); err != nil {
 			return TmplData{}, err
 		}
@@ -106,50 +131,40 @@ func Define(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			return TmplData{}, err
 		}
 
-		var enrs []string
-		for i, key := range p2pkeys {
-			// Best effort creation of folder, rather fail when saving p2pkey file next.
-			_ = os.MkdirAll(nodeFile(dir, i, ""), 0o755)
-
-			err := k1util.Save(key, nodeFile(dir, i, "charon-enr-private-key"))
-			if err != nil {
-				return TmplData{}, errors.Wrap(err, "save charon-enr-private-key")
-			}
-
-			record, err := enr.New(key)
-			if err != nil {
-				return TmplData{}, err
-			}
-			enrs = append(enrs, record.String())
+		enrs, err := saveP2PKeys(ctx, dir, p2pkeys)
+		if err != nil {
+			return TmplData{}, err
 		}
 
-		n := TmplNode{EnvVars: []kv{
+		n := TmplNode{EnvVars: withNodeEnvs(0, conf, []kv{
 			{"name", "compose"},
 			{"num_validators", fmt.Sprint(conf.NumValidators)},
 			{"operator_enrs", strings.Join(enrs, ",")},
 			{"threshold", fmt.Sprint(conf.Threshold)},
 			{"withdrawal_addresses", zeroXDead},
 			{"fee-recipient_addresses", zeroXDead},
-			{"dkg_algorithm", "frost"},
+			{"dkg_algorithm", conf.DKGAlgorithm},
 			{"output_dir", "/compose"},
-		}}
+		})}
 
 		data = TmplData{
-			ComposeDir:     dir,
-			CharonImageTag: conf.ImageTag,
-			CharonCommand:  cmdCreateDKG,
-			Nodes:          []TmplNode{n},
+			ComposeDir:        dir,
+			CharonImageTag:    conf.ImageTag,
+			CharonImageDigest: conf.ImageDigest,
+			CharonCommand:     cmdCreateDKG,
+			Nodes:             []TmplNode{n},
 		}
 	} else {
 		// Other keygens only need a noop docker-compose, since charon-compose.yml
 		// is used directly in their compose lock.
 
 		data = TmplData{
-			ComposeDir:       dir,
-			CharonImageTag:   conf.ImageTag,
-			CharonEntrypoint: "echo",
-			CharonCommand:    fmt.Sprintf("No charon commands needed for keygen=%s define step", conf.KeyGen),
-			Nodes:            []TmplNode{{}},
+			ComposeDir:        dir,
+			CharonImageTag:    conf.ImageTag,
+			CharonImageDigest: conf.ImageDigest,
+			CharonEntrypoint:  "echo",
+			CharonCommand:     fmt.Sprintf("No charon commands needed for keygen=%s define step", conf.KeyGen),
+			Nodes:             []TmplNode{{}},
 		}
 	}
 
@@ -186,6 +201,31 @@ func validateSplitKeysDir(dir string, spitKeysDir string) error {
 	return nil
 }
 
+// validateThreshold returns an error if the number of validators or the threshold is invalid for the number of nodes.
+func validateThreshold(conf Config) error {
+	if conf.NumValidators <= 0 {
+		return errors.New("num validators must be positive", z.Int("num_validators", conf.NumValidators))
+	} else if conf.Threshold <= 0 || conf.Threshold > conf.NumNodes {
+		return errors.New("threshold must be between 1 and number of nodes",
+			z.Int("threshold", conf.Threshold), z.Int("num_nodes", conf.NumNodes))
+	}
+
+	return nil
+}
+
+// validateImageDigest returns an error if the non-empty digest isn't of the form "sha256:<64 hex chars>".
+func validateImageDigest(digest string) error {
+	if digest == "" {
+		return nil
+	}
+
+	if !imageDigestRegex.MatchString(digest) {
+		return errors.New("invalid image digest, expected sha256:<hex>", z.Str("digest", digest))
+	}
+
+	return nil
+}
+
 // getRelSplitKeysDir returns the splitKeysDir as a relative path to dir.
 func getRelSplitKeysDir(dir, splitKeysDir string) (string, error) {
 	if splitKeysDir == "" {
@@ -224,55 +264,135 @@ func pullLatest(ctx context.Context) error {
 	return nil
 }
 
-// buildLocal builds an `obolnetwork/charon:local` docker container from source. Note this requires CHARON_REPO env var.
-func buildLocal(ctx context.Context) error {
+// buildAttempts is the maximum number of attempts to build the local docker container.
+const buildAttempts = 3
+
+// buildRetryDelay is the delay between build attempts, it can be overridden in tests.
+var buildRetryDelay = 5 * time.Second
+
+// transientBuildErrs are build output snippets indicating transient network errors.
+var transientBuildErrs = []string{
+	"i/o timeout",
+	"connection reset by peer",
+	"connection refused",
+	"TLS handshake timeout",
+	"temporary failure in name resolution",
+	"no such host",
+	"unexpected EOF",
+	"502 Bad Gateway",
+	"503 Service Unavailable",
+}
+
+// runBuildFunc runs docker with the args in the repo returning its combined output, it can be overridden in tests.
+var runBuildFunc = func(ctx context.Context, repo string, args []string) (string, error) {
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.Dir = repo
+
+	err := cmd.Run()
+
+	return out.String(), err
+}
+
+// buildLocal builds an `obolnetwork/charon:local` docker container from source for the configured target platform.
+// Note this requires CHARON_REPO env var. Builds failing due to transient network errors are retried.
+func buildLocal(ctx context.Context, conf Config) error {
 	repo, ok := os.LookupEnv("CHARON_REPO")
 	if !ok || repo == "" {
 		return errors.New("cannot build local charon binary; CHARON_REPO env var, the path to the charon repo, is not set")
 	}
 
-	log.Info(ctx, "Building `obolnetwork/charon:local` docker container", z.Str("repo", repo))
+	args, err := buildArgs(conf)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Building `obolnetwork/charon:local` docker container", z.Str("repo", repo), z.Str("args", strings.Join(args, " ")))
+
+	for attempt := 1; ; attempt++ {
+		out, err := runBuildFunc(ctx, repo, args) // Only log output if there is an error.
+		if err == nil {
+			return nil
+		} else if !isTransientBuildErr(out) {
+			return errors.Wrap(err, "exec docker build", z.Str("output", out))
+		} else if attempt >= buildAttempts {
+			return errors.Wrap(err, "exec docker build failed after retries",
+				z.Int("attempts", attempt), z.Str("output", out))
+		}
 
-	var out bytes.Buffer // Only log output if there is an error.
-	cmd := exec.CommandContext(ctx, "docker", "build", "-t", "obolnetwork/charon:local", ".")
-	cmd.Stdout = &out
-	cmd.Stderr = &out
-	cmd.Dir = repo
+		log.Warn(ctx, "Docker build failed with transient error, retrying", err, z.Int("attempt", attempt))
 
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(err, "exec docker build", z.Str("output", out.String()))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(buildRetryDelay):
+		}
 	}
+}
 
-	return nil
+// buildArgs returns the docker build args for the configured target platform.
+func buildArgs(conf Config) ([]string, error) {
+	goos, goarch := conf.BuildOS, conf.BuildArch
+	if goos == "" {
+		goos = defaultBuildOS
+	}
+	if goarch == "" {
+		goarch = defaultBuildArch
+	}
+
+	if !supportedBuildOSes[goos] {
+		return nil, errors.New("unsupported build os", z.Str("os", goos))
+	} else if !supportedBuildArches[goarch] {
+		return nil, errors.New("unsupported build arch", z.Str("arch", goarch))
+	}
+
+	return []string{"build", "--platform", goos + "/" + goarch, "-t", "obolnetwork/charon:local", "."}, nil
+}
+
+// isTransientBuildErr returns true if the build output indicates a transient network error.
+func isTransientBuildErr(output string) bool {
+	output = strings.ToLower(output)
+	for _, s := range transientBuildErrs {
+		if strings.Contains(output, strings.ToLower(s)) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // copyStaticFolders copies the embedded static folders to the compose dir.
 func copyStaticFolders(dir string) error {
-	const staticRoot = "static"
-	dirs, err := static.ReadDir(staticRoot)
+	return copyStaticFS(static, dir)
+}
+
+// staticRoot is the root folder of the static file system.
+const staticRoot = "static"
+
+// copyStaticFS copies the folders in the static root of the file system to the dir.
+func copyStaticFS(fsys fs.FS, dir string) error {
+	if err := validateStaticFS(fsys); err != nil {
+		return err
+	}
+
+	dirs, err := fs.ReadDir(fsys, staticRoot)
 	if err != nil {
 		return errors.Wrap(err, "read dirs")
 	}
 	for _, d := range dirs {
-		if !d.IsDir() {
-			return errors.New("static files not supported")
-		}
-
 		if err := os.MkdirAll(path.Join(dir, d.Name()), 0o755); err != nil {
 			return errors.Wrap(err, "mkdir all")
 		}
 
-		files, err := static.ReadDir(path.Join(staticRoot, d.Name()))
+		files, err := fs.ReadDir(fsys, path.Join(staticRoot, d.Name()))
 		if err != nil {
 			return errors.Wrap(err, "read files")
 		}
 
 		for _, f := range files {
-			if f.IsDir() {
-				return errors.New("child static dirs not supported")
-			}
-
-			b, err := static.ReadFile(path.Join(staticRoot, d.Name(), f.Name()))
+			b, err := fs.ReadFile(fsys, path.Join(staticRoot, d.Name(), f.Name()))
 			if err != nil {
 				return errors.Wrap(err, "read file")
 			}
@@ -291,6 +411,34 @@ func copyStaticFolders(dir string) error {
 	return nil
 }
 
+// validateStaticFS returns an error if the static root doesn't only contain
+// directories at the top level and files one level deep.
+func validateStaticFS(fsys fs.FS) error {
+	dirs, err := fs.ReadDir(fsys, staticRoot)
+	if err != nil {
+		return errors.Wrap(err, "read static root", z.Str("path", staticRoot))
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			return errors.New("static files not supported", z.Str("path", path.Join(staticRoot, d.Name())))
+		}
+
+		files, err := fs.ReadDir(fsys, path.Join(staticRoot, d.Name()))
+		if err != nil {
+			return errors.Wrap(err, "read static dir", z.Str("path", path.Join(staticRoot, d.Name())))
+		}
+
+		for _, f := range files {
+			if f.IsDir() {
+				return errors.New("child static dirs not supported", z.Str("path", path.Join(staticRoot, d.Name(), f.Name())))
+			}
+		}
+	}
+
+	return nil
+}
+
 // keyGenFunc can be overridden in tests for deterministic p2pkeys.
 var keyGenFunc = func() (*k1.PrivateKey, error) {
 	privkey, err := k1.GeneratePrivateKey()
@@ -315,6 +463,44 @@ func newP2PKeys(n int) ([]*k1.PrivateKey, error) {
 	return resp, nil
 }
 
+// saveP2PKeys concurrently saves the p2pkeys to their node folders and returns their ENRs in node order.
+func saveP2PKeys(ctx context.Context, dir string, p2pkeys []*k1.PrivateKey) ([]string, error) {
+	work := func(_ context.Context, i int) (string, error) {
+		// Best effort creation of folder, rather fail when saving p2pkey file next.
+		_ = os.MkdirAll(nodeFile(dir, i, ""), 0o755)
+
+		err := k1util.Save(p2pkeys[i], nodeFile(dir, i, "charon-enr-private-key"))
+		if err != nil {
+			return "", errors.Wrap(err, "save charon-enr-private-key")
+		}
+
+		record, err := enr.New(p2pkeys[i])
+		if err != nil {
+			return "", err
+		}
+
+		return record.String(), nil
+	}
+
+	fork, join, cancel := forkjoin.New(ctx, work, forkjoin.WithInputBuffer(len(p2pkeys)))
+	defer cancel()
+
+	for i := range p2pkeys {
+		fork(i)
+	}
+
+	// Results are returned in completion order, so index them by input.
+	enrs := make([]string, len(p2pkeys))
+	for result := range join() {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		enrs[result.Input] = result.Output
+	}
+
+	return enrs, nil
+}
+
 // nodeFile returns the path to a file in a node folder.
 func nodeFile(dir string, i int, file string) string {
 	return path.Join(dir, fmt.Sprintf("node%d", i), file)
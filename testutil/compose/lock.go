@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
@@ -31,7 +32,7 @@ func Lock(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		}
 
 		// Only single node to call charon create cluster generate keys
-		n := TmplNode{EnvVars: []kv{
+		n := TmplNode{EnvVars: withNodeEnvs(0, conf, []kv{
 			{"name", fmt.Sprintf("compose-%d-%d", conf.NumNodes, conf.NumValidators)},
 			{"threshold", fmt.Sprint(conf.Threshold)},
 			{"nodes", fmt.Sprint(conf.NumNodes)},
@@ -42,13 +43,14 @@ func Lock(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			{"insecure-keys", fmt.Sprintf(`"%v"`, conf.InsecureKeys)},
 			{"withdrawal-addresses", zeroXDead},
 			{"fee-recipient-addresses", zeroXDead},
-		}}
+		})}
 
 		data = TmplData{
-			ComposeDir:     dir,
-			CharonImageTag: conf.ImageTag,
-			CharonCommand:  cmdCreateCluster,
-			Nodes:          []TmplNode{n},
+			ComposeDir:        dir,
+			CharonImageTag:    conf.ImageTag,
+			CharonImageDigest: conf.ImageDigest,
+			CharonCommand:     cmdCreateCluster,
+			Nodes:             []TmplNode{n},
 		}
 	case KeyGenDKG:
 
@@ -63,11 +65,12 @@ func Lock(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		}
 
 		data = TmplData{
-			ComposeDir:     dir,
-			CharonImageTag: conf.ImageTag,
-			CharonCommand:  "not used",
-			Relay:          true,
-			Nodes:          nodes,
+			ComposeDir:        dir,
+			CharonImageTag:    conf.ImageTag,
+			CharonImageDigest: conf.ImageDigest,
+			CharonCommand:     "not used",
+			Relay:             true,
+			Nodes:             nodes,
 		}
 	default:
 		return TmplData{}, errors.New("unsupported keygen", z.Any("keygen", conf.KeyGen))
@@ -117,15 +120,14 @@ func newNodeEnvs(index int, conf Config, vcType VCType) []kv {
 
 	if conf.Step == stepDefined {
 		// Define lock config
-		return append(kvs,
+		return withNodeEnvs(index, conf, append(kvs,
 			kv{"data-dir", fmt.Sprintf("/compose/node%d", index)},
 			kv{"definition-file", "/compose/cluster-definition.json"},
-			kv{"insecure-keys", fmt.Sprintf(`"%v"`, conf.InsecureKeys)},
-		)
+		))
 	}
 
 	// Define run config
-	return append(kvs,
+	return withNodeEnvs(index, conf, append(kvs,
 		kv{"jaeger-service", fmt.Sprintf("node%d", index)},
 		kv{"jaeger-address", "jaeger:6831"},
 		kv{"lock-file", lockFile},
@@ -138,7 +140,40 @@ func newNodeEnvs(index int, conf Config, vcType VCType) []kv {
 		kv{"loki-addresses", "http://loki:3100/loki/api/v1/push"},
 		kv{"loki-service", fmt.Sprintf("node%d", index)},
 		kv{"synthetic-block-proposals", fmt.Sprintf(`"%v"`, conf.SyntheticBlockProposals)},
-	)
+	))
+}
+
+// withNodeEnvs returns the node environment variables with the configured per-node overrides applied.
+// Overrides replace existing variables with the same env key, others are appended in sorted order.
+func withNodeEnvs(index int, conf Config, kvs []kv) []kv {
+	overrides := conf.NodeEnvs[index]
+	if len(overrides) == 0 {
+		return kvs
+	}
+
+	var keys []string
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		override := kv{Key: key, Value: overrides[key]}
+
+		var replaced bool
+		for i := range kvs {
+			if kvs[i].EnvKey() == override.EnvKey() {
+				kvs[i].Value = override.Value
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			kvs = append(kvs, override)
+		}
+	}
+
+	return kvs
 }
 
 // LoadConfig returns the config loaded from disk.
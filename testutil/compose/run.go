@@ -42,14 +42,15 @@ func Run(ctx context.Context, dir string, conf Config) (TmplData, error) {
 	}
 
 	data := TmplData{
-		ComposeDir:      dir,
-		CharonImageTag:  conf.ImageTag,
-		CharonCommand:   cmdRun,
-		Nodes:           nodes,
-		Relay:           true,
-		Monitoring:      true,
-		MonitoringPorts: !conf.DisableMonitoringPorts,
-		VCs:             vcs,
+		ComposeDir:        dir,
+		CharonImageTag:    conf.ImageTag,
+		CharonImageDigest: conf.ImageDigest,
+		CharonCommand:     cmdRun,
+		Nodes:             nodes,
+		Relay:             true,
+		Monitoring:        true,
+		MonitoringPorts:   !conf.DisableMonitoringPorts,
+		VCs:               vcs,
 	}
 
 	log.Info(ctx, "Created docker-compose.yml")
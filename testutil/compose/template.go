@@ -23,9 +23,10 @@ var static embed.FS
 type TmplData struct {
 	ComposeDir string
 
-	CharonImageTag   string
-	CharonEntrypoint string
-	CharonCommand    string
+	CharonImageTag    string
+	CharonImageDigest string // CharonImageDigest is empty by default, resulting in CharonImageTag being used.
+	CharonEntrypoint  string
+	CharonCommand     string
 
 	Nodes []TmplNode
 	VCs   []TmplVC
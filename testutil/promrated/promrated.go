@@ -26,8 +26,8 @@ type Config struct {
 // Run blocks running the promrated program until the context is canceled or a fatal error occurs.
 func Run(ctx context.Context, config Config) error {
 	log.Info(ctx, "Promrated started",
-		z.Str("rated_endpoint", config.RatedEndpoint), // TODO(corver): This may contain a password
-		z.Str("prom_auth", config.PromAuth),           // TODO(corver): This may contain a password
+		z.Str("rated_endpoint", log.Redact("rated_endpoint", config.RatedEndpoint)),
+		log.Secret("prom_auth", config.PromAuth),
 		z.Str("monitoring_addr", config.MonitoringAddr),
 	)
 